@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"newsapi/internal/di"
+	"newsapi/internal/model/request"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// runImport loads articles from an NDJSON or CSV file acting as an existing
+// user, prints the import report as JSON and fails when any line failed
+func runImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := flags.String("file", "", "NDJSON or CSV file to import, - reads stdin")
+	format := flags.String("format", "", "ndjson or csv, taken from the file extension when empty")
+	userID := flags.Int("user", 0, "id of the user the articles are imported as")
+	dryRun := flags.Bool("dry-run", false, "check every line without writing")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *file == "" || *userID == 0 {
+		flags.Usage()
+		return errors.New("import needs -file and -user")
+	}
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(*file)) {
+		case ".csv":
+			*format = request.ImportFormatCSV
+		case ".ndjson", ".jsonl":
+			*format = request.ImportFormatNDJSON
+		}
+	}
+
+	var input io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	app := di.InitApp()
+	actor, err := app.AuthUsecase.Actor(ctx, *userID)
+	if err != nil {
+		return fmt.Errorf("user %d: %w", *userID, err)
+	}
+
+	reader, err := request.NewNewsImportReader(input, *format, app.Validator)
+	if err != nil {
+		return err
+	}
+
+	report, importErr := app.NewsUsecase.ImportNews(ctx, actor, reader, *dryRun)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if importErr != nil {
+		return importErr
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d lines failed", report.Failed, report.Total)
+	}
+
+	return nil
+}
@@ -1,15 +1,46 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"newsapi/internal/di"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
-	server := di.InitHTTPServer()
-	server.ConnectCoreWithEcho()
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal("import failed: ", err.Error())
+		}
+		return
+	}
+
+	app := di.InitApp()
+	app.HttpServer.ConnectCoreWithEcho()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go app.ScheduledPublisher.Run(ctx)
+	go app.TrashPurger.Run(ctx)
+
+	go func() {
+		if err := app.HttpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("failed to serve http:", err.Error())
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatal("failed to serve http:", err.Error())
+	if err := app.HttpServer.Shutdown(shutdownCtx); err != nil {
+		log.Fatal("failed to shutdown http server:", err.Error())
 	}
 }
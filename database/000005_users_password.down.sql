@@ -0,0 +1,5 @@
+begin;
+
+ALTER TABLE users DROP COLUMN IF EXISTS password;
+
+commit;
\ No newline at end of file
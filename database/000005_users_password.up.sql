@@ -0,0 +1,5 @@
+begin;
+
+ALTER TABLE users ADD COLUMN password VARCHAR(255);
+
+commit;
\ No newline at end of file
@@ -0,0 +1,6 @@
+begin;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+drop type if exists user_role;
+
+commit;
\ No newline at end of file
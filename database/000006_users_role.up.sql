@@ -0,0 +1,7 @@
+begin;
+
+CREATE TYPE user_role AS ENUM ('admin', 'editor', 'author', 'reader');
+
+ALTER TABLE users ADD COLUMN role user_role NOT NULL DEFAULT 'reader';
+
+commit;
\ No newline at end of file
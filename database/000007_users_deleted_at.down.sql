@@ -0,0 +1,6 @@
+begin;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS updated_at;
+
+commit;
\ No newline at end of file
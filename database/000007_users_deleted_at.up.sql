@@ -0,0 +1,6 @@
+begin;
+
+ALTER TABLE users ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,5 @@
+begin;
+
+DROP INDEX IF EXISTS idx_news_articles_publish_order;
+
+commit;
\ No newline at end of file
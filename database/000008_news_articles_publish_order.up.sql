@@ -0,0 +1,5 @@
+begin;
+
+CREATE INDEX idx_news_articles_publish_order ON news_articles ((COALESCE(published_at, created_at)) DESC, id DESC) WHERE deleted_at IS NULL;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,6 @@
+begin;
+
+DROP INDEX IF EXISTS idx_news_articles_search_vector;
+ALTER TABLE news_articles DROP COLUMN IF EXISTS search_vector;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,11 @@
+begin;
+
+ALTER TABLE news_articles ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_news_articles_search_vector ON news_articles USING GIN (search_vector);
+
+commit;
\ No newline at end of file
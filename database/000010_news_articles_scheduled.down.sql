@@ -0,0 +1,16 @@
+begin;
+
+UPDATE news_articles SET status = 'draft' WHERE status = 'scheduled';
+
+DROP INDEX IF EXISTS idx_news_articles_publish_at;
+ALTER TABLE news_articles DROP COLUMN IF EXISTS publish_at;
+
+ALTER TYPE article_status RENAME TO article_status_old;
+CREATE TYPE article_status AS ENUM ('draft', 'published', 'deleted');
+ALTER TABLE news_articles
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE article_status USING status::text::article_status,
+    ALTER COLUMN status SET DEFAULT 'draft';
+DROP TYPE article_status_old;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,10 @@
+begin;
+
+ALTER TYPE article_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+ALTER TABLE news_articles ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE;
+
+-- only scheduled articles carry publish_at, the publisher scans due rows by it
+CREATE INDEX idx_news_articles_publish_at ON news_articles(publish_at) WHERE publish_at IS NOT NULL AND deleted_at IS NULL;
+
+commit;
\ No newline at end of file
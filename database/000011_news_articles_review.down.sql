@@ -0,0 +1,18 @@
+begin;
+
+UPDATE news_articles SET status = 'draft' WHERE status IN ('in_review', 'approved');
+
+DROP INDEX IF EXISTS idx_news_articles_reviewer_id;
+ALTER TABLE news_articles
+    DROP COLUMN IF EXISTS reviewer_id,
+    DROP COLUMN IF EXISTS review_comment;
+
+ALTER TYPE article_status RENAME TO article_status_old;
+CREATE TYPE article_status AS ENUM ('draft', 'published', 'deleted', 'scheduled');
+ALTER TABLE news_articles
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE article_status USING status::text::article_status,
+    ALTER COLUMN status SET DEFAULT 'draft';
+DROP TYPE article_status_old;
+
+commit;
\ No newline at end of file
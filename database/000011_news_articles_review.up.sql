@@ -0,0 +1,12 @@
+begin;
+
+ALTER TYPE article_status ADD VALUE IF NOT EXISTS 'in_review';
+ALTER TYPE article_status ADD VALUE IF NOT EXISTS 'approved';
+
+ALTER TABLE news_articles
+    ADD COLUMN reviewer_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN review_comment TEXT;
+
+CREATE INDEX idx_news_articles_reviewer_id ON news_articles(reviewer_id) WHERE reviewer_id IS NOT NULL;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,5 @@
+begin;
+
+DROP TABLE IF EXISTS article_revisions;
+
+commit;
\ No newline at end of file
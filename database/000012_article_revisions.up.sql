@@ -0,0 +1,26 @@
+begin;
+
+CREATE TABLE article_revisions (
+    id SERIAL PRIMARY KEY,
+    news_article_id INTEGER NOT NULL REFERENCES news_articles(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    summary TEXT,
+    slug VARCHAR(255) NOT NULL,
+    topic_ids INTEGER[] NOT NULL DEFAULT '{}',
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(news_article_id, revision)
+);
+
+CREATE INDEX idx_article_revisions_editor_id ON article_revisions(editor_id);
+
+-- existing articles start their history from their current state
+INSERT INTO article_revisions (news_article_id, revision, title, content, summary, slug, topic_ids, editor_id, created_at)
+SELECT na.id, 1, na.title, na.content, na.summary, na.slug,
+    COALESCE(ARRAY(SELECT nt.topic_id FROM news_topics nt WHERE nt.news_article_id = na.id AND nt.deleted_at IS NULL ORDER BY nt.topic_id), '{}'),
+    na.author_id, na.updated_at
+FROM news_articles na;
+
+commit;
\ No newline at end of file
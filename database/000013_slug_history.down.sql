@@ -0,0 +1,6 @@
+begin;
+
+DROP TABLE IF EXISTS topic_slugs;
+DROP TABLE IF EXISTS news_article_slugs;
+
+commit;
\ No newline at end of file
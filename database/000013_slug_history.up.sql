@@ -0,0 +1,28 @@
+begin;
+
+CREATE TABLE news_article_slugs (
+    id SERIAL PRIMARY KEY,
+    news_article_id INTEGER NOT NULL REFERENCES news_articles(id) ON DELETE CASCADE,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_news_article_slugs_news_article_id ON news_article_slugs(news_article_id);
+
+CREATE TABLE topic_slugs (
+    id SERIAL PRIMARY KEY,
+    topic_id INTEGER NOT NULL REFERENCES topics(id) ON DELETE CASCADE,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_topic_slugs_topic_id ON topic_slugs(topic_id);
+
+-- slugs replaced since revisions are recorded
+INSERT INTO news_article_slugs (news_article_id, slug)
+SELECT DISTINCT ar.news_article_id, ar.slug
+FROM article_revisions ar
+WHERE NOT EXISTS (SELECT 1 FROM news_articles na WHERE na.slug = ar.slug)
+ON CONFLICT (slug) DO NOTHING;
+
+commit;
\ No newline at end of file
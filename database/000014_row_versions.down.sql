@@ -0,0 +1,6 @@
+begin;
+
+ALTER TABLE topics DROP COLUMN IF EXISTS version;
+ALTER TABLE news_articles DROP COLUMN IF EXISTS version;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,7 @@
+begin;
+
+-- bumped on every write, exposed as the ETag of articles and topics
+ALTER TABLE news_articles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE topics ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+commit;
\ No newline at end of file
@@ -0,0 +1,6 @@
+begin;
+
+DROP INDEX IF EXISTS idx_topics_parent_id;
+ALTER TABLE topics DROP COLUMN IF EXISTS parent_id;
+
+commit;
\ No newline at end of file
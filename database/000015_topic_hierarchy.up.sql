@@ -0,0 +1,9 @@
+begin;
+
+-- purging a parent turns its children into top level topics
+ALTER TABLE topics ADD COLUMN parent_id INTEGER REFERENCES topics(id) ON DELETE SET NULL;
+ALTER TABLE topics ADD CONSTRAINT topics_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_topics_parent_id ON topics(parent_id);
+
+commit;
\ No newline at end of file
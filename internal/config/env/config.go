@@ -1,6 +1,7 @@
 package env
 
 import (
+	"errors"
 	"newsapi/internal/utils"
 	"sync"
 	"time"
@@ -18,6 +19,12 @@ type ApplicationConfig struct {
 	Port    string
 	Service string
 	Timeout time.Duration
+	// ExportTimeout replaces Timeout for the news export, which streams for
+	// as long as the rows take to read
+	ExportTimeout time.Duration
+	// ImportTimeout replaces Timeout for the news import, which commits batch
+	// after batch for as long as the file takes to read
+	ImportTimeout time.Duration
 }
 
 type DatabaseConfig struct {
@@ -31,9 +38,52 @@ type DatabaseConfig struct {
 	MaxLifetime  time.Duration
 }
 
+type AuthConfig struct {
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// WorkerConfig tunes the background jobs running next to the http server
+type WorkerConfig struct {
+	PublishInterval     time.Duration
+	PublishBatchSize    int
+	TrashPurgeInterval  time.Duration
+	TrashRetention      time.Duration
+	TrashPurgeBatchSize int
+}
+
 type Config struct {
 	ApplicationConfig ApplicationConfig
 	DatabaseConfig    DatabaseConfig
+	AuthConfig        AuthConfig
+	WorkerConfig      WorkerConfig
+}
+
+// minJWTSecretLength is the shortest HMAC secret accepted, HS256 needs 32 bytes
+// of key to be as strong as its hash
+const minJWTSecretLength = 32
+
+// Validate reports settings the service cannot safely run with
+func (c *Config) Validate() error {
+	if len(c.AuthConfig.JWTSecret) < minJWTSecretLength {
+		return errors.New("JWT_SECRET must be set to at least 32 bytes")
+	}
+	if c.WorkerConfig.PublishInterval <= 0 {
+		return errors.New("SCHEDULED_PUBLISH_INTERVAL must be a positive duration")
+	}
+	if c.WorkerConfig.PublishBatchSize <= 0 {
+		return errors.New("SCHEDULED_PUBLISH_BATCH_SIZE must be a positive number")
+	}
+	// a non positive retention turns the purge off, its other settings are unused then
+	if c.WorkerConfig.TrashRetention > 0 && c.WorkerConfig.TrashPurgeInterval <= 0 {
+		return errors.New("TRASH_PURGE_INTERVAL must be a positive duration")
+	}
+	if c.WorkerConfig.TrashRetention > 0 && c.WorkerConfig.TrashPurgeBatchSize <= 0 {
+		return errors.New("TRASH_PURGE_BATCH_SIZE must be a positive number")
+	}
+
+	return nil
 }
 
 func BuildConfig() *Config {
@@ -43,10 +93,12 @@ func BuildConfig() *Config {
 		godotenv.Load(".env")
 
 		config.ApplicationConfig = ApplicationConfig{
-			Host:    utils.GetStringEnv("SERVICE_HOST", "0.0.0.0"),
-			Port:    utils.GetStringEnv("SERVICE_PORT", "8080"),
-			Service: utils.GetStringEnv("SERVICE_NAME", "echouser"),
-			Timeout: utils.GetDurationEnv("SERVICE_TIMEOUT", "30000ms"),
+			Host:          utils.GetStringEnv("SERVICE_HOST", "0.0.0.0"),
+			Port:          utils.GetStringEnv("SERVICE_PORT", "8080"),
+			Service:       utils.GetStringEnv("SERVICE_NAME", "echouser"),
+			Timeout:       utils.GetDurationEnv("SERVICE_TIMEOUT", "30000ms"),
+			ExportTimeout: utils.GetDurationEnv("EXPORT_TIMEOUT", "30m"),
+			ImportTimeout: utils.GetDurationEnv("IMPORT_TIMEOUT", "30m"),
 		}
 
 		config.DatabaseConfig = DatabaseConfig{
@@ -59,6 +111,20 @@ func BuildConfig() *Config {
 			MaxIdleConns: utils.GetIntEnv("POSTGRES_DB_MAX_IDLE_CONNECTION", 5),
 			MaxLifetime:  utils.GetDurationEnv("POSTGRES_DB_MAX_LIFETIME", "30m"),
 		}
+
+		config.AuthConfig = AuthConfig{
+			JWTSecret:       utils.GetStringEnv("JWT_SECRET", ""),
+			AccessTokenTTL:  utils.GetDurationEnv("JWT_ACCESS_TOKEN_TTL", "15m"),
+			RefreshTokenTTL: utils.GetDurationEnv("JWT_REFRESH_TOKEN_TTL", "168h"),
+		}
+
+		config.WorkerConfig = WorkerConfig{
+			PublishInterval:     utils.GetDurationEnv("SCHEDULED_PUBLISH_INTERVAL", "30s"),
+			PublishBatchSize:    utils.GetIntEnv("SCHEDULED_PUBLISH_BATCH_SIZE", 100),
+			TrashPurgeInterval:  utils.GetDurationEnv("TRASH_PURGE_INTERVAL", "1h"),
+			TrashRetention:      utils.GetDurationEnv("TRASH_RETENTION", "720h"),
+			TrashPurgeBatchSize: utils.GetIntEnv("TRASH_PURGE_BATCH_SIZE", 100),
+		}
 	})
 
 	return config
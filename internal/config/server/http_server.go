@@ -1,11 +1,13 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"newsapi/internal/config/env"
 	"newsapi/internal/handler"
 	"newsapi/internal/middleware"
 	"newsapi/internal/routing"
+	"newsapi/internal/usecase"
 
 	"github.com/labstack/echo/v4"
 )
@@ -14,11 +16,13 @@ type HttpServer struct {
 	echo    *echo.Echo
 	config  *env.Config
 	handler handler.HandlerRegistry
+	authUC  usecase.AuthUsecase
 }
 
 func NewHttpServer(
 	config *env.Config,
 	handler handler.HandlerRegistry,
+	authUC usecase.AuthUsecase,
 ) *HttpServer {
 	e := echo.New()
 	middleware.SetupGlobalMiddleware(e, config.ApplicationConfig)
@@ -27,6 +31,7 @@ func NewHttpServer(
 		echo:    e,
 		config:  config,
 		handler: handler,
+		authUC:  authUC,
 	}
 
 	return server
@@ -37,7 +42,14 @@ func (s *HttpServer) ListenAndServe() error {
 	return s.echo.Start(serviceUrl)
 }
 
+// Shutdown stops accepting connections and waits for in-flight requests until ctx is done
+func (s *HttpServer) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
 func (s *HttpServer) ConnectCoreWithEcho() {
-	appRoutes := routing.NewAppRoutes(s.echo, s.handler)
+	auth := middleware.Authenticate(s.config.AuthConfig, s.authUC)
+	optionalAuth := middleware.AuthenticateOptional(s.config.AuthConfig, s.authUC)
+	appRoutes := routing.NewAppRoutes(s.echo, s.handler, auth, optionalAuth)
 	appRoutes.RegisterRoute()
 }
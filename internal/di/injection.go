@@ -1,23 +1,32 @@
 package di
 
 import (
+	"log"
 	"newsapi/internal/config/db"
 	"newsapi/internal/config/env"
 	"newsapi/internal/config/server"
 	"newsapi/internal/handler"
+	"newsapi/internal/model/request"
 	"newsapi/internal/repository"
 	"newsapi/internal/usecase"
+	"newsapi/internal/worker"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/jmoiron/sqlx"
 )
 
 func provideValidator() *validator.Validate {
-	return validator.New()
+	v := validator.New()
+	request.RegisterValidations(v)
+	return v
 }
 
 func provideConfig() *env.Config {
-	return env.BuildConfig()
+	config := env.BuildConfig()
+	if err := config.Validate(); err != nil {
+		log.Fatal("invalid config: ", err.Error())
+	}
+	return config
 }
 
 func provideDB(config env.DatabaseConfig) *sqlx.DB {
@@ -40,19 +49,46 @@ func provideNewsTopicsRepository(db *sqlx.DB) repository.NewsTopicsRepository {
 	return repository.NewNewsTopicsRepository(db)
 }
 
+func provideArticleRevisionsRepository(db *sqlx.DB) repository.ArticleRevisionsRepository {
+	return repository.NewArticleRevisionsRepository(db)
+}
+
+func provideTransactor(db *sqlx.DB) repository.Transactor {
+	return repository.NewTransactor(db)
+}
+
 func provideUsersUsecase(repos repository.UsersRepository) usecase.UsersUsecase {
 	return usecase.NewUsersUsecase(repos)
 }
 
-func provideTopicsUsecase(repos repository.TopicsRepository) usecase.TopicsUsecase {
-	return usecase.NewTopicsUsecase(repos)
+func provideAuthUsecase(repos repository.UsersRepository, config env.AuthConfig) usecase.AuthUsecase {
+	return usecase.NewAuthUsecase(repos, config)
+}
+
+func provideTopicsUsecase(
+	repos repository.TopicsRepository,
+	newsTopics repository.NewsTopicsRepository,
+	transactor repository.Transactor,
+) usecase.TopicsUsecase {
+	return usecase.NewTopicsUsecase(repos, newsTopics, transactor)
 }
 
 func provideNewsUsecase(
 	newsArticles repository.NewsArticlesRepository,
 	newsTopics repository.NewsTopicsRepository,
+	topics repository.TopicsRepository,
+	users repository.UsersRepository,
+	articleRevisions repository.ArticleRevisionsRepository,
+	transactor repository.Transactor,
 ) usecase.NewsUsecase {
-	return usecase.NewNewsArticlesUsecase(newsArticles, newsTopics)
+	return usecase.NewNewsArticlesUsecase(newsArticles, newsTopics, topics, users, articleRevisions, transactor)
+}
+
+func provideAuthHandler(
+	validator *validator.Validate,
+	uc usecase.AuthUsecase,
+) handler.AuthHandler {
+	return handler.NewAuthHandler(validator, uc)
 }
 
 func provideUsersHandler(
@@ -76,18 +112,42 @@ func provideNewsHandler(validator *validator.Validate,
 }
 
 func provideHandlerRegistry(
+	authHandler handler.AuthHandler,
 	usersHandler handler.UsersHandler,
 	topicsHandler handler.TopicsHandler,
 	newsHandler handler.NewsHandler,
 ) handler.HandlerRegistry {
-	return handler.NewHandlerRegistry(usersHandler, topicsHandler, newsHandler)
+	return handler.NewHandlerRegistry(authHandler, usersHandler, topicsHandler, newsHandler)
+}
+
+func provideHttpServer(env *env.Config, handler handler.HandlerRegistry, authUC usecase.AuthUsecase) *server.HttpServer {
+	return server.NewHttpServer(env, handler, authUC)
+}
+
+func provideScheduledPublisher(uc usecase.NewsUsecase, config env.WorkerConfig) *worker.ScheduledPublisher {
+	return worker.NewScheduledPublisher(uc, config)
+}
+
+func provideTrashPurger(
+	newsUC usecase.NewsUsecase,
+	topicsUC usecase.TopicsUsecase,
+	config env.WorkerConfig,
+) *worker.TrashPurger {
+	return worker.NewTrashPurger(newsUC, topicsUC, config)
 }
 
-func provideHttpServer(env *env.Config, handler handler.HandlerRegistry) *server.HttpServer {
-	return server.NewHttpServer(env, handler)
+// App holds the http server and the background workers sharing its dependencies,
+// the command line tools reach the usecases directly
+type App struct {
+	HttpServer         *server.HttpServer
+	ScheduledPublisher *worker.ScheduledPublisher
+	TrashPurger        *worker.TrashPurger
+	Validator          *validator.Validate
+	AuthUsecase        usecase.AuthUsecase
+	NewsUsecase        usecase.NewsUsecase
 }
 
-func InitHTTPServer() *server.HttpServer {
+func InitApp() App {
 	validator := provideValidator()
 	config := provideConfig()
 	sqlClient := provideDB(config.DatabaseConfig)
@@ -95,14 +155,27 @@ func InitHTTPServer() *server.HttpServer {
 	topicsRepo := provideTopicsRepository(sqlClient)
 	newsArticlesRepo := provideNewsArticlesRepository(sqlClient)
 	newsTopicsRepo := provideNewsTopicsRepository(sqlClient)
+	articleRevisionsRepo := provideArticleRevisionsRepository(sqlClient)
+	transactor := provideTransactor(sqlClient)
 	usersUC := provideUsersUsecase(usersRepo)
-	topicsUC := provideTopicsUsecase(topicsRepo)
-	newsUC := provideNewsUsecase(newsArticlesRepo, newsTopicsRepo)
+	authUC := provideAuthUsecase(usersRepo, config.AuthConfig)
+	topicsUC := provideTopicsUsecase(topicsRepo, newsTopicsRepo, transactor)
+	newsUC := provideNewsUsecase(newsArticlesRepo, newsTopicsRepo, topicsRepo, usersRepo, articleRevisionsRepo, transactor)
+	authHandler := provideAuthHandler(validator, authUC)
 	usersHandler := provideUsersHandler(validator, usersUC)
 	topicsHandler := provideTopicsHandler(validator, topicsUC)
 	newsHandler := provideNewsHandler(validator, newsUC)
-	handlerRegistry := provideHandlerRegistry(usersHandler, topicsHandler, newsHandler)
-	httpServer := provideHttpServer(config, handlerRegistry)
-
-	return httpServer
+	handlerRegistry := provideHandlerRegistry(authHandler, usersHandler, topicsHandler, newsHandler)
+	httpServer := provideHttpServer(config, handlerRegistry, authUC)
+	scheduledPublisher := provideScheduledPublisher(newsUC, config.WorkerConfig)
+	trashPurger := provideTrashPurger(newsUC, topicsUC, config.WorkerConfig)
+
+	return App{
+		HttpServer:         httpServer,
+		ScheduledPublisher: scheduledPublisher,
+		TrashPurger:        trashPurger,
+		Validator:          validator,
+		AuthUsecase:        authUC,
+		NewsUsecase:        newsUC,
+	}
 }
@@ -1,5 +1,7 @@
 package exception
 
+import "fmt"
+
 var (
 	ErrFailedInsertNews      = CustomError{Code: 20001, Message: "failed insert news"}
 	ErrNewsNotFound          = CustomError{Code: 20002, Message: "news not found"}
@@ -8,4 +10,39 @@ var (
 	ErrFailedUpdateTopicNews = CustomError{Code: 20005, Message: "failed update topic news"}
 	ErrFailedDeleteNews      = CustomError{Code: 20006, Message: "failed delete news"}
 	ErrFailedDeleteTopicNews = CustomError{Code: 20007, Message: "failed delete topic news"}
+	ErrAuthorNotFound        = CustomError{Code: 20008, Message: "author not found"}
+	ErrInvalidPublishAt      = CustomError{Code: 20009, Message: "scheduled news require a future publish_at"}
+	ErrPublishAtNotScheduled = CustomError{Code: 20010, Message: "publish_at is only allowed for scheduled news"}
+	ErrFailedPublishNews     = CustomError{Code: 20011, Message: "failed publish scheduled news"}
+	ErrInvalidTransition     = CustomError{Code: 20012, Message: "invalid news status transition"}
+	ErrReviewerNotFound      = CustomError{Code: 20013, Message: "reviewer not found"}
+	ErrInvalidReviewer       = CustomError{Code: 20014, Message: "reviewer must be an editor or admin other than the author"}
+	ErrReviewCommentRequired = CustomError{Code: 20015, Message: "rejecting news requires a comment"}
+	ErrReviewerNotAllowed    = CustomError{Code: 20016, Message: "reviewer_id can only be set when submitting news for review"}
+	ErrFailedTransitionNews  = CustomError{Code: 20017, Message: "failed change news status"}
+	ErrRevisionNotFound      = CustomError{Code: 20018, Message: "revision not found"}
+	ErrFailedGetRevision     = CustomError{Code: 20019, Message: "failed get revision"}
+	ErrFailedRecordRevision  = CustomError{Code: 20020, Message: "failed record news revision"}
+	ErrSlugRetired           = CustomError{Code: 20021, Message: "slug was used by another news and cannot be reused"}
+	ErrNewsNotInTrash        = CustomError{Code: 20022, Message: "news not found in trash"}
+	ErrFailedRestoreNews     = CustomError{Code: 20023, Message: "failed restore news"}
+	ErrFailedPurgeNews       = CustomError{Code: 20024, Message: "failed purge news"}
+	ErrNewsVersionMismatch   = CustomError{Code: 20025, Message: "news was changed since it was read"}
+	ErrInvalidTopicIDs       = CustomError{Code: 20026, Message: "one or more topic IDs are invalid"}
+	ErrFailedBulkNews        = CustomError{Code: 20027, Message: "failed apply bulk news action"}
+	ErrBulkNewsAborted       = CustomError{Code: 20028, Message: "bulk news action aborted, no news was changed"}
+	ErrUnknownTopicSlug      = CustomError{Code: 20029, Message: "one or more topic slugs are unknown"}
+	ErrSlugTaken             = CustomError{Code: 20030, Message: "slug is already used by another news"}
+	ErrFailedImportNews      = CustomError{Code: 20031, Message: "failed import news, lines before the failed batch were imported"}
+	ErrFailedReadImport      = CustomError{Code: 20032, Message: "failed read import, lines before the failure were imported"}
+	ErrFailedExportNews      = CustomError{Code: 20033, Message: "failed export news"}
 )
+
+// NewInvalidTransitionError reports a status change the editorial workflow does
+// not allow, it keeps the ErrInvalidTransition code
+func NewInvalidTransitionError(from, to string) CustomError {
+	return CustomError{
+		Code:    ErrInvalidTransition.Code,
+		Message: fmt.Sprintf("cannot move news from %s to %s", from, to),
+	}
+}
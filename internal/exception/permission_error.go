@@ -0,0 +1,5 @@
+package exception
+
+var (
+	ErrPermissionDenied = CustomError{Code: 30001, Message: "you do not have permission to perform this action"}
+)
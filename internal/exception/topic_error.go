@@ -1,10 +1,34 @@
 package exception
 
+import "fmt"
+
 var (
-	ErrFailedInsertTopic = CustomError{Code: 20001, Message: "failed insert topic"}
-	ErrTopicNotFound     = CustomError{Code: 20002, Message: "topic not found"}
-	ErrFailedGetTopic    = CustomError{Code: 20003, Message: "failed get topic"}
-	ErrFailedUpdateTopic = CustomError{Code: 20004, Message: "failed update topic"}
-	ErrNoFieldUpdate     = CustomError{Code: 20005, Message: "no field update"}
-	ErrFailedDeleteTopic = CustomError{Code: 20006, Message: "failed delete topic"}
+	ErrFailedInsertTopic    = CustomError{Code: 20001, Message: "failed insert topic"}
+	ErrTopicNotFound        = CustomError{Code: 20002, Message: "topic not found"}
+	ErrFailedGetTopic       = CustomError{Code: 20003, Message: "failed get topic"}
+	ErrFailedUpdateTopic    = CustomError{Code: 20004, Message: "failed update topic"}
+	ErrNoFieldUpdate        = CustomError{Code: 20005, Message: "no field update"}
+	ErrFailedDeleteTopic    = CustomError{Code: 20006, Message: "failed delete topic"}
+	ErrTopicSlugRetired     = CustomError{Code: 20007, Message: "slug was used by another topic and cannot be reused"}
+	ErrTopicNotInTrash      = CustomError{Code: 20008, Message: "topic not found in trash"}
+	ErrFailedRestoreTopic   = CustomError{Code: 20009, Message: "failed restore topic"}
+	ErrFailedPurgeTopic     = CustomError{Code: 20010, Message: "failed purge topic"}
+	ErrTopicVersionMismatch = CustomError{Code: 20011, Message: "topic was changed since it was read"}
+	ErrParentTopicNotFound  = CustomError{Code: 20012, Message: "parent topic not found"}
+	ErrTopicCycle           = CustomError{Code: 20013, Message: "topic cannot be moved below itself or one of its subtopics"}
+	ErrTopicMergeSelf       = CustomError{Code: 20014, Message: "topic cannot be merged into itself"}
+	ErrMergeTargetNotFound  = CustomError{Code: 20015, Message: "merge target topic not found"}
+	ErrTopicMergeSubtopic   = CustomError{Code: 20016, Message: "topic cannot be merged into one of its subtopics"}
+	ErrFailedMergeTopic     = CustomError{Code: 20017, Message: "failed merge topic"}
+	ErrTopicInUse           = CustomError{Code: 20018, Message: "topic is used by published news"}
+	ErrTopicMerged          = CustomError{Code: 20019, Message: "topic was merged into another topic and cannot be restored"}
 )
+
+// NewTopicInUseError reports how many published articles keep a topic from
+// being deleted, it keeps the ErrTopicInUse code
+func NewTopicInUseError(articles int) CustomError {
+	return CustomError{
+		Code:    ErrTopicInUse.Code,
+		Message: fmt.Sprintf("topic is used by %d published news, delete with force=true to detach them", articles),
+	}
+}
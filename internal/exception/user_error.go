@@ -1,3 +1,12 @@
 package exception
 
-var errCreateUser = CustomError{Code: 10001, Message: "failed create user, please try again later"}
+var (
+	ErrFailedCreateUser     = CustomError{Code: 10001, Message: "failed create user, please try again later"}
+	ErrInvalidCredential    = CustomError{Code: 10002, Message: "invalid email or password"}
+	ErrFailedGenerateToken  = CustomError{Code: 10003, Message: "failed generate token"}
+	ErrInvalidToken         = CustomError{Code: 10004, Message: "invalid or expired token"}
+	ErrUserNotFound         = CustomError{Code: 10005, Message: "user not found"}
+	ErrFailedGetUser        = CustomError{Code: 10006, Message: "failed get user"}
+	ErrFailedUpdateUser     = CustomError{Code: 10007, Message: "failed update user"}
+	ErrFailedDeactivateUser = CustomError{Code: 10008, Message: "failed deactivate user"}
+)
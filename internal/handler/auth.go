@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"newsapi/internal/exception"
+	"newsapi/internal/model/request"
+	responder "newsapi/internal/model/response"
+	"newsapi/internal/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type AuthHandler struct {
+	uc        usecase.AuthUsecase
+	validator *validator.Validate
+}
+
+func NewAuthHandler(
+	validator *validator.Validate,
+	uc usecase.AuthUsecase,
+) AuthHandler {
+	return AuthHandler{
+		uc:        uc,
+		validator: validator,
+	}
+}
+
+func (h AuthHandler) Login(c echo.Context) error {
+	var req request.LoginRequest
+	err := c.Bind(&req)
+	if err != nil {
+		log.Errorf("AuthHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("AuthHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "login require [email, password]")
+	}
+
+	token, err := h.uc.Login(c.Request().Context(), req)
+	if err != nil {
+		if err == exception.ErrInvalidCredential {
+			return responder.ResponseUnauthorize(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, token, "")
+}
+
+func (h AuthHandler) RefreshToken(c echo.Context) error {
+	var req request.RefreshTokenRequest
+	err := c.Bind(&req)
+	if err != nil {
+		log.Errorf("AuthHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("AuthHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "refresh token require [refresh_token]")
+	}
+
+	token, err := h.uc.RefreshToken(c.Request().Context(), req)
+	if err != nil {
+		if err == exception.ErrInvalidToken {
+			return responder.ResponseUnauthorize(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, token, "")
+}
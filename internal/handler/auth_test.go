@@ -0,0 +1,175 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"newsapi/internal/exception"
+	"newsapi/internal/handler"
+	"newsapi/internal/model/response"
+	mock_usecase "newsapi/mocks/usecase"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type AuthHandlerAccessor struct {
+	authUC  *mock_usecase.MockAuthUsecase
+	handler handler.AuthHandler
+}
+
+func newAuthHandlerAccessor(ctrl *gomock.Controller) AuthHandlerAccessor {
+	authUC := mock_usecase.NewMockAuthUsecase(ctrl)
+	validator := validator.New()
+	handler := handler.NewAuthHandler(validator, authUC)
+	return AuthHandlerAccessor{
+		authUC:  authUC,
+		handler: handler,
+	}
+}
+
+func Test_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newAuthHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+	body := `{"email":"user@example.com","password":"secret-password"}`
+
+	tests := []struct {
+		name      string
+		body      string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing password return 400",
+			body:     `{"email":"user@example.com"}`,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "invalid credential return 401",
+			body: body,
+			initMock: func() {
+				accessor.authUC.EXPECT().Login(gomock.Any(), gomock.Any()).
+					Return(response.AuthToken{}, exception.ErrInvalidCredential)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name: "usecase error return 422",
+			body: body,
+			initMock: func() {
+				accessor.authUC.EXPECT().Login(gomock.Any(), gomock.Any()).
+					Return(response.AuthToken{}, errors.New("db error"))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+		{
+			name: "valid credential return 200",
+			body: body,
+			initMock: func() {
+				accessor.authUC.EXPECT().Login(gomock.Any(), gomock.Any()).
+					Return(response.AuthToken{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer"}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"access_token":"access"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := h.Login(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newAuthHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		body      string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing refresh token return 400",
+			body:     `{}`,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "invalid refresh token return 401",
+			body: `{"refresh_token":"expired"}`,
+			initMock: func() {
+				accessor.authUC.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					Return(response.AuthToken{}, exception.ErrInvalidToken)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name: "valid refresh token return 200",
+			body: `{"refresh_token":"valid"}`,
+			initMock: func() {
+				accessor.authUC.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					Return(response.AuthToken{AccessToken: "access"}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := h.RefreshToken(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// etag formats a row version as a strong entity tag
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// representationETag tags a response that carries more than the row, like a
+// topic with its latest articles. The tag leads with the row version so it can
+// be sent back as If-Match, the digest of body changes with everything else.
+func representationETag(version int, body any) string {
+	digest := fnv.New64a()
+	_ = json.NewEncoder(digest).Encode(body)
+	return fmt.Sprintf(`"%d-%x"`, version, digest.Sum64())
+}
+
+// parseIfMatch returns the version a write is conditioned on, nil when the
+// request sets no If-Match or accepts any version with "*". A weak or malformed
+// tag can never match a version, the caller answers it with 412.
+func parseIfMatch(c echo.Context) (*int, error) {
+	raw := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if raw == "" || raw == "*" {
+		return nil, nil
+	}
+
+	unquoted, ok := strings.CutPrefix(raw, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	// drop the digest of a representation tag
+	unquoted, _, _ = strings.Cut(unquoted, "-")
+	version, err := strconv.Atoi(unquoted)
+	if !ok || err != nil {
+		return nil, errors.New("If-Match does not name a current version")
+	}
+
+	return &version, nil
+}
+
+// notModified sets tag as the ETag of the response and reports whether the
+// client already holds it, compared weakly as If-None-Match requires
+func notModified(c echo.Context, tag string) bool {
+	c.Response().Header().Set(headerETag, tag)
+
+	raw := c.Request().Header.Get(headerIfNoneMatch)
+	if raw == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(raw, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// exportFlushRows is the number of rows written between two flushes, so the
+// client receives the export while it is read instead of at the end
+const exportFlushRows = 500
+
+var csvExportColumns = []string{
+	"id", "title", "summary", "content", "slug", "status", "author_id", "author_name",
+	"published_at", "created_at", "updated_at", "topic_ids", "topic_slugs",
+}
+
+// newsExportWriter streams exported rows as NDJSON or CSV. The headers are only
+// sent with the first row, until then a failed export can still be answered
+// with an error response.
+type newsExportWriter struct {
+	res     *echo.Response
+	format  string
+	json    *json.Encoder
+	csv     *csv.Writer
+	rows    int
+	started bool
+}
+
+func newNewsExportWriter(res *echo.Response, format string) *newsExportWriter {
+	w := &newsExportWriter{res: res, format: format}
+	if format == request.ImportFormatCSV {
+		w.csv = csv.NewWriter(res)
+	} else {
+		w.json = json.NewEncoder(res)
+	}
+	return w
+}
+
+func (w *newsExportWriter) start() error {
+	w.started = true
+
+	contentType, ext := "application/x-ndjson", "ndjson"
+	if w.csv != nil {
+		contentType, ext = "text/csv; charset=utf-8", "csv"
+	}
+	header := w.res.Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="news.%s"`, ext))
+	w.res.WriteHeader(http.StatusOK)
+
+	if w.csv != nil {
+		return w.csv.Write(csvExportColumns)
+	}
+	return nil
+}
+
+// Write is handed to the usecase and writes one row
+func (w *newsExportWriter) Write(row response.NewsExport) error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	if w.csv != nil {
+		if err := w.csv.Write(csvExportRow(row)); err != nil {
+			return err
+		}
+	} else if err := w.json.Encode(row); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%exportFlushRows == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close sends what is left, an export without rows still gets its headers
+func (w *newsExportWriter) Close() error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+func (w *newsExportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.res.Flush()
+	return nil
+}
+
+// csvExportRow lays a row out like csvExportColumns, lists are joined the way
+// the CSV import splits them
+func csvExportRow(row response.NewsExport) []string {
+	summary := ""
+	if row.Summary != nil {
+		summary = *row.Summary
+	}
+	publishedAt := ""
+	if row.PublishedAt != nil {
+		publishedAt = row.PublishedAt.Format(time.RFC3339)
+	}
+	topicIDs := make([]string, 0, len(row.TopicIDs))
+	for _, id := range row.TopicIDs {
+		topicIDs = append(topicIDs, strconv.Itoa(int(id)))
+	}
+
+	return []string{
+		strconv.Itoa(row.ID),
+		row.Title,
+		summary,
+		row.Content,
+		row.Slug,
+		string(row.Status),
+		strconv.Itoa(row.AuthorID),
+		row.AuthorName,
+		publishedAt,
+		row.CreatedAt.Format(time.RFC3339),
+		row.UpdatedAt.Format(time.RFC3339),
+		strings.Join(topicIDs, request.CSVListSeparator),
+		strings.Join(row.TopicSlugs, request.CSVListSeparator),
+	}
+}
@@ -1,17 +1,20 @@
 package handler
 
 type HandlerRegistry struct {
+	AuthHandler         AuthHandler
 	UsersHandler        UsersHandler
 	TopicsHandler       TopicsHandler
 	NewsArticlesHandler NewsHandler
 }
 
 func NewHandlerRegistry(
+	authHandler AuthHandler,
 	usersHandler UsersHandler,
 	topicsHandler TopicsHandler,
 	newsArticlesHandler NewsHandler,
 ) HandlerRegistry {
 	return HandlerRegistry{
+		AuthHandler:         authHandler,
 		UsersHandler:        usersHandler,
 		TopicsHandler:       topicsHandler,
 		NewsArticlesHandler: newsArticlesHandler,
@@ -1,12 +1,18 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
 	"newsapi/internal/exception"
+	"newsapi/internal/middleware"
 	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
 	"newsapi/internal/model/request"
 	responder "newsapi/internal/model/response"
 	"newsapi/internal/usecase"
+	"path"
+	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -29,20 +35,28 @@ func NewNewsArticlesHandler(
 }
 
 func (h NewsHandler) CreateNews(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
 	var req request.CreateNewsArticleRequest
 	err := c.Bind(&req)
 	if err != nil {
-		log.Errorf("NewsHandler.bind: %w", err)
+		log.Errorf("NewsHandler.bind: %v", err)
 		return responder.ResponseBadRequest(c, "")
 	}
 
 	err = h.validator.Struct(req)
 	if err != nil {
-		log.Errorf("NewsHandler.validateStruct: %w", err)
-		return responder.ResponseBadRequest(c, "create news article require [title, content, summary (optional), author_id, slug, status (optional), topicIDs]")
+		log.Errorf("NewsHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "create news article require [title, content, summary (optional), author_id (optional), slug (optional, lowercase words joined by hyphens), status (optional), publish_at (scheduled only), topicIDs]")
 	}
 
-	if err := h.uc.CreateNewsArticle(c.Request().Context(), req); err != nil {
+	if err := h.uc.CreateNewsArticle(c.Request().Context(), actor, req); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
 		return responder.ResponseUnprocessableEntity(c, err.Error())
 	}
 
@@ -50,50 +64,181 @@ func (h NewsHandler) CreateNews(c echo.Context) error {
 }
 
 func (h NewsHandler) GetNewsArticles(c echo.Context) error {
-	status := c.QueryParam("status")
-	topicID := c.QueryParam("topic_id")
+	filter, err := parseNewsFilter(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	pagination, err := parseCursorPagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
 
-	filter := dto.NewsFilter{
-		Status:  entity.VerifyStatus(entity.ArticleStatus(status)),
-		TopicID: topicID,
+	// a cursor only makes sense for the ordering it was issued for
+	if cursor := pagination.Cursor; cursor != nil && cursor.Sort != "" && cursor.Sort != filter.Sort.String() {
+		return responder.ResponseBadRequest(c, "invalid cursor, sort does not match")
 	}
+	filter.Pagination = pagination
 
-	articles, err := h.uc.GetNewsArticles(c.Request().Context(), filter)
+	// anonymous callers are the zero AuthUser and only see published news
+	viewer, _ := middleware.GetAuthUser(c)
+	articles, meta, err := h.uc.GetNewsArticles(c.Request().Context(), viewer, filter)
 	if err != nil {
-		log.Errorf("NewsHandler.getTopics: %w", err)
+		log.Errorf("NewsHandler.getTopics: %v", err)
 		return responder.ResponseUnprocessableEntity(c, "failed get topics")
 	}
 
-	return responder.RespondOK(c, articles, "")
+	return responder.RespondOKWithMeta(c, articles, meta, "")
+}
+
+// parseNewsFilter reads the filter and sort query params shared by the news
+// list and the export, pagination is left to the caller. The returned error
+// is meant for a bad request response.
+func parseNewsFilter(c echo.Context) (dto.NewsFilter, error) {
+	q := strings.TrimSpace(c.QueryParam("q"))
+
+	topicIDs, err := parseIDList(c, "topic_id")
+	if err != nil {
+		return dto.NewsFilter{}, err
+	}
+
+	topicMatch, err := dto.ParseTopicMatch(c.QueryParam("topic_match"))
+	if err != nil {
+		log.Errorf("NewsHandler.parseTopicMatch: %v", err)
+		return dto.NewsFilter{}, errors.New("invalid topic_match, use one of [any, all]")
+	}
+
+	subtopics, err := parseOptionalBool(c, "include_subtopics")
+	if err != nil {
+		return dto.NewsFilter{}, err
+	}
+
+	authorID, err := parseOptionalInt(c, "author_id")
+	if err != nil {
+		return dto.NewsFilter{}, err
+	}
+
+	published, err := parseTimeRange(c, "published")
+	if err != nil {
+		return dto.NewsFilter{}, err
+	}
+
+	created, err := parseTimeRange(c, "created")
+	if err != nil {
+		return dto.NewsFilter{}, err
+	}
+
+	sort, err := dto.ParseNewsSort(c.QueryParam("sort"))
+	if err != nil {
+		log.Errorf("NewsHandler.parseSort: %v", err)
+		return dto.NewsFilter{}, errors.New("invalid sort, use one of [published_at, created_at, updated_at, title, relevance] with optional :asc or :desc")
+	}
+
+	if q == "" && sort.Field == dto.SortByRelevance {
+		return dto.NewsFilter{}, errors.New("invalid sort, relevance requires q")
+	}
+	// search results are ranked by relevance unless another order is requested
+	if q != "" && c.QueryParam("sort") == "" {
+		sort = dto.RelevanceNewsSort
+	}
+
+	return dto.NewsFilter{
+		Query:      q,
+		Status:     entity.VerifyStatus(entity.ArticleStatus(c.QueryParam("status"))),
+		TopicIDs:   topicIDs,
+		TopicSlugs: parseStringList(c, "topic_slug"),
+		TopicMatch: topicMatch,
+		Subtopics:  subtopics,
+		AuthorID:   authorID,
+		Published:  published,
+		Created:    created,
+		Sort:       sort,
+	}, nil
 }
 
 func (h NewsHandler) GetNewsBySlug(c echo.Context) error {
 	slug := c.Param("slug")
 
-	article, err := h.uc.GetNewsArticleBySlug(c.Request().Context(), slug)
+	viewer, _ := middleware.GetAuthUser(c)
+	article, err := h.uc.GetNewsArticleBySlug(c.Request().Context(), viewer, slug)
 	if err != nil {
+		if err == exception.ErrNewsNotFound {
+			// the slug may belong to a renamed article, send old links to its new home
+			if current, resolveErr := h.uc.ResolveNewsSlug(c.Request().Context(), viewer, slug); resolveErr == nil {
+				return c.Redirect(http.StatusMovedPermanently, canonicalURL(c, current))
+			}
+		}
 		return responder.ResponseUnprocessableEntity(c, err.Error())
 	}
 
+	// the author and topic names are not part of the row version
+	if notModified(c, representationETag(article.Version, article)) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
 	return responder.RespondOK(c, article, "")
 }
 
+// canonicalURL swaps the last path segment of the request for slug and keeps
+// the query string
+func canonicalURL(c echo.Context, slug string) string {
+	reqURL := *c.Request().URL
+	reqURL.Path = path.Join(path.Dir(reqURL.Path), slug)
+	reqURL.RawPath = ""
+	return reqURL.RequestURI()
+}
+
+func (h NewsHandler) GetAuthorArticles(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	pagination, err := parsePagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	profile, meta, err := h.uc.GetAuthorArticles(c.Request().Context(), id, pagination)
+	if err != nil {
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOKWithMeta(c, profile, meta, "")
+}
+
 func (h NewsHandler) UpdateNewsArticle(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
 	slug := c.Param("slug")
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return responder.ResponsePreconditionFailed(c, err.Error())
+	}
+
 	var req request.UpdateNewsArticleRequest
-	err := c.Bind(&req)
+	err = c.Bind(&req)
 	if err != nil {
-		log.Errorf("NewsHandler.bind: %w", err)
+		log.Errorf("NewsHandler.bind: %v", err)
 		return responder.ResponseBadRequest(c, "")
 	}
 
 	err = h.validator.Struct(req)
 	if err != nil {
-		log.Errorf("NewsHandler.validateStruct: %w", err)
-		return responder.ResponseBadRequest(c, "update news require one of [title, content, summary (optional), slug, status (optional), topicIDs]")
+		log.Errorf("NewsHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "update news require one of [title, content, summary (optional), slug, topicIDs]")
 	}
 
-	if err := h.uc.UpdateNewsArticleBySlug(c.Request().Context(), slug, req); err != nil {
+	if err := h.uc.UpdateNewsArticleBySlug(c.Request().Context(), actor, slug, req, expectedVersion); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrNewsVersionMismatch {
+			return responder.ResponsePreconditionFailed(c, err.Error())
+		}
 		if customErr, ok := err.(exception.CustomError); ok && customErr.Code == 20005 {
 			return responder.RespondOK(c, nil, "no field updated")
 		}
@@ -103,12 +248,324 @@ func (h NewsHandler) UpdateNewsArticle(c echo.Context) error {
 	return responder.RespondOK(c, nil, "news updated")
 }
 
+func (h NewsHandler) TransitionNewsArticle(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	slug := c.Param("slug")
+	var req request.TransitionNewsArticleRequest
+	err := c.Bind(&req)
+	if err != nil {
+		log.Errorf("NewsHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("NewsHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "transition news require status one of [draft, in_review, approved, scheduled, published] with optional reviewer_id, comment and publish_at")
+	}
+
+	status, err := h.uc.TransitionNewsArticle(c.Request().Context(), actor, slug, req)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if customErr, ok := err.(exception.CustomError); ok && customErr.Code == exception.ErrInvalidTransition.Code {
+			return responder.ResponseConflict(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, status, "news status updated")
+}
+
+func (h NewsHandler) BulkNews(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	var req request.BulkNewsRequest
+	err := c.Bind(&req)
+	if err != nil {
+		log.Errorf("NewsHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("NewsHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "bulk news require 1 to 100 slugs and action one of [set_status, add_topics, remove_topics, delete] with status or topic_ids to match")
+	}
+
+	result, err := h.uc.BulkNews(c.Request().Context(), actor, req)
+	if err != nil {
+		if err == exception.ErrBulkNewsAborted {
+			return responder.BuildResponse(c, responder.Response{
+				Data:       result,
+				Message:    err.Error(),
+				HTTPStatus: http.StatusUnprocessableEntity,
+			})
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, result, "bulk news applied")
+}
+
+// ImportNews reads an NDJSON or CSV body, the format comes from the format
+// query param or else from the Content-Type
+func (h NewsHandler) ImportNews(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	dryRun, err := parseOptionalBool(c, "dry_run")
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = importFormat(c.Request().Header.Get(echo.HeaderContentType))
+	}
+
+	reader, err := request.NewNewsImportReader(c.Request().Body, format, h.validator)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	report, err := h.uc.ImportNews(c.Request().Context(), actor, reader, dryRun)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrFailedReadImport || err == exception.ErrFailedImportNews {
+			return responder.BuildResponse(c, responder.Response{
+				Data:       report,
+				Message:    err.Error(),
+				HTTPStatus: http.StatusUnprocessableEntity,
+			})
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	message := "news imported"
+	if dryRun {
+		message = "news import checked, nothing was written"
+	}
+	return responder.RespondOK(c, report, message)
+}
+
+// importFormat maps a Content-Type onto an import format
+func importFormat(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/csv":
+		return request.ImportFormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/json-lines":
+		return request.ImportFormatNDJSON
+	}
+	return ""
+}
+
+// ExportNews streams every article matching the list filters as NDJSON or CSV.
+// Once the first row is sent the status cannot change anymore, so a failure
+// after that aborts the connection and the client sees a truncated body
+// instead of an export that looks complete.
+func (h NewsHandler) ExportNews(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	format := c.QueryParam("format")
+	switch format {
+	case "":
+		format = request.ImportFormatNDJSON
+	case request.ImportFormatNDJSON, request.ImportFormatCSV:
+	default:
+		return responder.ResponseBadRequest(c, "invalid format, use one of [ndjson, csv]")
+	}
+
+	filter, err := parseNewsFilter(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	writer := newNewsExportWriter(c.Response(), format)
+	err = h.uc.ExportNews(c.Request().Context(), actor, filter, writer.Write)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if writer.started {
+			log.Errorf("NewsHandler.exportNews: aborted after %d rows: %v", writer.rows, err)
+			panic(http.ErrAbortHandler)
+		}
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return nil
+}
+
 func (h NewsHandler) DeleteNewsArticle(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
 	slug := c.Param("slug")
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return responder.ResponsePreconditionFailed(c, err.Error())
+	}
 
-	err := h.uc.DeleteNewsArticleBySlug(c.Request().Context(), slug)
+	err = h.uc.DeleteNewsArticleBySlug(c.Request().Context(), actor, slug, expectedVersion)
 	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrNewsVersionMismatch {
+			return responder.ResponsePreconditionFailed(c, err.Error())
+		}
 		return responder.ResponseUnprocessableEntity(c, err.Error())
 	}
 	return responder.RespondOK(c, nil, "news deleted")
 }
+
+func (h NewsHandler) GetNewsRevisions(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	pagination, err := parsePagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	revisions, meta, err := h.uc.GetNewsRevisions(c.Request().Context(), actor, c.Param("slug"), pagination)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOKWithMeta(c, revisions, meta, "")
+}
+
+func (h NewsHandler) DiffNewsRevisions(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	from, err := parseOptionalInt(c, "from")
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+	to, err := parseOptionalInt(c, "to")
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+	if from == 0 || to == 0 {
+		return responder.ResponseBadRequest(c, "diff news revisions require from and to")
+	}
+
+	diff, err := h.uc.DiffNewsRevisions(c.Request().Context(), actor, c.Param("slug"), from, to)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, diff, "")
+}
+
+func (h NewsHandler) RestoreNewsRevision(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		return responder.ResponseBadRequest(c, "invalid revision")
+	}
+
+	if err := h.uc.RestoreNewsRevision(c.Request().Context(), actor, c.Param("slug"), revision); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if customErr, ok := err.(exception.CustomError); ok && customErr.Code == 20005 {
+			return responder.RespondOK(c, nil, "no field updated")
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, "news revision restored")
+}
+
+func (h NewsHandler) GetTrashedNews(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	pagination, err := parsePagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	news, meta, err := h.uc.GetTrashedNews(c.Request().Context(), actor, pagination)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOKWithMeta(c, news, meta, "")
+}
+
+func (h NewsHandler) RestoreNewsArticle(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	if err := h.uc.RestoreNewsArticle(c.Request().Context(), actor, c.Param("slug")); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, "news restored")
+}
+
+func (h NewsHandler) PurgeNewsArticle(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	if err := h.uc.PurgeNewsArticle(c.Request().Context(), actor, c.Param("slug")); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, "news purged")
+}
@@ -1,15 +1,24 @@
 package handler_test
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"newsapi/internal/exception"
 	"newsapi/internal/handler"
+	"newsapi/internal/middleware"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
 	"newsapi/internal/model/response"
+	"newsapi/internal/usecase"
+	"newsapi/internal/utils"
 	mock_usecase "newsapi/mocks/usecase"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
@@ -17,6 +26,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var mockActor = dto.AuthUser{ID: 1, Role: entity.RoleAdmin}
+
 type NewsHandlerAccessor struct {
 	newsUC  *mock_usecase.MockNewsUsecase
 	handler handler.NewsHandler
@@ -25,6 +36,7 @@ type NewsHandlerAccessor struct {
 func newNewsHandlerAccessor(ctrl *gomock.Controller) NewsHandlerAccessor {
 	newsUC := mock_usecase.NewMockNewsUsecase(ctrl)
 	validator := validator.New()
+	request.RegisterValidations(validator)
 	handler := handler.NewNewsArticlesHandler(validator, newsUC)
 	return NewsHandlerAccessor{
 		newsUC:  newsUC,
@@ -65,12 +77,34 @@ func Test_CreateNews(t *testing.T) {
 				assert.Equal(t, http.StatusBadRequest, rr.Code)
 			},
 		},
+		{
+			name:     "slug with spaces and uppercase returns 400",
+			body:     `{"title": "A Valid Title", "content": "This is the valid content.", "slug": "A Valid Title"}`,
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "slug can be omitted, expect 201",
+			body: `{"title": "A Valid Title", "content": "This is the valid content."}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					CreateNewsArticle(gomock.Any(), gomock.Any(), request.CreateNewsArticleRequest{Title: "A Valid Title", Content: "This is the valid content."}).
+					Return(nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusCreated, rr.Code)
+			},
+		},
 		{
 			name: "valid payload but usecase returns error, expect 422",
 			body: validBody,
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					CreateNewsArticle(gomock.Any(), gomock.Any()).
+					CreateNewsArticle(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("something went wrong"))
 			},
 			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
@@ -83,7 +117,7 @@ func Test_CreateNews(t *testing.T) {
 			body: validBody,
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					CreateNewsArticle(gomock.Any(), gomock.Any()).
+					CreateNewsArticle(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
@@ -102,6 +136,7 @@ func Test_CreateNews(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
+			middleware.SetAuthUser(c, mockActor)
 			err := h.CreateNews(c)
 			tt.assertion(c, rec, err)
 		})
@@ -116,8 +151,11 @@ func Test_GetNewsArticles(t *testing.T) {
 	h := accessor.handler
 	e := echo.New()
 
+	cursor := dto.Cursor{Value: "2025-06-05T14:25:24Z", ID: 9, Sort: "published_at:desc"}
+
 	tests := []struct {
 		name      string
+		query     string
 		initMock  func()
 		assertion func(echo.Context, *httptest.ResponseRecorder, error)
 	}{
@@ -125,8 +163,8 @@ func Test_GetNewsArticles(t *testing.T) {
 			name: "usecase returns error, expect 422",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					GetNewsArticles(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("unexpected error"))
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, gomock.Any()).
+					Return(nil, response.Pagination{}, errors.New("unexpected error"))
 			},
 			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
@@ -137,21 +175,277 @@ func Test_GetNewsArticles(t *testing.T) {
 			name: "usecase returns articles successfully, expect 200",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					GetNewsArticles(gomock.Any(), gomock.Any()).
-					Return([]response.NewsArticle{}, nil)
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{Sort: dto.DefaultNewsSort, Pagination: dto.NewPagination(0, 0)}).
+					Return([]response.NewsArticle{}, response.Pagination{Page: 1, Limit: 20}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"meta":{"page":1,"limit":20,"total":0}`)
+			},
+		},
+		{
+			name:  "cursor is decoded into filter, expect 200",
+			query: "?limit=5&cursor=" + cursor.Encode(),
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{Sort: dto.DefaultNewsSort, Pagination: dto.NewCursorPagination(cursor, 5)}).
+					Return([]response.NewsArticle{}, response.Pagination{Limit: 5, Total: 12, NextCursor: "next"}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"next_cursor":"next"`)
+			},
+		},
+		{
+			name:  "sort with direction is parsed into filter, expect 200",
+			query: "?sort=title:desc",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{
+						Sort:       dto.NewsSort{Field: dto.SortByTitle, Desc: true},
+						Pagination: dto.NewPagination(0, 0),
+					}).
+					Return([]response.NewsArticle{}, response.Pagination{}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:  "sort without direction uses field default, expect 200",
+			query: "?sort=created_at",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{
+						Sort:       dto.NewsSort{Field: dto.SortByCreatedAt, Desc: true},
+						Pagination: dto.NewPagination(0, 0),
+					}).
+					Return([]response.NewsArticle{}, response.Pagination{}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:  "search without sort ranks by relevance, expect 200",
+			query: "?q=election+results",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{
+						Query:      "election results",
+						Sort:       dto.RelevanceNewsSort,
+						Pagination: dto.NewPagination(0, 0),
+					}).
+					Return([]response.NewsArticle{}, response.Pagination{}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:  "search with explicit sort keeps requested order, expect 200",
+			query: "?q=election&sort=published_at",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{
+						Query:      "election",
+						Sort:       dto.DefaultNewsSort,
+						Pagination: dto.NewPagination(0, 0),
+					}).
+					Return([]response.NewsArticle{}, response.Pagination{}, nil)
 			},
 			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusOK, rr.Code)
 			},
 		},
+		{
+			name:     "relevance sort without search, expect 400",
+			query:    "?sort=relevance",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "relevance requires q")
+			},
+		},
+		{
+			name:  "topic ids and slugs are parsed into filter, expect 200",
+			query: "?topic_id=1,2,3&topic_slug=tech,+science&topic_match=all",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{
+						TopicIDs:   []int32{1, 2, 3},
+						TopicSlugs: []string{"tech", "science"},
+						TopicMatch: dto.TopicMatchAll,
+						Sort:       dto.DefaultNewsSort,
+						Pagination: dto.NewPagination(0, 0),
+					}).
+					Return([]response.NewsArticle{}, response.Pagination{}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:     "malformed topic id list, expect 400",
+			query:    "?topic_id=1,abc",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid topic_id")
+			},
+		},
+		{
+			name:     "unknown topic match, expect 400",
+			query:    "?topic_id=1&topic_match=some",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid topic_match")
+			},
+		},
+		{
+			name:  "subtopics flag is parsed into filter, expect 200",
+			query: "?topic_slug=sports&include_subtopics=true",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, dto.NewsFilter{
+						TopicSlugs: []string{"sports"},
+						Subtopics:  true,
+						Sort:       dto.DefaultNewsSort,
+						Pagination: dto.NewPagination(0, 0),
+					}).
+					Return([]response.NewsArticle{}, response.Pagination{}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:     "malformed subtopics flag, expect 400",
+			query:    "?topic_id=1&include_subtopics=maybe",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid include_subtopics")
+			},
+		},
+		{
+			name:  "author and date ranges are parsed into filter, expect 200",
+			query: "?author_id=7&published_from=2025-06-02T00:00:00Z&published_to=2025-06-09T00:00:00Z&created_from=2025-06-01T00:00:00%2B07:00",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticles(gomock.Any(), dto.AuthUser{}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ dto.AuthUser, filter dto.NewsFilter) ([]response.NewsArticle, response.Pagination, error) {
+						assert.Equal(t, 7, filter.AuthorID)
+						assert.True(t, filter.Published.From.Equal(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)))
+						assert.True(t, filter.Published.To.Equal(time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)))
+						assert.True(t, filter.Created.From.Equal(time.Date(2025, 5, 31, 17, 0, 0, 0, time.UTC)))
+						assert.Nil(t, filter.Created.To)
+						return []response.NewsArticle{}, response.Pagination{}, nil
+					})
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:     "malformed published_from, expect 400",
+			query:    "?published_from=2025-06-02",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid published_from")
+			},
+		},
+		{
+			name:     "created range ending before it starts, expect 400",
+			query:    "?created_from=2025-06-09T00:00:00Z&created_to=2025-06-02T00:00:00Z",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid created_to")
+			},
+		},
+		{
+			name:     "non numeric author_id, expect 400",
+			query:    "?author_id=me",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid author_id")
+			},
+		},
+		{
+			name:     "unknown sort field, expect 400",
+			query:    "?sort=content",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid sort")
+			},
+		},
+		{
+			name:     "unknown sort direction, expect 400",
+			query:    "?sort=title:up",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:     "cursor issued for another sort, expect 400",
+			query:    "?sort=title&cursor=" + cursor.Encode(),
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "sort does not match")
+			},
+		},
+		{
+			name:     "malformed cursor, expect 400",
+			query:    "?cursor=not-a-cursor",
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid cursor")
+			},
+		},
+		{
+			name:     "cursor combined with page, expect 400",
+			query:    "?page=2&cursor=" + cursor.Encode(),
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.initMock()
 
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/news", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/news"+tt.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
@@ -170,22 +464,39 @@ func Test_GetNewsBySlug(t *testing.T) {
 	e := echo.New()
 
 	tests := []struct {
-		name      string
-		slug      string
-		initMock  func()
-		assertion func(*httptest.ResponseRecorder, error)
+		name        string
+		slug        string
+		ifNoneMatch string
+		initMock    func()
+		assertion   func(*httptest.ResponseRecorder, error)
 	}{
 		{
 			name: "returns article successfully",
 			slug: "test-slug",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					GetNewsArticleBySlug(gomock.Any(), "test-slug").
+					GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "test-slug").
 					Return(response.NewsArticleWithTopic{
-						ID:    1,
-						Title: "Test article",
+						ID:      1,
+						Title:   "Test article",
+						Version: 4,
 					}, nil)
 			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.True(t, strings.HasPrefix(rr.Header().Get("ETag"), `"4-`))
+			},
+		},
+		{
+			name:        "client holds an older version, expect 200",
+			slug:        "test-slug",
+			ifNoneMatch: `"3"`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "test-slug").
+					Return(response.NewsArticleWithTopic{ID: 1, Version: 4}, nil)
+			},
 			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusOK, rr.Code)
@@ -196,7 +507,7 @@ func Test_GetNewsBySlug(t *testing.T) {
 			slug: "missing-slug",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					GetNewsArticleBySlug(gomock.Any(), "missing-slug").
+					GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "missing-slug").
 					Return(response.NewsArticleWithTopic{}, errors.New("not found"))
 			},
 			assertion: func(rr *httptest.ResponseRecorder, err error) {
@@ -204,13 +515,46 @@ func Test_GetNewsBySlug(t *testing.T) {
 				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 			},
 		},
+		{
+			name: "unknown slug, expect 422",
+			slug: "unknown-slug",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "unknown-slug").
+					Return(response.NewsArticleWithTopic{}, exception.ErrNewsNotFound)
+				accessor.newsUC.EXPECT().ResolveNewsSlug(gomock.Any(), dto.AuthUser{}, "unknown-slug").Return("", exception.ErrNewsNotFound)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+				assert.Contains(t, rr.Body.String(), "news not found")
+			},
+		},
+		{
+			name: "old slug of a renamed article, expect 301 to the current one",
+			slug: "old-slug",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "old-slug").
+					Return(response.NewsArticleWithTopic{}, exception.ErrNewsNotFound)
+				accessor.newsUC.EXPECT().ResolveNewsSlug(gomock.Any(), dto.AuthUser{}, "old-slug").Return("new-slug", nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+				assert.Equal(t, "/api/v1/news/new-slug?ref=share", rr.Header().Get(echo.HeaderLocation))
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.initMock()
 
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/news/"+tt.slug, nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/news/"+tt.slug+"?ref=share", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("slug")
@@ -220,9 +564,38 @@ func Test_GetNewsBySlug(t *testing.T) {
 			tt.assertion(rec, err)
 		})
 	}
+
+	t.Run("client holds the current representation until a name changes", func(t *testing.T) {
+		article := response.NewsArticleWithTopic{ID: 1, AuthorName: "Jane", Topics: []string{"Sports"}, Version: 4}
+		renamed := article
+		renamed.Topics = []string{"Athletics"}
+		gomock.InOrder(
+			accessor.newsUC.EXPECT().GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "test-slug").Return(article, nil).Times(2),
+			accessor.newsUC.EXPECT().GetNewsArticleBySlug(gomock.Any(), dto.AuthUser{}, "test-slug").Return(renamed, nil),
+		)
+
+		get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/news/test-slug", nil)
+			if ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues("test-slug")
+			assert.NoError(t, h.GetNewsBySlug(c))
+			return rec
+		}
+
+		tag := get("").Header().Get("ETag")
+		notModified := get(`"3", W/` + tag)
+		assert.Equal(t, http.StatusNotModified, notModified.Code)
+		assert.Empty(t, notModified.Body.String())
+		assert.Equal(t, http.StatusOK, get(tag).Code)
+	})
 }
 
-func Test_UpdateNewsArticle(t *testing.T) {
+func Test_GetAuthorArticles(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -230,70 +603,62 @@ func Test_UpdateNewsArticle(t *testing.T) {
 	h := accessor.handler
 	e := echo.New()
 
-	validBody := `{"title": "Updated Title"}`
-	slug := "some-article"
-
 	tests := []struct {
 		name      string
-		body      string
+		id        string
+		query     string
 		initMock  func()
-		assertion func(echo.Context, *httptest.ResponseRecorder, error)
+		assertion func(*httptest.ResponseRecorder, error)
 	}{
 		{
-			name:     "invalid JSON body, expect 400",
-			body:     `{"title": 123}`, // invalid type
+			name:     "invalid id return 400",
+			id:       "abc",
 			initMock: func() {},
-			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusBadRequest, rr.Code)
 			},
 		},
 		{
-			name:     "validation fails, expect 400",
-			body:     `{"title": "abc"}`, // too short
+			name:     "invalid page return 400",
+			id:       "7",
+			query:    "?page=0",
 			initMock: func() {},
-			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid page")
 			},
 		},
 		{
-			name: "usecase returns custom no update error, expect 200 with message",
-			body: validBody,
-			initMock: func() {
-				accessor.newsUC.EXPECT().
-					UpdateNewsArticleBySlug(gomock.Any(), slug, gomock.Any()).
-					Return(exception.CustomError{Code: 20005})
-			},
-			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
-				assert.NoError(t, err)
-				assert.Equal(t, http.StatusOK, rr.Code)
-			},
-		},
-		{
-			name: "usecase returns generic error, expect 422",
-			body: validBody,
+			name: "author not found return 422",
+			id:   "7",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					UpdateNewsArticleBySlug(gomock.Any(), slug, gomock.Any()).
-					Return(errors.New("unexpected error"))
+					GetAuthorArticles(gomock.Any(), 7, dto.NewPagination(0, 0)).
+					Return(response.AuthorProfile{}, response.Pagination{}, exception.ErrAuthorNotFound)
 			},
-			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 			},
 		},
 		{
-			name: "successfully updated, expect 200",
-			body: validBody,
+			name:  "returns author profile successfully",
+			id:    "7",
+			query: "?page=2&limit=5",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					UpdateNewsArticleBySlug(gomock.Any(), slug, gomock.Any()).
-					Return(nil)
+					GetAuthorArticles(gomock.Any(), 7, dto.Pagination{Page: 2, Limit: 5}).
+					Return(response.AuthorProfile{
+						Author:   response.Author{ID: 7, Name: "Jane"},
+						Articles: []response.ArticleSummary{{ID: 1, Title: "Title 1"}},
+					}, response.Pagination{Page: 2, Limit: 5, Total: 6}, nil)
 			},
-			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"total":6`)
 			},
 		},
 	}
@@ -302,20 +667,776 @@ func Test_UpdateNewsArticle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.initMock()
 
-			req := httptest.NewRequest(http.MethodPatch, "/api/v1/news/"+slug, strings.NewReader(tt.body))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.id+"/articles"+tt.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.SetParamNames("slug")
-			c.SetParamValues(slug)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
 
-			err := h.UpdateNewsArticle(c)
-			tt.assertion(c, rec, err)
+			err := h.GetAuthorArticles(c)
+			tt.assertion(rec, err)
 		})
 	}
 }
 
-func Test_DeleteNewsArticle(t *testing.T) {
+func Test_UpdateNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	validBody := `{"title": "Updated Title"}`
+	slug := "some-article"
+
+	tests := []struct {
+		name      string
+		body      string
+		ifMatch   string
+		initMock  func()
+		assertion func(echo.Context, *httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "invalid JSON body, expect 400",
+			body:     `{"title": 123}`, // invalid type
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:     "validation fails, expect 400",
+			body:     `{"title": "abc"}`, // too short
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "usecase returns custom no update error, expect 200 with message",
+			body: validBody,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					UpdateNewsArticleBySlug(gomock.Any(), gomock.Any(), slug, gomock.Any(), nil).
+					Return(exception.CustomError{Code: 20005})
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name: "usecase returns generic error, expect 422",
+			body: validBody,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					UpdateNewsArticleBySlug(gomock.Any(), gomock.Any(), slug, gomock.Any(), nil).
+					Return(errors.New("unexpected error"))
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+		{
+			name: "successfully updated, expect 200",
+			body: validBody,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					UpdateNewsArticleBySlug(gomock.Any(), gomock.Any(), slug, gomock.Any(), nil).
+					Return(nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:     "malformed If-Match, expect 412",
+			body:     validBody,
+			ifMatch:  `W/"2"`,
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+			},
+		},
+		{
+			name:    "stale If-Match, expect 412",
+			body:    validBody,
+			ifMatch: `"2"`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					UpdateNewsArticleBySlug(gomock.Any(), gomock.Any(), slug, gomock.Any(), utils.IntPtr(2)).
+					Return(exception.ErrNewsVersionMismatch)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/news/"+slug, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues(slug)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.UpdateNewsArticle(c)
+			tt.assertion(c, rec, err)
+		})
+	}
+}
+
+func Test_TransitionNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	slug := "some-article"
+
+	tests := []struct {
+		name      string
+		body      string
+		initMock  func()
+		assertion func(echo.Context, *httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "unknown status, expect 400",
+			body:     `{"status": "deleted"}`,
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "illegal transition, expect 409",
+			body: `{"status": "published"}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					TransitionNewsArticle(gomock.Any(), mockActor, slug, request.TransitionNewsArticleRequest{Status: "published"}).
+					Return(response.NewsStatus{}, exception.NewInvalidTransitionError("draft", "published"))
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusConflict, rr.Code)
+				assert.Contains(t, rr.Body.String(), "cannot move news from draft to published")
+			},
+		},
+		{
+			name: "usecase returns permission denied, expect 403",
+			body: `{"status": "approved"}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					TransitionNewsArticle(gomock.Any(), mockActor, slug, gomock.Any()).
+					Return(response.NewsStatus{}, exception.ErrPermissionDenied)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "usecase returns validation error, expect 422",
+			body: `{"status": "draft"}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					TransitionNewsArticle(gomock.Any(), mockActor, slug, gomock.Any()).
+					Return(response.NewsStatus{}, exception.ErrReviewCommentRequired)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+		{
+			name: "successfully submitted for review, expect 200",
+			body: `{"status": "in_review", "reviewer_id": 5}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					TransitionNewsArticle(gomock.Any(), mockActor, slug, request.TransitionNewsArticleRequest{Status: "in_review", ReviewerID: utils.IntPtr(5)}).
+					Return(response.NewsStatus{Slug: slug, Status: entity.StatusInReview, ReviewerID: utils.IntPtr(5)}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"status":"in_review"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/news/"+slug+"/transition", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues(slug)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.TransitionNewsArticle(c)
+			tt.assertion(c, rec, err)
+		})
+	}
+}
+
+func Test_BulkNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	result := response.BulkNewsResult{
+		Action: "delete",
+		Atomic: true,
+		Failed: 2,
+		Items: []response.BulkNewsItem{
+			{Slug: "first-news", Error: exception.ErrBulkNewsAborted.Error()},
+			{Slug: "second-news", Error: exception.ErrPermissionDenied.Error()},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		initMock  func()
+		assertion func(echo.Context, *httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "set_status without status, expect 400",
+			body:     `{"slugs": ["first-news"], "action": "set_status"}`,
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:     "add_topics without topic_ids, expect 400",
+			body:     `{"slugs": ["first-news"], "action": "add_topics"}`,
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "atomic request aborted, expect 422 with the items",
+			body: `{"slugs": ["first-news", "second-news"], "action": "delete", "atomic": true}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					BulkNews(gomock.Any(), mockActor, request.BulkNewsRequest{Slugs: []string{"first-news", "second-news"}, Action: "delete", Atomic: true}).
+					Return(result, exception.ErrBulkNewsAborted)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"slug":"second-news","ok":false,"error":"you do not have permission to perform this action"`)
+			},
+		},
+		{
+			name: "usecase returns error, expect 422",
+			body: `{"slugs": ["first-news"], "action": "add_topics", "topic_ids": [99]}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					BulkNews(gomock.Any(), mockActor, gomock.Any()).
+					Return(response.BulkNewsResult{}, exception.ErrInvalidTopicIDs)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+				assert.NotContains(t, rr.Body.String(), `"data"`)
+			},
+		},
+		{
+			name: "successfully applied, expect 200",
+			body: `{"slugs": ["first-news"], "action": "set_status", "status": "published"}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					BulkNews(gomock.Any(), mockActor, request.BulkNewsRequest{Slugs: []string{"first-news"}, Action: "set_status", Status: "published"}).
+					Return(response.BulkNewsResult{Action: "set_status", Succeeded: 1, Items: []response.BulkNewsItem{{Slug: "first-news", OK: true}}}, nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"succeeded":1`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/news/bulk", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.BulkNews(c)
+			tt.assertion(c, rec, err)
+		})
+	}
+}
+
+func Test_ImportNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	// drain reads every record the handler decoded
+	var records []request.NewsImportRecord
+	drain := func(_ context.Context, _ dto.AuthUser, source usecase.NewsImportSource, dryRun bool) (response.NewsImport, error) {
+		records = nil
+		for {
+			record, err := source.Next()
+			if errors.Is(err, io.EOF) {
+				return response.NewsImport{DryRun: dryRun, Total: len(records)}, nil
+			}
+			records = append(records, record)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		initMock    func()
+		assertion   func(echo.Context, *httptest.ResponseRecorder, error)
+	}{
+		{
+			name:        "unknown format, expect 400",
+			contentType: echo.MIMETextPlain,
+			body:        "title,content",
+			initMock:    func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:        "unknown csv column, expect 400",
+			contentType: "text/csv",
+			body:        "title,body\n",
+			initMock:    func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), `unknown csv column \"body\"`)
+			},
+		},
+		{
+			name:        "ndjson lines are decoded and validated, expect 200",
+			query:       "?dry_run=true",
+			contentType: "application/x-ndjson",
+			body: `{"title":"Imported title","content":"Imported content","topic_slugs":["sports"]}
+
+{"title":"no"}
+not json
+`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().ImportNews(gomock.Any(), mockActor, gomock.Any(), true).DoAndReturn(drain)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "nothing was written")
+				assert.Len(t, records, 3)
+				assert.NoError(t, records[0].Err)
+				assert.Equal(t, []string{"sports"}, records[0].Article.TopicSlugs)
+				assert.Equal(t, 3, records[1].Line)
+				assert.EqualError(t, records[1].Err, "invalid title: min, content: required")
+				assert.Equal(t, 4, records[2].Line)
+				assert.Error(t, records[2].Err)
+			},
+		},
+		{
+			name:  "csv rows are decoded and validated, expect 200",
+			query: "?format=csv",
+			body: `title,content,topic_ids,status,publish_at
+Imported title,"Imported content, with a comma",1|2,scheduled,2030-01-02T15:04:05Z
+Imported title,Imported content,one,,
+`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().ImportNews(gomock.Any(), mockActor, gomock.Any(), false).DoAndReturn(drain)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Len(t, records, 2)
+				assert.NoError(t, records[0].Err)
+				assert.Equal(t, []int{1, 2}, records[0].Article.TopicIDs)
+				assert.Equal(t, "Imported content, with a comma", records[0].Article.Content)
+				assert.Equal(t, utils.StringPtr("scheduled"), records[0].Article.Status)
+				assert.NotNil(t, records[0].Article.PublishAt)
+				assert.Equal(t, 3, records[1].Line)
+				assert.EqualError(t, records[1].Err, `topic_ids "one" is not a list of numbers`)
+			},
+		},
+		{
+			name:        "failed batch keeps the report, expect 422",
+			contentType: "application/x-ndjson",
+			body:        `{"title":"Imported title","content":"Imported content"}`,
+			initMock: func() {
+				accessor.newsUC.EXPECT().ImportNews(gomock.Any(), mockActor, gomock.Any(), false).
+					Return(response.NewsImport{Total: 1, Failed: 1}, exception.ErrFailedImportNews)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"failed":1`)
+			},
+		},
+		{
+			name:        "usecase returns permission denied, expect 403",
+			contentType: "application/x-ndjson",
+			initMock: func() {
+				accessor.newsUC.EXPECT().ImportNews(gomock.Any(), mockActor, gomock.Any(), false).
+					Return(response.NewsImport{}, exception.ErrPermissionDenied)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/news/import"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.ImportNews(c)
+			tt.assertion(c, rec, err)
+		})
+	}
+}
+
+func Test_ExportNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	publishedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := []response.NewsExport{
+		{ID: 1, Title: "First, with a comma", Content: "Content", Slug: "first", Status: entity.StatusPublished,
+			AuthorID: 3, AuthorName: "Jane", PublishedAt: &publishedAt, CreatedAt: publishedAt, UpdatedAt: publishedAt,
+			TopicIDs: []int32{1, 2}, TopicSlugs: []string{"sports", "football"}},
+		{ID: 2, Title: "Second", Content: "Content", Slug: "second", Status: entity.StatusDraft,
+			AuthorID: 3, AuthorName: "Jane", CreatedAt: publishedAt, UpdatedAt: publishedAt, TopicIDs: []int32{}, TopicSlugs: []string{}},
+	}
+	stream := func(err error) func(context.Context, dto.AuthUser, dto.NewsFilter, func(response.NewsExport) error) error {
+		return func(_ context.Context, _ dto.AuthUser, _ dto.NewsFilter, write func(response.NewsExport) error) error {
+			for _, row := range rows {
+				if err := write(row); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "unknown format, expect 400",
+			query:    "?format=xml",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:     "invalid filter, expect 400",
+			query:    "?sort=relevance",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "relevance requires q")
+			},
+		},
+		{
+			name:  "streams ndjson by default, expect 200",
+			query: "?status=published&topic_id=1",
+			initMock: func() {
+				filter := dto.NewsFilter{Status: entity.StatusPublished, TopicIDs: []int32{1}, Sort: dto.DefaultNewsSort}
+				accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, filter, gomock.Any()).DoAndReturn(stream(nil))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Equal(t, "application/x-ndjson", rr.Header().Get(echo.HeaderContentType))
+				lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+				assert.Len(t, lines, 2)
+				assert.Contains(t, lines[0], `"author_name":"Jane"`)
+				assert.Contains(t, lines[0], `"topic_slugs":["sports","football"]`)
+				assert.Contains(t, lines[1], `"published_at":null`)
+			},
+		},
+		{
+			name:  "streams csv with a header, expect 200",
+			query: "?format=csv",
+			initMock: func() {
+				accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, gomock.Any(), gomock.Any()).DoAndReturn(stream(nil))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Header().Get(echo.HeaderContentDisposition), "news.csv")
+				assert.Equal(t, strings.Join([]string{
+					"id,title,summary,content,slug,status,author_id,author_name,published_at,created_at,updated_at,topic_ids,topic_slugs",
+					`1,"First, with a comma",,Content,first,published,3,Jane,2024-05-01T10:00:00Z,2024-05-01T10:00:00Z,2024-05-01T10:00:00Z,1|2,sports|football`,
+					"2,Second,,Content,second,draft,3,Jane,,2024-05-01T10:00:00Z,2024-05-01T10:00:00Z,,",
+					"",
+				}, "\n"), rr.Body.String())
+			},
+		},
+		{
+			name:  "no rows still sends the csv header, expect 200",
+			query: "?format=csv",
+			initMock: func() {
+				accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.True(t, strings.HasPrefix(rr.Body.String(), "id,title,"))
+			},
+		},
+		{
+			name: "usecase returns permission denied, expect 403",
+			initMock: func() {
+				accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, gomock.Any(), gomock.Any()).Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "failed before the first row, expect 422",
+			initMock: func() {
+				accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, gomock.Any(), gomock.Any()).Return(exception.ErrFailedExportNews)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/news/export"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.ExportNews(c)
+			tt.assertion(rec, err)
+		})
+	}
+
+	t.Run("failed after streaming started, expect aborted connection", func(t *testing.T) {
+		accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, gomock.Any(), gomock.Any()).
+			DoAndReturn(stream(exception.ErrFailedExportNews))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/news/export", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		middleware.SetAuthUser(c, mockActor)
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() { _ = h.ExportNews(c) })
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func Test_DeleteNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		slug      string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name: "deletes article successfully",
+			slug: "test-slug",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					DeleteNewsArticleBySlug(gomock.Any(), gomock.Any(), "test-slug", nil).
+					Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name: "returns 403 when caller is not allowed",
+			slug: "test-slug",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					DeleteNewsArticleBySlug(gomock.Any(), gomock.Any(), "test-slug", nil).
+					Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "returns 422 on delete error",
+			slug: "invalid-slug",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					DeleteNewsArticleBySlug(gomock.Any(), gomock.Any(), "invalid-slug", nil).
+					Return(errors.New("delete error"))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/news/"+tt.slug, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues(tt.slug)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.DeleteNewsArticle(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_GetNewsRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	slug := "some-article"
+
+	tests := []struct {
+		name      string
+		query     string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "invalid limit, expect 400",
+			query:    "?limit=abc",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "usecase returns permission denied, expect 403",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsRevisions(gomock.Any(), mockActor, slug, gomock.Any()).
+					Return(nil, response.Pagination{}, exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name:  "returns revisions successfully",
+			query: "?page=1&limit=5",
+			initMock: func() {
+				accessor.newsUC.EXPECT().
+					GetNewsRevisions(gomock.Any(), mockActor, slug, dto.Pagination{Page: 1, Limit: 5}).
+					Return([]response.ArticleRevision{{Revision: 2, Title: "Title v2"}}, response.Pagination{Page: 1, Limit: 5, Total: 2}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"revision":2`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/news/"+slug+"/revisions"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues(slug)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.GetNewsRevisions(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_DiffNewsRevisions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -323,52 +1444,373 @@ func Test_DeleteNewsArticle(t *testing.T) {
 	h := accessor.handler
 	e := echo.New()
 
+	slug := "some-article"
+
 	tests := []struct {
 		name      string
-		slug      string
+		query     string
 		initMock  func()
 		assertion func(*httptest.ResponseRecorder, error)
 	}{
 		{
-			name: "deletes article successfully",
-			slug: "test-slug",
+			name:     "missing to, expect 400",
+			query:    "?from=1",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "require from and to")
+			},
+		},
+		{
+			name:     "invalid from, expect 400",
+			query:    "?from=0&to=2",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid from")
+			},
+		},
+		{
+			name:  "unknown revision, expect 422",
+			query: "?from=1&to=9",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					DeleteNewsArticleBySlug(gomock.Any(), "test-slug").
-					Return(nil)
+					DiffNewsRevisions(gomock.Any(), mockActor, slug, 1, 9).
+					Return(response.RevisionDiff{}, exception.ErrRevisionNotFound)
 			},
 			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
-				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 			},
 		},
 		{
-			name: "returns 422 on delete error",
-			slug: "invalid-slug",
+			name:  "returns diff successfully",
+			query: "?from=1&to=2",
 			initMock: func() {
 				accessor.newsUC.EXPECT().
-					DeleteNewsArticleBySlug(gomock.Any(), "invalid-slug").
-					Return(errors.New("delete error"))
+					DiffNewsRevisions(gomock.Any(), mockActor, slug, 1, 2).
+					Return(response.RevisionDiff{
+						From:    1,
+						To:      2,
+						Changes: []response.FieldChange{{Field: "title", From: "Old", To: "New"}},
+					}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `{"field":"title","from":"Old","to":"New"}`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/news/"+slug+"/revisions/diff"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues(slug)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.DiffNewsRevisions(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_RestoreNewsRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	slug := "some-article"
+
+	tests := []struct {
+		name      string
+		revision  string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "invalid revision, expect 400",
+			revision: "abc",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:     "nothing to restore, expect 200",
+			revision: "2",
+			initMock: func() {
+				accessor.newsUC.EXPECT().RestoreNewsRevision(gomock.Any(), mockActor, slug, 2).Return(exception.ErrNoFieldUpdate)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "no field updated")
+			},
+		},
+		{
+			name:     "usecase returns permission denied, expect 403",
+			revision: "1",
+			initMock: func() {
+				accessor.newsUC.EXPECT().RestoreNewsRevision(gomock.Any(), mockActor, slug, 1).Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name:     "restores revision successfully",
+			revision: "1",
+			initMock: func() {
+				accessor.newsUC.EXPECT().RestoreNewsRevision(gomock.Any(), mockActor, slug, 1).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "news revision restored")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/news/"+slug+"/revisions/"+tt.revision+"/restore", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug", "revision")
+			c.SetParamValues(slug, tt.revision)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.RestoreNewsRevision(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_GetTrashedNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		query     string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "invalid page, expect 400",
+			query:    "?page=0",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:  "usecase returns permission denied, expect 403",
+			query: "",
+			initMock: func() {
+				accessor.newsUC.EXPECT().GetTrashedNews(gomock.Any(), mockActor, dto.NewPagination(1, 0)).
+					Return(nil, response.Pagination{}, exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name:  "lists trashed news successfully",
+			query: "?page=2&limit=5",
+			initMock: func() {
+				accessor.newsUC.EXPECT().GetTrashedNews(gomock.Any(), mockActor, dto.NewPagination(2, 5)).
+					Return([]response.NewsArticle{{ID: 3, Slug: "slug-3"}}, response.Pagination{Page: 2, Limit: 5, Total: 6}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "slug-3")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/trash/news"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.GetTrashedNews(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_RestoreAndPurgeNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	slug := "some-article"
+
+	tests := []struct {
+		name      string
+		method    string
+		handle    echo.HandlerFunc
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:   "restore news not in trash, expect 422",
+			method: http.MethodPost,
+			handle: h.RestoreNewsArticle,
+			initMock: func() {
+				accessor.newsUC.EXPECT().RestoreNewsArticle(gomock.Any(), mockActor, slug).Return(exception.ErrNewsNotInTrash)
 			},
 			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 			},
 		},
+		{
+			name:   "restores news successfully",
+			method: http.MethodPost,
+			handle: h.RestoreNewsArticle,
+			initMock: func() {
+				accessor.newsUC.EXPECT().RestoreNewsArticle(gomock.Any(), mockActor, slug).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "news restored")
+			},
+		},
+		{
+			name:   "purge as editor, expect 403",
+			method: http.MethodDelete,
+			handle: h.PurgeNewsArticle,
+			initMock: func() {
+				accessor.newsUC.EXPECT().PurgeNewsArticle(gomock.Any(), mockActor, slug).Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name:   "purges news successfully",
+			method: http.MethodDelete,
+			handle: h.PurgeNewsArticle,
+			initMock: func() {
+				accessor.newsUC.EXPECT().PurgeNewsArticle(gomock.Any(), mockActor, slug).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "news purged")
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.initMock()
 
-			req := httptest.NewRequest(http.MethodDelete, "/api/v1/news/"+tt.slug, nil)
+			req := httptest.NewRequest(tt.method, "/api/v1/trash/news/"+slug, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("slug")
-			c.SetParamValues(tt.slug)
+			c.SetParamValues(slug)
 
-			err := h.DeleteNewsArticle(c)
+			middleware.SetAuthUser(c, mockActor)
+			err := tt.handle(c)
 			tt.assertion(rec, err)
 		})
 	}
 }
+
+func Test_ExportFedBackToImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	publishedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := []response.NewsExport{
+		{ID: 1, Title: "First, with a comma", Content: "Exported content", Slug: "first-news", Status: entity.StatusPublished,
+			AuthorID: 3, AuthorName: "Jane", PublishedAt: &publishedAt, CreatedAt: publishedAt, UpdatedAt: publishedAt,
+			TopicIDs: []int32{1, 2}, TopicSlugs: []string{"sports", "football"}},
+		{ID: 2, Title: "Second news", Content: "Exported content", Slug: "second-news", Status: entity.StatusApproved,
+			AuthorID: 3, AuthorName: "Jane", CreatedAt: publishedAt, UpdatedAt: publishedAt, TopicIDs: []int32{}, TopicSlugs: []string{}},
+	}
+	accessor.newsUC.EXPECT().ExportNews(gomock.Any(), mockActor, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ dto.AuthUser, _ dto.NewsFilter, write func(response.NewsExport) error) error {
+			for _, row := range rows {
+				if err := write(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/news/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	middleware.SetAuthUser(c, mockActor)
+	assert.NoError(t, h.ExportNews(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var records []request.NewsImportRecord
+	accessor.newsUC.EXPECT().ImportNews(gomock.Any(), mockActor, gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, _ dto.AuthUser, source usecase.NewsImportSource, dryRun bool) (response.NewsImport, error) {
+			for {
+				record, err := source.Next()
+				if errors.Is(err, io.EOF) {
+					return response.NewsImport{DryRun: dryRun, Total: len(records)}, nil
+				}
+				records = append(records, record)
+			}
+		})
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/news/import?format=csv&dry_run=true", strings.NewReader(rec.Body.String()))
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	middleware.SetAuthUser(c, mockActor)
+	assert.NoError(t, h.ImportNews(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// the export-only columns are skipped, the rest is checked like any import
+	assert.Len(t, records, 2)
+	assert.NoError(t, records[0].Err)
+	assert.Equal(t, "First, with a comma", records[0].Article.Title)
+	assert.Equal(t, "first-news", records[0].Article.Slug)
+	assert.Equal(t, utils.IntPtr(3), records[0].Article.AuthorID)
+	assert.Equal(t, []int{1, 2}, records[0].Article.TopicIDs)
+	assert.Equal(t, []string{"sports", "football"}, records[0].Article.TopicSlugs)
+	assert.EqualError(t, records[1].Err, "invalid status: oneof")
+}
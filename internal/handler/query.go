@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"newsapi/internal/model/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// parsePagination reads the optional page and limit query params,
+// the returned error names the offending parameter
+func parsePagination(c echo.Context) (dto.Pagination, error) {
+	page, err := parseOptionalInt(c, "page")
+	if err != nil {
+		return dto.Pagination{}, err
+	}
+
+	limit, err := parseOptionalInt(c, "limit")
+	if err != nil {
+		return dto.Pagination{}, err
+	}
+
+	return dto.NewPagination(page, limit), nil
+}
+
+// parseCursorPagination behaves like parsePagination but also accepts an
+// opaque keyset cursor, which cannot be combined with page
+func parseCursorPagination(c echo.Context) (dto.Pagination, error) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		return dto.Pagination{}, err
+	}
+
+	rawCursor := c.QueryParam("cursor")
+	if rawCursor == "" {
+		return pagination, nil
+	}
+
+	if c.QueryParam("page") != "" {
+		return dto.Pagination{}, errors.New("invalid cursor, cannot be combined with page")
+	}
+
+	cursor, err := dto.DecodeCursor(rawCursor)
+	if err != nil {
+		return dto.Pagination{}, errors.New("invalid cursor")
+	}
+
+	return dto.NewCursorPagination(cursor, pagination.Limit), nil
+}
+
+func parseOptionalInt(c echo.Context, name string) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("invalid %s, must be a positive integer", name)
+	}
+
+	return value, nil
+}
+
+// parseOptionalBool reads a boolean query param, an absent param is false
+func parseOptionalBool(c echo.Context, name string) (bool, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s, must be true or false", name)
+	}
+
+	return value, nil
+}
+
+// parseIDList reads a comma separated list of positive ids, an absent
+// param yields nil
+func parseIDList(c echo.Context, name string) ([]int32, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	ids := make([]int32, 0, len(parts))
+	for _, part := range parts {
+		value, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("invalid %s, must be a comma separated list of positive integers", name)
+		}
+		ids = append(ids, int32(value))
+	}
+
+	return ids, nil
+}
+
+// parseStringList reads a comma separated list, skipping blank entries
+func parseStringList(c echo.Context, name string) []string {
+	var values []string
+	for _, part := range strings.Split(c.QueryParam(name), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+
+	return values
+}
+
+// parseTimeRange reads the <prefix>_from and <prefix>_to RFC3339 params
+func parseTimeRange(c echo.Context, prefix string) (dto.TimeRange, error) {
+	from, err := parseOptionalTime(c, prefix+"_from")
+	if err != nil {
+		return dto.TimeRange{}, err
+	}
+
+	to, err := parseOptionalTime(c, prefix+"_to")
+	if err != nil {
+		return dto.TimeRange{}, err
+	}
+
+	if from != nil && to != nil && !to.After(*from) {
+		return dto.TimeRange{}, fmt.Errorf("invalid %s_to, must be after %s_from", prefix, prefix)
+	}
+
+	return dto.TimeRange{From: from, To: to}, nil
+}
+
+func parseOptionalTime(c echo.Context, name string) (*time.Time, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s, must be an RFC3339 timestamp", name)
+	}
+
+	return &value, nil
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"fmt"
+	"net/http"
 	"newsapi/internal/exception"
+	"newsapi/internal/middleware"
 	"newsapi/internal/model/request"
 	responder "newsapi/internal/model/response"
 	"newsapi/internal/usecase"
@@ -29,20 +31,28 @@ func NewTopicsHandler(
 }
 
 func (h TopicsHandler) CreateTopic(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
 	var req request.CreateTopicRequest
 	err := c.Bind(&req)
 	if err != nil {
-		log.Errorf("TopicHandler.bind: %w", err)
+		log.Errorf("TopicHandler.bind: %v", err)
 		return responder.ResponseBadRequest(c, "")
 	}
 
 	err = h.validator.Struct(req)
 	if err != nil {
-		log.Errorf("TopicHandler.validateStruct: %w", err)
-		return responder.ResponseBadRequest(c, "create topic require [name, description, slug]")
+		log.Errorf("TopicHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "create topic require [name, description, slug (optional, lowercase words joined by hyphens), parent_id (optional)]")
 	}
 
-	if err := h.uc.CreateTopic(c.Request().Context(), req); err != nil {
+	if err := h.uc.CreateTopic(c.Request().Context(), actor, req); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
 		return responder.ResponseUnprocessableEntity(c, err.Error())
 	}
 
@@ -50,35 +60,98 @@ func (h TopicsHandler) CreateTopic(c echo.Context) error {
 }
 
 func (h TopicsHandler) GetTopics(c echo.Context) error {
-	topics, err := h.uc.GetTopics(c.Request().Context())
+	pagination, err := parseCursorPagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	withCounts, err := parseOptionalBool(c, "with_counts")
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	topics, meta, err := h.uc.GetTopics(c.Request().Context(), pagination, withCounts)
 	if err != nil {
-		log.Errorf("TopicHandler.getTopics: %w", err)
+		log.Errorf("TopicHandler.getTopics: %v", err)
 		return responder.ResponseUnprocessableEntity(c, "failed get topics")
 	}
 
-	return responder.RespondOK(c, topics, "")
+	return responder.RespondOKWithMeta(c, topics, meta, "")
+}
+
+func (h TopicsHandler) GetTopicTree(c echo.Context) error {
+	tree, err := h.uc.GetTopicTree(c.Request().Context())
+	if err != nil {
+		log.Errorf("TopicHandler.getTopicTree: %v", err)
+		return responder.ResponseUnprocessableEntity(c, "failed get topic tree")
+	}
+
+	return responder.RespondOK(c, tree, "")
+}
+
+// GetTopic looks the topic up by id, or by slug when the param is not a number
+func (h TopicsHandler) GetTopic(c echo.Context) error {
+	key := c.Param("id")
+
+	latest, err := parseOptionalInt(c, "latest")
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	topic, err := h.uc.GetTopic(c.Request().Context(), key, latest)
+	if err != nil {
+		if _, convErr := strconv.Atoi(key); err == exception.ErrTopicNotFound && convErr != nil {
+			// the slug may belong to a renamed topic, send old links to its new home
+			if current, resolveErr := h.uc.ResolveTopicSlug(c.Request().Context(), key); resolveErr == nil {
+				return c.Redirect(http.StatusMovedPermanently, canonicalURL(c, current))
+			}
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	if notModified(c, representationETag(topic.Version, topic)) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return responder.RespondOK(c, topic, "")
 }
 
 func (h TopicsHandler) UpdateTopic(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return responder.ResponseBadRequest(c, "invalid id")
 	}
 
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return responder.ResponsePreconditionFailed(c, err.Error())
+	}
+
 	var req request.UpdateTopicRequest
 	err = c.Bind(&req)
 	if err != nil {
-		log.Errorf("TopicHandler.bind: %w", err)
+		log.Errorf("TopicHandler.bind: %v", err)
 		return responder.ResponseBadRequest(c, "")
 	}
 
 	err = h.validator.Struct(req)
 	if err != nil {
-		log.Errorf("TopicHandler.validateStruct: %w", err)
-		return responder.ResponseBadRequest(c, "update topic require one of [name, description, slug]")
+		log.Errorf("TopicHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "update topic require one of [name, description, slug, parent_id (0 for top level)]")
 	}
 
-	if err := h.uc.UpdateTopic(c.Request().Context(), id, req); err != nil {
+	if err := h.uc.UpdateTopic(c.Request().Context(), actor, id, req, expectedVersion); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrTopicVersionMismatch {
+			return responder.ResponsePreconditionFailed(c, err.Error())
+		}
 		if customErr, ok := err.(exception.CustomError); ok && customErr.Code == 20005 {
 			return responder.RespondOK(c, nil, "no field updated")
 		}
@@ -89,15 +162,146 @@ func (h TopicsHandler) UpdateTopic(c echo.Context) error {
 }
 
 func (h TopicsHandler) DeleteTopic(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return responder.ResponseBadRequest(c, "invalid id")
 	}
 
-	err = h.uc.DeleteTopic(c.Request().Context(), id)
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return responder.ResponsePreconditionFailed(c, err.Error())
+	}
+
+	force, err := parseOptionalBool(c, "force")
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	err = h.uc.DeleteTopic(c.Request().Context(), actor, id, expectedVersion, force)
 	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrTopicVersionMismatch {
+			return responder.ResponsePreconditionFailed(c, err.Error())
+		}
+		if customErr, ok := err.(exception.CustomError); ok && customErr.Code == exception.ErrTopicInUse.Code {
+			return responder.ResponseConflict(c, err.Error())
+		}
 		return responder.ResponseUnprocessableEntity(c, err.Error())
 	}
 
 	return responder.RespondOK(c, nil, fmt.Sprintf("success delete topic with id %d", id))
 }
+
+func (h TopicsHandler) MergeTopic(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return responder.ResponsePreconditionFailed(c, err.Error())
+	}
+
+	var req request.MergeTopicRequest
+	err = c.Bind(&req)
+	if err != nil {
+		log.Errorf("TopicHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("TopicHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "merge topic require [target_id]")
+	}
+
+	res, err := h.uc.MergeTopic(c.Request().Context(), actor, id, req, expectedVersion)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrTopicVersionMismatch {
+			return responder.ResponsePreconditionFailed(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, res, fmt.Sprintf("success merge topic with id %d", id))
+}
+
+func (h TopicsHandler) GetTrashedTopics(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	pagination, err := parsePagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	topics, meta, err := h.uc.GetTrashedTopics(c.Request().Context(), actor, pagination)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOKWithMeta(c, topics, meta, "")
+}
+
+func (h TopicsHandler) RestoreTopic(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	if err := h.uc.RestoreTopic(c.Request().Context(), actor, id); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, fmt.Sprintf("success restore topic with id %d", id))
+}
+
+func (h TopicsHandler) PurgeTopic(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	if err := h.uc.PurgeTopic(c.Request().Context(), actor, id); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, fmt.Sprintf("success purge topic with id %d", id))
+}
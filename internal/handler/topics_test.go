@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"newsapi/internal/exception"
 	"newsapi/internal/handler"
+	"newsapi/internal/middleware"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/request"
 	"newsapi/internal/model/response"
 	"newsapi/internal/utils"
@@ -32,6 +34,7 @@ type TopicsHandlerAccessor struct {
 func newTopicsHandlerAccessor(ctrl *gomock.Controller) TopicsHandlerAccessor {
 	topicsUC := mock_usecase.NewMockTopicsUsecase(ctrl)
 	validator := validator.New()
+	request.RegisterValidations(validator)
 	handler := handler.NewTopicsHandler(validator, topicsUC)
 	return TopicsHandlerAccessor{
 		topicsUC: topicsUC,
@@ -63,7 +66,7 @@ func TestTopicsHandler_CreateTopic(t *testing.T) {
 			testname: "successful topic creation",
 			body:     body,
 			initMock: func() {
-				topicsUC.EXPECT().CreateTopic(gomock.Any(), gomock.Any()).Return(nil)
+				topicsUC.EXPECT().CreateTopic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusCreated, rr.Code)
@@ -87,11 +90,29 @@ func TestTopicsHandler_CreateTopic(t *testing.T) {
 				assert.Equal(t, http.StatusBadRequest, rr.Code)
 			},
 		},
+		{
+			testname: "validation error - slug is not lowercase and hyphenated",
+			body:     `{"name": "Politics", "slug": "World Politics"}`,
+			initMock: func() {},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			testname: "slug can be omitted",
+			body:     `{"name": "World Politics"}`,
+			initMock: func() {
+				topicsUC.EXPECT().CreateTopic(gomock.Any(), gomock.Any(), request.CreateTopicRequest{Name: "World Politics"}).Return(nil)
+			},
+			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rr.Code)
+			},
+		},
 		{
 			testname: "usecase error - duplicate slug",
 			body:     body,
 			initMock: func() {
-				topicsUC.EXPECT().CreateTopic(gomock.Any(), gomock.Any()).Return(errors.New("slug already exists"))
+				topicsUC.EXPECT().CreateTopic(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("slug already exists"))
 			},
 			assertion: func(ctx echo.Context, rr *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
@@ -107,6 +128,7 @@ func TestTopicsHandler_CreateTopic(t *testing.T) {
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			middleware.SetAuthUser(c, mockActor)
 			h.CreateTopic(c)
 
 			tt.assertion(c, rec)
@@ -126,6 +148,7 @@ func TestTopicsHandler_GetTopics(t *testing.T) {
 	mockTime := time.Now()
 	tests := []struct {
 		testname  string
+		query     string
 		initMock  func()
 		response  string
 		assertion func(*httptest.ResponseRecorder, string)
@@ -137,7 +160,7 @@ func TestTopicsHandler_GetTopics(t *testing.T) {
 					{ID: 1, Name: "Topic One", Description: utils.StringPtr("Desc 1"), Slug: "topic-one", UpdatedAt: mockTime},
 					{ID: 2, Name: "Topic Two", Description: nil, Slug: "topic-two", UpdatedAt: mockTime},
 				}
-				topicsUC.EXPECT().GetTopics(gomock.Any()).Return(mockTopics, nil)
+				topicsUC.EXPECT().GetTopics(gomock.Any(), dto.NewPagination(0, 0), false).Return(mockTopics, response.Pagination{Page: 1, Limit: 20, Total: 2}, nil)
 			},
 			response: `{"data":[{"id":1,"name":"Topic One","description":"Desc 1","slug":"topic-one","updated_at":"` + mockTime.Format("2006-01-02T15:04:05.999999Z07:00") + `"},{"id":2,"name":"Topic Two","description":null,"slug":"topic-two","updated_at":"` + mockTime.Format("2006-01-02T15:04:05.999999Z07:00") + `"}],"http_status":200}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
@@ -148,9 +171,9 @@ func TestTopicsHandler_GetTopics(t *testing.T) {
 		{
 			testname: "no topics found (empty slice)",
 			initMock: func() {
-				topicsUC.EXPECT().GetTopics(gomock.Any()).Return([]response.Topic{}, nil)
+				topicsUC.EXPECT().GetTopics(gomock.Any(), dto.NewPagination(0, 0), false).Return([]response.Topic{}, response.Pagination{Page: 1, Limit: 20}, nil)
 			},
-			response: `{"data":[],"http_status":200}`,
+			response: `{"data":[],"meta":{"page":1,"limit":20,"total":0},"http_status":200}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
 				assert.Equal(t, http.StatusOK, rr.Code)
 				assert.Equal(t, s, strings.TrimSpace(rr.Body.String()))
@@ -159,20 +182,43 @@ func TestTopicsHandler_GetTopics(t *testing.T) {
 		{
 			testname: "usecase error when getting topics",
 			initMock: func() {
-				topicsUC.EXPECT().GetTopics(gomock.Any()).Return(nil, errors.New("database error"))
+				topicsUC.EXPECT().GetTopics(gomock.Any(), dto.NewPagination(0, 0), false).Return(nil, response.Pagination{}, errors.New("database error"))
 			},
 			response: `{"code":422,"status":"unprocessable entity","message":"failed get topics"}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
 				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 			},
 		},
+		{
+			testname: "with counts",
+			query:    "?with_counts=true",
+			initMock: func() {
+				topicsUC.EXPECT().GetTopics(gomock.Any(), dto.NewPagination(0, 0), true).
+					Return([]response.Topic{{ID: 1, Name: "Sports", Slug: "sports", UpdatedAt: mockTime, ArticleCount: utils.IntPtr(0)}}, response.Pagination{Page: 1, Limit: 20, Total: 1}, nil)
+			},
+			response: `"article_count":0`,
+			assertion: func(rr *httptest.ResponseRecorder, s string) {
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), s)
+			},
+		},
+		{
+			testname: "invalid with_counts, expect 400",
+			query:    "?with_counts=maybe",
+			initMock: func() {},
+			response: `{"message":"invalid with_counts, must be true or false","http_status":400}`,
+			assertion: func(rr *httptest.ResponseRecorder, s string) {
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Equal(t, s, strings.TrimSpace(rr.Body.String()))
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
 
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/topics"+tt.query, nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
@@ -207,7 +253,7 @@ func TestTopicsHandler_UpdateTopic(t *testing.T) {
 				Name: utils.StringPtr("Updated Name"),
 			},
 			initMock: func() {
-				topicsUC.EXPECT().UpdateTopic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				topicsUC.EXPECT().UpdateTopic(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil).Return(nil)
 			},
 			response: `{"message":"topic updated","http_status":200}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
@@ -235,7 +281,7 @@ func TestTopicsHandler_UpdateTopic(t *testing.T) {
 				Name: utils.StringPtr("N"),
 			},
 			initMock: func() {},
-			response: `{"message":"update topic require one of [name, description, slug]","http_status":400}`,
+			response: `{"message":"update topic require one of [name, description, slug, parent_id (0 for top level)]","http_status":400}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
 				assert.Equal(t, http.StatusBadRequest, rr.Code)
 				assert.Equal(t, s, strings.TrimSpace(rr.Body.String()))
@@ -253,7 +299,7 @@ func TestTopicsHandler_UpdateTopic(t *testing.T) {
 					Code:    20005,
 					Message: "no field updated",
 				}
-				topicsUC.EXPECT().UpdateTopic(gomock.Any(), gomock.Any(), gomock.Any()).Return(customErr)
+				topicsUC.EXPECT().UpdateTopic(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil).Return(customErr)
 			},
 			response: `{"message":"no field updated","http_status":200}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
@@ -268,7 +314,7 @@ func TestTopicsHandler_UpdateTopic(t *testing.T) {
 				Name: utils.StringPtr("New Name"),
 			},
 			initMock: func() {
-				topicsUC.EXPECT().UpdateTopic(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal server error"))
+				topicsUC.EXPECT().UpdateTopic(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil).Return(errors.New("internal server error"))
 			},
 			response: `{"message":"internal server error","http_status":422}`,
 			assertion: func(rr *httptest.ResponseRecorder, s string) {
@@ -289,6 +335,7 @@ func TestTopicsHandler_UpdateTopic(t *testing.T) {
 			c := e.NewContext(req, rec)
 			c.SetParamNames("id")
 			c.SetParamValues(tt.paramID)
+			middleware.SetAuthUser(c, mockActor)
 			h.UpdateTopic(c)
 
 			tt.assertion(rec, tt.response)
@@ -296,6 +343,178 @@ func TestTopicsHandler_UpdateTopic(t *testing.T) {
 	}
 }
 
+func TestTopicsHandler_GetTopicTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name: "returns nested topics",
+			initMock: func() {
+				accessor.topicsUC.EXPECT().GetTopicTree(gomock.Any()).Return([]response.TopicNode{
+					{ID: 1, Name: "Sports", Slug: "sports", Children: []response.TopicNode{
+						{ID: 2, Name: "Football", Slug: "football", Children: []response.TopicNode{}},
+					}},
+				}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"children":[{"id":2,"name":"Football","description":null,"slug":"football","children":[]}]`)
+			},
+		},
+		{
+			name: "usecase error, expect 422",
+			initMock: func() {
+				accessor.topicsUC.EXPECT().GetTopicTree(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/topics/tree", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.GetTopicTree(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func TestTopicsHandler_GetTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicsHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	detail := response.TopicDetail{
+		Topic:          response.Topic{ID: 1, Slug: "sports", Version: 2, ArticleCount: utils.IntPtr(1)},
+		LatestArticles: []response.ArticleSummary{{ID: 4, Title: "Final", Slug: "final"}},
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		query     string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name: "returns topic detail with its ETag",
+			id:   "1",
+			initMock: func() {
+				accessor.topicsUC.EXPECT().GetTopic(gomock.Any(), "1", 0).Return(detail, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.True(t, strings.HasPrefix(rr.Header().Get("ETag"), `"2-`))
+				assert.Contains(t, rr.Body.String(), `"article_count":1`)
+				assert.Contains(t, rr.Body.String(), `"latest_articles":[{"id":4`)
+			},
+		},
+		{
+			name:  "by slug with latest",
+			id:    "sports",
+			query: "?latest=3",
+			initMock: func() {
+				accessor.topicsUC.EXPECT().GetTopic(gomock.Any(), "sports", 3).Return(detail, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:     "invalid latest, expect 400",
+			id:       "sports",
+			query:    "?latest=0",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "renamed slug, expect redirect",
+			id:   "soccer",
+			initMock: func() {
+				accessor.topicsUC.EXPECT().GetTopic(gomock.Any(), "soccer", 0).Return(response.TopicDetail{}, exception.ErrTopicNotFound)
+				accessor.topicsUC.EXPECT().ResolveTopicSlug(gomock.Any(), "soccer").Return("football", nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+				assert.Equal(t, "/api/v1/topics/football", rr.Header().Get("Location"))
+			},
+		},
+		{
+			name: "unknown topic, expect 422",
+			id:   "9",
+			initMock: func() {
+				accessor.topicsUC.EXPECT().GetTopic(gomock.Any(), "9", 0).Return(response.TopicDetail{}, exception.ErrTopicNotFound)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/topics/"+tt.id+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			err := h.GetTopic(c)
+			tt.assertion(rec, err)
+		})
+	}
+
+	t.Run("client holds the current representation, expect 304", func(t *testing.T) {
+		accessor.topicsUC.EXPECT().GetTopic(gomock.Any(), "1", 0).Return(detail, nil).Times(2)
+
+		get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/topics/1", nil)
+			if ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			assert.NoError(t, h.GetTopic(c))
+			return rec
+		}
+
+		tag := get("").Header().Get("ETag")
+		assert.Equal(t, http.StatusNotModified, get(tag).Code)
+	})
+}
+
 func Test_DeleteTopic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -307,6 +526,8 @@ func Test_DeleteTopic(t *testing.T) {
 	tests := []struct {
 		name      string
 		id        int
+		query     string
+		ifMatch   string
 		initMock  func(id int)
 		assertion func(*httptest.ResponseRecorder, error)
 	}{
@@ -315,7 +536,7 @@ func Test_DeleteTopic(t *testing.T) {
 			id:   1,
 			initMock: func(id int) {
 				accessor.topicsUC.EXPECT().
-					DeleteTopic(gomock.Any(), id).Return(nil)
+					DeleteTopic(gomock.Any(), gomock.Any(), id, nil, false).Return(nil)
 			},
 			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
@@ -327,27 +548,287 @@ func Test_DeleteTopic(t *testing.T) {
 			id:   1,
 			initMock: func(id int) {
 				accessor.topicsUC.EXPECT().
-					DeleteTopic(gomock.Any(), id).Return(errors.New("delete error"))
+					DeleteTopic(gomock.Any(), gomock.Any(), id, nil, false).Return(errors.New("delete error"))
 			},
 			assertion: func(rr *httptest.ResponseRecorder, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 			},
 		},
+		{
+			name:    "stale If-Match, expect 412",
+			id:      1,
+			ifMatch: `"3"`,
+			initMock: func(id int) {
+				accessor.topicsUC.EXPECT().
+					DeleteTopic(gomock.Any(), gomock.Any(), id, utils.IntPtr(3), false).Return(exception.ErrTopicVersionMismatch)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+			},
+		},
+		{
+			name: "published news use the topic, expect 409",
+			id:   1,
+			initMock: func(id int) {
+				accessor.topicsUC.EXPECT().
+					DeleteTopic(gomock.Any(), gomock.Any(), id, nil, false).Return(exception.NewTopicInUseError(3))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusConflict, rr.Code)
+				assert.Contains(t, rr.Body.String(), "topic is used by 3 published news")
+			},
+		},
+		{
+			name:  "force delete",
+			id:    1,
+			query: "?force=true",
+			initMock: func(id int) {
+				accessor.topicsUC.EXPECT().
+					DeleteTopic(gomock.Any(), gomock.Any(), id, nil, true).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+		{
+			name:     "invalid force, expect 400",
+			id:       1,
+			query:    "?force=yes",
+			initMock: func(id int) {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:    "If-Match with the ETag of a topic detail",
+			id:      1,
+			ifMatch: `"4-9c1d2e3f4a5b6c7d"`,
+			initMock: func(id int) {
+				accessor.topicsUC.EXPECT().
+					DeleteTopic(gomock.Any(), gomock.Any(), id, utils.IntPtr(4), false).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.initMock(tt.id)
 
-			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/news/%d", tt.id), nil)
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/topics/%d%s", tt.id, tt.query), nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("id")
 			c.SetParamValues(strconv.Itoa(tt.id))
 
+			middleware.SetAuthUser(c, mockActor)
 			err := h.DeleteTopic(c)
 			tt.assertion(rec, err)
 		})
 	}
 }
+
+func TestTopicsHandler_MergeTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := echo.New()
+	accessor := newTopicsHandlerAccessor(ctrl)
+	h := accessor.handler
+
+	tests := []struct {
+		name      string
+		id        string
+		body      string
+		ifMatch   string
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name: "merges topic successfully",
+			id:   "2",
+			body: `{"target_id":5}`,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().
+					MergeTopic(gomock.Any(), mockActor, 2, request.MergeTopicRequest{TargetID: 5}, nil).
+					Return(response.TopicMerge{SourceID: 2, TargetID: 5, ArticlesMoved: 3}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"articles_moved":3`)
+			},
+		},
+		{
+			name:     "missing target, expect 400",
+			id:       "2",
+			body:     `{}`,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "merge topic require [target_id]")
+			},
+		},
+		{
+			name:    "stale If-Match, expect 412",
+			id:      "2",
+			body:    `{"target_id":5}`,
+			ifMatch: `"1"`,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().
+					MergeTopic(gomock.Any(), mockActor, 2, request.MergeTopicRequest{TargetID: 5}, utils.IntPtr(1)).
+					Return(response.TopicMerge{}, exception.ErrTopicVersionMismatch)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+			},
+		},
+		{
+			name: "merge into a subtopic, expect 422",
+			id:   "2",
+			body: `{"target_id":5}`,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().
+					MergeTopic(gomock.Any(), mockActor, 2, request.MergeTopicRequest{TargetID: 5}, nil).
+					Return(response.TopicMerge{}, exception.ErrTopicMergeSubtopic)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/topics/"+tt.id+"/merge", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := h.MergeTopic(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func TestTopicsHandler_RestoreAndPurgeTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := echo.New()
+	accessor := newTopicsHandlerAccessor(ctrl)
+	h := accessor.handler
+
+	tests := []struct {
+		name      string
+		id        string
+		method    string
+		handle    echo.HandlerFunc
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "invalid id, expect 400",
+			id:       "abc",
+			method:   http.MethodPost,
+			handle:   h.RestoreTopic,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:   "restore topic not in trash, expect 422",
+			id:     "2",
+			method: http.MethodPost,
+			handle: h.RestoreTopic,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().RestoreTopic(gomock.Any(), mockActor, 2).Return(exception.ErrTopicNotInTrash)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+		{
+			name:   "restores topic successfully",
+			id:     "2",
+			method: http.MethodPost,
+			handle: h.RestoreTopic,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().RestoreTopic(gomock.Any(), mockActor, 2).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "success restore topic with id 2")
+			},
+		},
+		{
+			name:   "purge as editor, expect 403",
+			id:     "2",
+			method: http.MethodDelete,
+			handle: h.PurgeTopic,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().PurgeTopic(gomock.Any(), mockActor, 2).Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name:   "purges topic successfully",
+			id:     "2",
+			method: http.MethodDelete,
+			handle: h.PurgeTopic,
+			initMock: func() {
+				accessor.topicsUC.EXPECT().PurgeTopic(gomock.Any(), mockActor, 2).Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "success purge topic with id 2")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(tt.method, "/api/v1/trash/topics/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			middleware.SetAuthUser(c, mockActor)
+			err := tt.handle(c)
+			tt.assertion(rec, err)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"newsapi/internal/exception"
+	"newsapi/internal/middleware"
 	"newsapi/internal/model/request"
 	responder "newsapi/internal/model/response"
 	"newsapi/internal/usecase"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -29,14 +32,14 @@ func (h UsersHandler) CreateUser(c echo.Context) error {
 	var req request.CreateUserRequest
 	err := c.Bind(&req)
 	if err != nil {
-		log.Errorf("TopicHandler.bind: %w", err)
+		log.Errorf("TopicHandler.bind: %v", err)
 		return responder.ResponseBadRequest(c, "")
 	}
 
 	err = h.validator.Struct(req)
 	if err != nil {
-		log.Errorf("TopicHandler.validateStruct: %w", err)
-		return responder.ResponseBadRequest(c, "create topic require [name, email]")
+		log.Errorf("TopicHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "create user require [name, email, password]")
 	}
 
 	if err := h.uc.CreateUser(c.Request().Context(), req); err != nil {
@@ -45,3 +48,140 @@ func (h UsersHandler) CreateUser(c echo.Context) error {
 
 	return responder.ResponseCreated(c, "user registered")
 }
+
+func (h UsersHandler) UpdateUserRole(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	var req request.UpdateUserRoleRequest
+	err = c.Bind(&req)
+	if err != nil {
+		log.Errorf("UsersHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("UsersHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "update role require [role] one of [admin, editor, author, reader]")
+	}
+
+	if err := h.uc.UpdateUserRole(c.Request().Context(), actor, id, req); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, "user role updated")
+}
+
+func (h UsersHandler) GetUser(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	user, err := h.uc.GetUser(c.Request().Context(), actor, id)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, user, "")
+}
+
+func (h UsersHandler) GetUsers(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	pagination, err := parsePagination(c)
+	if err != nil {
+		return responder.ResponseBadRequest(c, err.Error())
+	}
+
+	users, meta, err := h.uc.GetUsers(c.Request().Context(), actor, pagination)
+	if err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		log.Errorf("UsersHandler.getUsers: %v", err)
+		return responder.ResponseUnprocessableEntity(c, "failed get users")
+	}
+
+	return responder.RespondOKWithMeta(c, users, meta, "")
+}
+
+func (h UsersHandler) UpdateUser(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	var req request.UpdateUserRequest
+	err = c.Bind(&req)
+	if err != nil {
+		log.Errorf("UsersHandler.bind: %v", err)
+		return responder.ResponseBadRequest(c, "")
+	}
+
+	err = h.validator.Struct(req)
+	if err != nil {
+		log.Errorf("UsersHandler.validateStruct: %v", err)
+		return responder.ResponseBadRequest(c, "update user require one of [name, email]")
+	}
+
+	if err := h.uc.UpdateUser(c.Request().Context(), actor, id, req); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		if err == exception.ErrNoFieldUpdate {
+			return responder.RespondOK(c, nil, "no field updated")
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, "user updated")
+}
+
+func (h UsersHandler) DeactivateUser(c echo.Context) error {
+	actor, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return responder.ResponseUnauthorize(c, "")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return responder.ResponseBadRequest(c, "invalid id")
+	}
+
+	if err := h.uc.DeactivateUser(c.Request().Context(), actor, id); err != nil {
+		if err == exception.ErrPermissionDenied {
+			return responder.ResponseForbidden(c, err.Error())
+		}
+		return responder.ResponseUnprocessableEntity(c, err.Error())
+	}
+
+	return responder.RespondOK(c, nil, "user deactivated")
+}
@@ -4,7 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"newsapi/internal/exception"
 	"newsapi/internal/handler"
+	"newsapi/internal/middleware"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/response"
 	mock_usecase "newsapi/mocks/usecase"
 	"strings"
 	"testing"
@@ -37,7 +41,7 @@ func Test_CreateUser(t *testing.T) {
 	accessor := newUsersHandlerAccessor(ctrl)
 	h := accessor.handler
 	e := echo.New()
-	body := `{"name":"user 1","email":"user@example.com"}`
+	body := `{"name":"user 1","email":"user@example.com","password":"secret-password"}`
 
 	tests := []struct {
 		name      string
@@ -94,3 +98,442 @@ func Test_CreateUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpdateUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUsersHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		id        string
+		body      string
+		auth      bool
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing auth user return 401",
+			id:       "2",
+			body:     `{"role":"editor"}`,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name:     "unknown role return 400",
+			id:       "2",
+			body:     `{"role":"owner"}`,
+			auth:     true,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "non admin return 403",
+			id:   "2",
+			body: `{"role":"editor"}`,
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().UpdateUserRole(gomock.Any(), gomock.Any(), 2, gomock.Any()).
+					Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "role updated return 200",
+			id:   "2",
+			body: `{"role":"editor"}`,
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().UpdateUserRole(gomock.Any(), gomock.Any(), 2, gomock.Any()).
+					Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/"+tt.id+"/role", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if tt.auth {
+				middleware.SetAuthUser(c, mockActor)
+			}
+			err := h.UpdateUserRole(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUsersHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		id        string
+		auth      bool
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing auth user return 401",
+			id:       "2",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name:     "invalid id return 400",
+			id:       "abc",
+			auth:     true,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "other user return 403",
+			id:   "2",
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().GetUser(gomock.Any(), mockActor, 2).
+					Return(response.User{}, exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "user not found return 422",
+			id:   "2",
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().GetUser(gomock.Any(), mockActor, 2).
+					Return(response.User{}, exception.ErrUserNotFound)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+		{
+			name: "get user return 200",
+			id:   "2",
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().GetUser(gomock.Any(), mockActor, 2).
+					Return(response.User{ID: 2, Name: "John"}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"name":"John"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if tt.auth {
+				middleware.SetAuthUser(c, mockActor)
+			}
+			err := h.GetUser(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_GetUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUsersHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		query     string
+		auth      bool
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing auth user return 401",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name:     "invalid limit return 400",
+			query:    "?limit=abc",
+			auth:     true,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Contains(t, rr.Body.String(), "invalid limit")
+			},
+		},
+		{
+			name:  "non admin return 403",
+			query: "",
+			auth:  true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().GetUsers(gomock.Any(), mockActor, gomock.Any()).
+					Return(nil, response.Pagination{}, exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name:  "list users return 200 with meta",
+			query: "?page=2&limit=5",
+			auth:  true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().GetUsers(gomock.Any(), mockActor, dto.Pagination{Page: 2, Limit: 5}).
+					Return([]response.User{{ID: 6}}, response.Pagination{Page: 2, Limit: 5, Total: 6}, nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), `"meta":{"page":2,"limit":5,"total":6}`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.auth {
+				middleware.SetAuthUser(c, mockActor)
+			}
+			err := h.GetUsers(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUsersHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		body      string
+		auth      bool
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing auth user return 401",
+			body:     `{"name":"Jane"}`,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name:     "invalid email return 400",
+			body:     `{"email":"not-an-email"}`,
+			auth:     true,
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name: "other user return 403",
+			body: `{"name":"Jane"}`,
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().UpdateUser(gomock.Any(), mockActor, 2, gomock.Any()).
+					Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "no field changed return 200",
+			body: `{"name":"John"}`,
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().UpdateUser(gomock.Any(), mockActor, 2, gomock.Any()).
+					Return(exception.ErrNoFieldUpdate)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "no field updated")
+			},
+		},
+		{
+			name: "duplicate email return 422",
+			body: `{"email":"jane@example.com"}`,
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().UpdateUser(gomock.Any(), mockActor, 2, gomock.Any()).
+					Return(errors.New("email: jane@example.com already exists"))
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			},
+		},
+		{
+			name: "user updated return 200",
+			body: `{"name":"Jane","email":"jane@example.com"}`,
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().UpdateUser(gomock.Any(), mockActor, 2, gomock.Any()).
+					Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), "user updated")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/2", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("2")
+			if tt.auth {
+				middleware.SetAuthUser(c, mockActor)
+			}
+			err := h.UpdateUser(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
+
+func Test_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUsersHandlerAccessor(ctrl)
+	h := accessor.handler
+	e := echo.New()
+
+	tests := []struct {
+		name      string
+		auth      bool
+		initMock  func()
+		assertion func(*httptest.ResponseRecorder, error)
+	}{
+		{
+			name:     "missing auth user return 401",
+			initMock: func() {},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name: "non admin return 403",
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().DeactivateUser(gomock.Any(), mockActor, 2).
+					Return(exception.ErrPermissionDenied)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+		{
+			name: "user deactivated return 200",
+			auth: true,
+			initMock: func() {
+				accessor.usersUC.EXPECT().DeactivateUser(gomock.Any(), mockActor, 2).
+					Return(nil)
+			},
+			assertion: func(rr *httptest.ResponseRecorder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/2", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("2")
+			if tt.auth {
+				middleware.SetAuthUser(c, mockActor)
+			}
+			err := h.DeactivateUser(c)
+
+			tt.assertion(rec, err)
+		})
+	}
+}
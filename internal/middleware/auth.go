@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"newsapi/internal/config/env"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	responder "newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const authUserKey = "auth_user"
+
+// ActorResolver loads the current identity of an active user
+type ActorResolver interface {
+	Actor(ctx context.Context, userID int) (dto.AuthUser, error)
+}
+
+// Authenticate verifies the bearer access token and stores the caller in the
+// echo context. The role is taken from the users table rather than the token,
+// so deactivated or demoted users lose their rights before the token expires.
+func Authenticate(config env.AuthConfig, actors ActorResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			token, found := strings.CutPrefix(header, "Bearer ")
+			if !found || token == "" {
+				return responder.ResponseUnauthorize(c, "missing bearer token")
+			}
+
+			claims, err := utils.ParseToken(config.JWTSecret, token, utils.AccessToken)
+			if err != nil {
+				return responder.ResponseUnauthorize(c, "invalid or expired token")
+			}
+
+			actor, err := actors.Actor(c.Request().Context(), claims.UserID)
+			if err != nil {
+				if err == exception.ErrUserNotFound {
+					return responder.ResponseUnauthorize(c, "invalid or expired token")
+				}
+				return responder.ResponseUnprocessableEntity(c, err.Error())
+			}
+
+			SetAuthUser(c, actor)
+			return next(c)
+		}
+	}
+}
+
+// AuthenticateOptional lets requests without an Authorization header through
+// anonymously and authenticates the others like Authenticate, so public reads
+// can show more to signed in users
+func AuthenticateOptional(config env.AuthConfig, actors ActorResolver) echo.MiddlewareFunc {
+	authenticate := Authenticate(config, actors)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authenticated := authenticate(next)
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+				return next(c)
+			}
+			return authenticated(c)
+		}
+	}
+}
+
+// SetAuthUser stores the authenticated user in the echo context
+func SetAuthUser(c echo.Context, user dto.AuthUser) {
+	c.Set(authUserKey, user)
+}
+
+// GetAuthUser returns the authenticated user set by Authenticate
+func GetAuthUser(c echo.Context) (dto.AuthUser, bool) {
+	user, ok := c.Get(authUserKey).(dto.AuthUser)
+	return user, ok
+}
@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"newsapi/internal/config/env"
+	"newsapi/internal/exception"
+	"newsapi/internal/middleware"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/utils"
+	mock_usecase "newsapi/mocks/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := env.AuthConfig{JWTSecret: "test-secret"}
+	actors := mock_usecase.NewMockAuthUsecase(ctrl)
+	e := echo.New()
+
+	accessToken, _ := utils.GenerateToken(config.JWTSecret, 7, "author", utils.AccessToken, time.Minute)
+	refreshToken, _ := utils.GenerateToken(config.JWTSecret, 7, "author", utils.RefreshToken, time.Minute)
+	expiredToken, _ := utils.GenerateToken(config.JWTSecret, 7, "author", utils.AccessToken, -time.Minute)
+	foreignToken, _ := utils.GenerateToken("other-secret", 7, "author", utils.AccessToken, time.Minute)
+
+	tests := []struct {
+		name       string
+		header     string
+		initMock   func()
+		wantStatus int
+	}{
+		{name: "missing header return 401", header: "", wantStatus: http.StatusUnauthorized},
+		{name: "non bearer scheme return 401", header: "Basic abc", wantStatus: http.StatusUnauthorized},
+		{name: "expired token return 401", header: "Bearer " + expiredToken, wantStatus: http.StatusUnauthorized},
+		{name: "refresh token return 401", header: "Bearer " + refreshToken, wantStatus: http.StatusUnauthorized},
+		{name: "token signed by other secret return 401", header: "Bearer " + foreignToken, wantStatus: http.StatusUnauthorized},
+		{
+			name:   "deactivated user return 401",
+			header: "Bearer " + accessToken,
+			initMock: func() {
+				actors.EXPECT().Actor(gomock.Any(), 7).Return(dto.AuthUser{}, exception.ErrUserNotFound)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "valid access token pass through with the current role",
+			header: "Bearer " + accessToken,
+			initMock: func() {
+				actors.EXPECT().Actor(gomock.Any(), 7).Return(dto.AuthUser{ID: 7, Role: entity.RoleReader}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.initMock != nil {
+				tt.initMock()
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/news", nil)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			next := func(c echo.Context) error {
+				user, ok := middleware.GetAuthUser(c)
+				assert.True(t, ok)
+				assert.Equal(t, 7, user.ID)
+				// the token still says author, the user was demoted since
+				assert.Equal(t, entity.RoleReader, user.Role)
+				return c.NoContent(http.StatusOK)
+			}
+
+			err := middleware.Authenticate(config, actors)(next)(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -8,10 +8,39 @@ import (
 	middleware "github.com/labstack/echo/v4/middleware"
 )
 
+// exportPath streams its response and is bound by ExportTimeout instead of
+// the service timeout
+const exportPath = "/api/v1/news/export"
+
+// importPath commits its batches as it reads the body and is bound by
+// ImportTimeout instead of the service timeout, so a large file is not cut off
+// halfway through
+const importPath = "/api/v1/news/import"
+
+func isExport(c echo.Context) bool {
+	return c.Path() == exportPath
+}
+
+func isImport(c echo.Context) bool {
+	return c.Path() == importPath
+}
+
 func SetupGlobalMiddleware(e *echo.Echo, config env.ApplicationConfig) {
-	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{Skipper: middleware.DefaultSkipper, Timeout: config.Timeout}))
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Skipper: func(c echo.Context) bool { return isExport(c) || isImport(c) },
+		Timeout: config.Timeout,
+	}))
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Skipper: func(c echo.Context) bool { return !isExport(c) },
+		Timeout: config.ExportTimeout,
+	}))
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Skipper: func(c echo.Context) bool { return !isImport(c) },
+		Timeout: config.ImportTimeout,
+	}))
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	// browsers only hand the ETag to scripts when it is exposed
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{ExposeHeaders: []string{"ETag"}}))
 	e.Use(echoprometheus.NewMiddleware("news_api_app"))
 }
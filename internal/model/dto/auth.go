@@ -0,0 +1,9 @@
+package dto
+
+import "newsapi/internal/model/entity"
+
+// AuthUser is the identity resolved from a verified access token
+type AuthUser struct {
+	ID   int
+	Role entity.UserRole
+}
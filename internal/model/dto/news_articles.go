@@ -1,8 +1,123 @@
 package dto
 
-import "newsapi/internal/model/entity"
+import (
+	"fmt"
+	"newsapi/internal/model/entity"
+	"strings"
+	"time"
+)
 
 type NewsFilter struct {
-	Status  entity.ArticleStatus
-	TopicID string
+	Query      string
+	Status     entity.ArticleStatus
+	AuthorID   int
+	TopicIDs   []int32
+	TopicSlugs []string
+	TopicMatch TopicMatch
+	// Subtopics also matches articles tagged with a descendant of a filtered topic
+	Subtopics  bool
+	Published  TimeRange
+	Created    TimeRange
+	Sort       NewsSort
+	Pagination Pagination
+	// PublishedOnly hides articles that are not published, except the ones
+	// written by OwnerID when it is set
+	PublishedOnly bool
+	OwnerID       int
+}
+
+// TimeRange bounds a timestamp column, From is inclusive and To exclusive,
+// a nil bound is left open
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// TopicMatch decides whether an article needs any or all of the filtered
+// topics, the zero value behaves like TopicMatchAny
+type TopicMatch string
+
+const (
+	TopicMatchAny TopicMatch = "any"
+	TopicMatchAll TopicMatch = "all"
+)
+
+func ParseTopicMatch(raw string) (TopicMatch, error) {
+	switch match := TopicMatch(strings.ToLower(raw)); match {
+	case "", TopicMatchAny, TopicMatchAll:
+		return match, nil
+	default:
+		return "", fmt.Errorf("unknown topic match %q", raw)
+	}
+}
+
+type NewsSortField string
+
+const (
+	SortByPublishedAt NewsSortField = "published_at"
+	SortByCreatedAt   NewsSortField = "created_at"
+	SortByUpdatedAt   NewsSortField = "updated_at"
+	SortByTitle       NewsSortField = "title"
+	SortByRelevance   NewsSortField = "relevance"
+)
+
+// NewsSort is the whitelisted ordering of a news listing, ties are always
+// broken by article id in the same direction
+type NewsSort struct {
+	Field NewsSortField
+	Desc  bool
+}
+
+var (
+	// DefaultNewsSort lists the newest published articles first
+	DefaultNewsSort = NewsSort{Field: SortByPublishedAt, Desc: true}
+	// RelevanceNewsSort lists the best full-text matches first
+	RelevanceNewsSort = NewsSort{Field: SortByRelevance, Desc: true}
+)
+
+// ParseNewsSort accepts "field" or "field:asc|desc", an empty value yields
+// DefaultNewsSort, title defaults to ascending and every other field to descending
+func ParseNewsSort(raw string) (NewsSort, error) {
+	if raw == "" {
+		return DefaultNewsSort, nil
+	}
+
+	field, direction, _ := strings.Cut(raw, ":")
+
+	var sort NewsSort
+	switch NewsSortField(field) {
+	case SortByPublishedAt, SortByCreatedAt, SortByUpdatedAt, SortByRelevance:
+		sort = NewsSort{Field: NewsSortField(field), Desc: true}
+	case SortByTitle:
+		sort = NewsSort{Field: SortByTitle}
+	default:
+		return NewsSort{}, fmt.Errorf("unknown sort field %q", field)
+	}
+
+	switch direction {
+	case "":
+	case "asc":
+		sort.Desc = false
+	case "desc":
+		sort.Desc = true
+	default:
+		return NewsSort{}, fmt.Errorf("unknown sort direction %q", direction)
+	}
+
+	return sort, nil
+}
+
+// OrDefault treats the zero value as DefaultNewsSort
+func (s NewsSort) OrDefault() NewsSort {
+	if s.Field == "" {
+		return DefaultNewsSort
+	}
+	return s
+}
+
+func (s NewsSort) String() string {
+	if s.Desc {
+		return string(s.Field) + ":desc"
+	}
+	return string(s.Field) + ":asc"
 }
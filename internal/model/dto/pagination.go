@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Pagination holds either a 1-based page number or a keyset cursor,
+// together with the page size of a list query
+type Pagination struct {
+	Page   int
+	Limit  int
+	Cursor *Cursor
+}
+
+// NewPagination clamps page and limit into their allowed range,
+// zero values fall back to the first page and the default limit
+func NewPagination(page int, limit int) Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	return Pagination{Page: page, Limit: limit}
+}
+
+// NewCursorPagination starts after the given cursor instead of at a page offset
+func NewCursorPagination(cursor Cursor, limit int) Pagination {
+	p := NewPagination(1, limit)
+	p.Page = 0
+	p.Cursor = &cursor
+	return p
+}
+
+func (p Pagination) Offset() int {
+	if p.Cursor != nil || p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.Limit
+}
+
+// Cursor points at the last row of the previous page, Value holds the
+// sort key of that row, ID breaks ties between equal sort keys and Sort
+// records the ordering the cursor was issued for
+type Cursor struct {
+	Value string `json:"v,omitempty"`
+	ID    int    `json:"id"`
+	Sort  string `json:"s,omitempty"`
+}
+
+// Encode returns the opaque form of the cursor handed out to clients
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return Cursor{}, err
+	}
+	if c.ID < 1 {
+		return Cursor{}, errors.New("cursor without id")
+	}
+
+	return c, nil
+}
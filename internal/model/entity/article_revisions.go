@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ArticleRevision struct {
+	ID            int           `db:"id"`
+	NewsArticleID int           `db:"news_article_id"`
+	Revision      int           `db:"revision"`
+	Title         string        `db:"title"`
+	Content       string        `db:"content"`
+	Summary       *string       `db:"summary"`
+	Slug          string        `db:"slug"`
+	TopicIDs      pq.Int32Array `db:"topic_ids"`
+	EditorID      *int          `db:"editor_id"`
+	EditorName    *string       `db:"editor_name"`
+	CreatedAt     time.Time     `db:"created_at"`
+}
@@ -11,13 +11,16 @@ type ArticleStatus string
 
 const (
 	StatusDraft     ArticleStatus = "draft"
+	StatusInReview  ArticleStatus = "in_review"
+	StatusApproved  ArticleStatus = "approved"
 	StatusPublished ArticleStatus = "published"
+	StatusScheduled ArticleStatus = "scheduled"
 	StatusDeleted   ArticleStatus = "deleted"
 )
 
 func VerifyStatus(status ArticleStatus) ArticleStatus {
 	switch status {
-	case StatusDraft, StatusPublished, StatusDeleted:
+	case StatusDraft, StatusInReview, StatusApproved, StatusPublished, StatusScheduled, StatusDeleted:
 		return status
 	default:
 		return ""
@@ -33,6 +36,7 @@ type NewsArticle struct {
 	Slug        string        `db:"slug"`
 	Status      ArticleStatus `db:"status"`
 	PublishedAt sql.NullTime  `db:"published_at"`
+	PublishAt   sql.NullTime  `db:"publish_at"`
 	CreatedAt   time.Time     `db:"created_at"`
 	UpdatedAt   time.Time     `db:"updated_at"`
 	DeletedAt   sql.NullTime  `db:"deleted_at"`
@@ -45,35 +49,73 @@ type ActiveNewsWithTopic struct {
 	Slug        string         `db:"slug"`
 	AuthorName  string         `db:"name"`
 	PublishedAt sql.NullTime   `db:"published_at"`
+	Version     int            `db:"version"`
+	Status      ArticleStatus  `db:"status"`
+	AuthorID    int            `db:"author_id"`
 	Topics      pq.StringArray `db:"topics"`
 }
 
 type NewsArticleWithTopicID struct {
-	ID          int           `db:"id"`
-	Title       string        `db:"title"`
-	Content     string        `db:"content"`
-	Summary     *string       `db:"summary"`
-	AuthorID    int           `db:"author_id"`
-	Slug        string        `db:"slug"`
-	Status      ArticleStatus `db:"status"`
-	PublishedAt sql.NullTime  `db:"published_at"`
-	CreatedAt   time.Time     `db:"created_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
-	DeletedAt   sql.NullTime  `db:"deleted_at"`
-	TopicIDs    pq.Int32Array `db:"topic_ids"`
+	ID            int           `db:"id"`
+	Title         string        `db:"title"`
+	Content       string        `db:"content"`
+	Summary       *string       `db:"summary"`
+	AuthorID      int           `db:"author_id"`
+	Slug          string        `db:"slug"`
+	Status        ArticleStatus `db:"status"`
+	PublishedAt   sql.NullTime  `db:"published_at"`
+	PublishAt     sql.NullTime  `db:"publish_at"`
+	CreatedAt     time.Time     `db:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"`
+	ReviewerID    *int          `db:"reviewer_id"`
+	ReviewComment *string       `db:"review_comment"`
+	DeletedAt     sql.NullTime  `db:"deleted_at"`
+	TopicIDs      pq.Int32Array `db:"topic_ids"`
+	Rank          *float32      `db:"rank"`
+	Snippet       *string       `db:"snippet"`
+}
+
+// NewsExport is an article with its author and live topics as it is exported
+type NewsExport struct {
+	ID          int            `db:"id"`
+	Title       string         `db:"title"`
+	Summary     *string        `db:"summary"`
+	Content     string         `db:"content"`
+	Slug        string         `db:"slug"`
+	Status      ArticleStatus  `db:"status"`
+	AuthorID    int            `db:"author_id"`
+	AuthorName  string         `db:"author_name"`
+	PublishedAt sql.NullTime   `db:"published_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+	TopicIDs    pq.Int32Array  `db:"topic_ids"`
+	TopicSlugs  pq.StringArray `db:"topic_slugs"`
 }
 
 type NewsArticleWithTopic struct {
-	ID          int           `db:"id"`
-	Title       string        `db:"title"`
-	Content     string        `db:"content"`
-	Summary     *string       `db:"summary"`
-	AuthorID    int           `db:"author_id"`
-	Slug        string        `db:"slug"`
-	Status      ArticleStatus `db:"status"`
-	PublishedAt sql.NullTime  `db:"published_at"`
-	CreatedAt   time.Time     `db:"created_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
-	DeletedAt   sql.NullTime  `db:"deleted_at"`
-	Topics      pq.Int32Array `db:"topic_ids"`
+	ID            int           `db:"id"`
+	Title         string        `db:"title"`
+	Content       string        `db:"content"`
+	Summary       *string       `db:"summary"`
+	AuthorID      int           `db:"author_id"`
+	Slug          string        `db:"slug"`
+	Status        ArticleStatus `db:"status"`
+	PublishedAt   sql.NullTime  `db:"published_at"`
+	PublishAt     sql.NullTime  `db:"publish_at"`
+	CreatedAt     time.Time     `db:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"`
+	ReviewerID    *int          `db:"reviewer_id"`
+	ReviewComment *string       `db:"review_comment"`
+	DeletedAt     sql.NullTime  `db:"deleted_at"`
+	Version       int           `db:"version"`
+	Topics        pq.Int32Array `db:"topic_ids"`
+}
+
+type PublishedNewsWithTopic struct {
+	ID          int            `db:"id"`
+	Title       string         `db:"title"`
+	Summary     *string        `db:"summary"`
+	Slug        string         `db:"slug"`
+	PublishedAt sql.NullTime   `db:"published_at"`
+	Topics      pq.StringArray `db:"topics"`
 }
@@ -0,0 +1,11 @@
+package entity
+
+import "database/sql"
+
+// RetiredSlug is a slug an article or topic used before it was renamed
+type RetiredSlug struct {
+	Slug        string       `db:"slug"`
+	OwnerID     int          `db:"owner_id"`
+	CurrentSlug string       `db:"current_slug"`
+	DeletedAt   sql.NullTime `db:"deleted_at"`
+}
@@ -10,7 +10,21 @@ type Topic struct {
 	Name        string       `db:"name"`
 	Description *string      `db:"description"`
 	Slug        string       `db:"slug"`
+	ParentID    *int         `db:"parent_id"`
 	CreatedAt   time.Time    `db:"created_at"`
 	UpdatedAt   time.Time    `db:"updated_at"`
 	DeletedAt   sql.NullTime `db:"deleted_at"`
+	Version     int          `db:"version"`
+}
+
+// TopicArticleCount is the number of published articles filed under a topic
+type TopicArticleCount struct {
+	TopicID int `db:"topic_id"`
+	Count   int `db:"article_count"`
+}
+
+// TopicSlugRef ties a current or retired topic slug to the topic using it
+type TopicSlugRef struct {
+	Slug    string `db:"slug"`
+	TopicID int    `db:"topic_id"`
 }
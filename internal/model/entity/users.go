@@ -1,11 +1,27 @@
 package entity
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
+
+type UserRole string
+
+const (
+	RoleAdmin  UserRole = "admin"
+	RoleEditor UserRole = "editor"
+	RoleAuthor UserRole = "author"
+	RoleReader UserRole = "reader"
+)
 
 // User represents a user entity
 type User struct {
-	ID        int       `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Email     string    `json:"email" db:"email"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        int          `json:"id" db:"id"`
+	Name      string       `json:"name" db:"name"`
+	Email     string       `json:"email" db:"email"`
+	Password  *string      `json:"-" db:"password"`
+	Role      UserRole     `json:"role" db:"role"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+	DeletedAt sql.NullTime `json:"-" db:"deleted_at"`
 }
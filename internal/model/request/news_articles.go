@@ -1,21 +1,54 @@
 package request
 
+import "time"
+
+// CreateNewsArticleRequest represents the request payload for creating a news article,
+// the author defaults to the caller and only admins may set AuthorID to someone else.
+// Slug is derived from the title when omitted.
+// Only editors and admins may create published or scheduled articles, PublishAt is
+// required with the scheduled status and must lie in the future
 type CreateNewsArticleRequest struct {
-	Title    string  `json:"title" validate:"required,min=5,max=255"`
-	Content  string  `json:"content" validate:"required,min=10"`
-	Summary  *string `json:"summary,omitempty" validate:"omitempty,max=500"`
-	AuthorID int     `json:"author_id" validate:"required,min=1"`
-	Slug     string  `json:"slug" validate:"required,min=5,max=255"`
-	Status   *string `json:"status,omitempty" validate:"omitempty,oneof=draft published deleted"`
-	TopicIDs []int   `json:"topic_ids,omitempty" validate:"omitempty,dive,min=1"`
+	Title     string     `json:"title" validate:"required,min=5,max=255"`
+	Content   string     `json:"content" validate:"required,min=10"`
+	Summary   *string    `json:"summary,omitempty" validate:"omitempty,max=500"`
+	AuthorID  *int       `json:"author_id,omitempty" validate:"omitempty,min=1"`
+	Slug      string     `json:"slug,omitempty" validate:"omitempty,slug,min=5,max=255"`
+	Status    *string    `json:"status,omitempty" validate:"omitempty,oneof=draft in_review published scheduled"`
+	TopicIDs  []int      `json:"topic_ids,omitempty" validate:"omitempty,dive,min=1"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
 }
 
-// UpdateNewsArticleRequest represents the request payload for updating a news article
+// UpdateNewsArticleRequest represents the request payload for updating a news article,
+// status changes go through TransitionNewsArticleRequest instead
 type UpdateNewsArticleRequest struct {
 	Title    *string `json:"title,omitempty" validate:"omitempty,min=5,max=255"`
 	Content  *string `json:"content,omitempty" validate:"omitempty,min=10"`
 	Summary  *string `json:"summary,omitempty" validate:"omitempty,max=500"`
-	Slug     *string `json:"slug,omitempty" validate:"omitempty,min=5,max=255"`
-	Status   *string `json:"status,omitempty" validate:"omitempty,oneof=draft published deleted"`
+	Slug     *string `json:"slug,omitempty" validate:"omitempty,slug,min=5,max=255"`
 	TopicIDs []int32 `json:"topic_ids,omitempty" validate:"omitempty,dive,min=1"`
 }
+
+// BulkNewsRequest applies one action to every article of Slugs. Status,
+// ReviewerID and Comment go with set_status, ReviewerID only when moving to
+// in_review, TopicIDs with add_topics and remove_topics. When Atomic is set the
+// action is applied to all articles or to none.
+type BulkNewsRequest struct {
+	Slugs      []string `json:"slugs" validate:"required,min=1,max=100,dive,required"`
+	Action     string   `json:"action" validate:"required,oneof=set_status add_topics remove_topics delete"`
+	Status     string   `json:"status,omitempty" validate:"required_if=Action set_status,omitempty,oneof=draft in_review approved published"`
+	ReviewerID *int     `json:"reviewer_id,omitempty" validate:"omitempty,min=1"`
+	Comment    *string  `json:"comment,omitempty" validate:"omitempty,min=1,max=2000"`
+	TopicIDs   []int    `json:"topic_ids,omitempty" validate:"required_if=Action add_topics,required_if=Action remove_topics,omitempty,dive,min=1"`
+	Atomic     bool     `json:"atomic,omitempty"`
+}
+
+// TransitionNewsArticleRequest moves an article through the editorial workflow.
+// ReviewerID assigns a reviewer when submitting for review, Comment is kept as
+// the review comment and is required when a reviewer rejects an article back to
+// draft, PublishAt is required when scheduling
+type TransitionNewsArticleRequest struct {
+	Status     string     `json:"status" validate:"required,oneof=draft in_review approved scheduled published"`
+	ReviewerID *int       `json:"reviewer_id,omitempty" validate:"omitempty,min=1"`
+	Comment    *string    `json:"comment,omitempty" validate:"omitempty,min=1,max=2000"`
+	PublishAt  *time.Time `json:"publish_at,omitempty"`
+}
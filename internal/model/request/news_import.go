@@ -0,0 +1,236 @@
+package request
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// formats accepted by NewsImportReader
+const (
+	ImportFormatNDJSON = "ndjson"
+	ImportFormatCSV    = "csv"
+)
+
+// maxImportLine bounds a single NDJSON line, article content included
+const maxImportLine = 8 << 20
+
+// CSVListSeparator splits topic_ids and topic_slugs inside one CSV cell
+const CSVListSeparator = "|"
+
+// ImportNewsArticleRequest is one article of an import. It takes everything
+// CreateNewsArticleRequest does, topics may also be given by slug.
+type ImportNewsArticleRequest struct {
+	CreateNewsArticleRequest
+	TopicSlugs []string `json:"topic_slugs,omitempty" validate:"omitempty,dive,slug"`
+}
+
+// NewsImportRecord is an article read from line Line of an import, Err is set
+// when the line could not be decoded or failed validation
+type NewsImportRecord struct {
+	Line    int
+	Article ImportNewsArticleRequest
+	Err     error
+}
+
+// NewsImportReader streams the articles of an NDJSON or CSV import one record
+// at a time and validates every record like CreateNewsArticleRequest
+type NewsImportReader struct {
+	validator *validator.Validate
+	next      func() (NewsImportRecord, error)
+}
+
+// NewNewsImportReader reads format from r. A CSV import starts with a header
+// naming its columns after the JSON fields, topic_ids and topic_slugs hold
+// several values separated by "|". The columns only a CSV export has are
+// skipped.
+func NewNewsImportReader(r io.Reader, format string, v *validator.Validate) (*NewsImportReader, error) {
+	reader := &NewsImportReader{validator: v}
+
+	switch format {
+	case ImportFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+		line := 0
+		reader.next = func() (NewsImportRecord, error) {
+			for scanner.Scan() {
+				line++
+				if strings.TrimSpace(scanner.Text()) == "" {
+					continue
+				}
+
+				record := NewsImportRecord{Line: line}
+				record.Err = json.Unmarshal(scanner.Bytes(), &record.Article)
+				return record, nil
+			}
+			if err := scanner.Err(); err != nil {
+				return NewsImportRecord{}, err
+			}
+			return NewsImportRecord{}, io.EOF
+		}
+
+	case ImportFormatCSV:
+		csvReader := csv.NewReader(r)
+		header, err := csvReader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed read csv header: %w", err)
+		}
+		columns, err := csvColumns(header)
+		if err != nil {
+			return nil, err
+		}
+		reader.next = func() (NewsImportRecord, error) {
+			row, err := csvReader.Read()
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return NewsImportRecord{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+			}
+			if err != nil {
+				return NewsImportRecord{}, err
+			}
+
+			line, _ := csvReader.FieldPos(0)
+			record := NewsImportRecord{Line: line}
+			record.Article, record.Err = csvArticle(columns, row)
+			return record, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown import format %q, use %s or %s", format, ImportFormatNDJSON, ImportFormatCSV)
+	}
+
+	return reader, nil
+}
+
+// Next returns the next record, io.EOF once the input is read. Any other error
+// means the input itself cannot be read any further.
+func (r *NewsImportReader) Next() (NewsImportRecord, error) {
+	record, err := r.next()
+	if err != nil {
+		return record, err
+	}
+
+	if record.Err == nil {
+		record.Err = describeValidation(r.validator.Struct(record.Article))
+	}
+
+	return record, nil
+}
+
+var csvImportColumns = []string{
+	"title", "content", "summary", "author_id", "slug", "status", "topic_ids", "topic_slugs", "publish_at",
+}
+
+// csvExportOnlyColumns are written by the news export but cannot be set by an
+// import and are skipped. The other columns of an export are checked like any
+// import: approved or scheduled articles and taken slugs are reported per line.
+var csvExportOnlyColumns = []string{"id", "author_name", "published_at", "created_at", "updated_at"}
+
+func csvColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(csvExportOnlyColumns, name) {
+			continue
+		}
+		if !slices.Contains(csvImportColumns, name) {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		columns[name] = i
+	}
+
+	return columns, nil
+}
+
+// csvArticle maps a CSV row onto the fields its header names, empty cells are
+// left unset
+func csvArticle(columns map[string]int, row []string) (ImportNewsArticleRequest, error) {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	optional := func(name string) *string {
+		if value := cell(name); value != "" {
+			return &value
+		}
+		return nil
+	}
+
+	article := ImportNewsArticleRequest{}
+	article.Title = cell("title")
+	article.Content = cell("content")
+	article.Summary = optional("summary")
+	article.Slug = cell("slug")
+	article.Status = optional("status")
+
+	if value := cell("author_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return article, fmt.Errorf("author_id %q is not a number", value)
+		}
+		article.AuthorID = &id
+	}
+	if value := cell("topic_ids"); value != "" {
+		for _, part := range strings.Split(value, CSVListSeparator) {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return article, fmt.Errorf("topic_ids %q is not a list of numbers", value)
+			}
+			article.TopicIDs = append(article.TopicIDs, id)
+		}
+	}
+	if value := cell("topic_slugs"); value != "" {
+		for _, part := range strings.Split(value, CSVListSeparator) {
+			article.TopicSlugs = append(article.TopicSlugs, strings.TrimSpace(part))
+		}
+	}
+	if value := cell("publish_at"); value != "" {
+		publishAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return article, fmt.Errorf("publish_at %q is not an RFC 3339 time", value)
+		}
+		article.PublishAt = &publishAt
+	}
+
+	return article, nil
+}
+
+// describeValidation turns validator errors into "field: tag" pairs a person
+// fixing the import file can act on
+func describeValidation(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	parts := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		parts = append(parts, fmt.Sprintf("%s: %s", snakeCase(fieldErr.Field()), fieldErr.Tag()))
+	}
+
+	return errors.New("invalid " + strings.Join(parts, ", "))
+}
+
+// snakeCase names a field like its JSON key, TopicIDs becomes topic_ids
+func snakeCase(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(rune(field[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
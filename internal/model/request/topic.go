@@ -1,13 +1,25 @@
 package request
 
+// CreateTopicRequest represents the request payload for creating a topic, Slug is
+// derived from the name when omitted
 type CreateTopicRequest struct {
 	Name        string  `json:"name" validate:"required,min=2,max=100"`
 	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
-	Slug        string  `json:"slug" validate:"required,min=2,max=100"`
+	Slug        string  `json:"slug,omitempty" validate:"omitempty,slug,min=2,max=100"`
+	ParentID    *int    `json:"parent_id,omitempty" validate:"omitempty,min=1"`
 }
 
+// UpdateTopicRequest changes the given fields only, a ParentID of 0 moves the
+// topic to the top level
 type UpdateTopicRequest struct {
 	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
 	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
-	Slug        *string `json:"slug,omitempty" validate:"omitempty,min=2,max=100"`
+	Slug        *string `json:"slug,omitempty" validate:"omitempty,slug,min=2,max=100"`
+	ParentID    *int    `json:"parent_id,omitempty" validate:"omitempty,min=0"`
+}
+
+// MergeTopicRequest names the topic that takes over the links and slugs of the
+// merged one
+type MergeTopicRequest struct {
+	TargetID int `json:"target_id" validate:"required,min=1"`
 }
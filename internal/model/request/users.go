@@ -1,6 +1,16 @@
 package request
 
 type CreateUserRequest struct {
-	Name  string `json:"name" validate:"required,min=2,max=100"`
-	Email string `json:"email" validate:"required,email,max=255"`
+	Name     string `json:"name" validate:"required,min=2,max=100"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin editor author reader"`
+}
+
+type UpdateUserRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Email *string `json:"email,omitempty" validate:"omitempty,email,max=255"`
 }
@@ -0,0 +1,15 @@
+package request
+
+import (
+	"newsapi/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidations adds the custom tags used by the request payloads to v
+func RegisterValidations(v *validator.Validate) {
+	// registration only fails on an empty tag or a nil func
+	_ = v.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return utils.IsSlug(fl.Field().String())
+	})
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"newsapi/internal/model/entity"
+)
+
+type Author struct {
+	ID   int             `json:"id"`
+	Name string          `json:"name"`
+	Role entity.UserRole `json:"role"`
+}
+
+func AuthorSerializer(entity entity.User) Author {
+	return Author{
+		ID:   entity.ID,
+		Name: entity.Name,
+		Role: entity.Role,
+	}
+}
+
+type AuthorProfile struct {
+	Author   Author           `json:"author"`
+	Articles []ArticleSummary `json:"articles"`
+}
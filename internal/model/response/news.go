@@ -6,43 +6,57 @@ import (
 )
 
 type NewsArticle struct {
-	ID          int                  `json:"id"`
-	Title       string               `json:"title"`
-	Content     string               `json:"content"`
-	Summary     *string              `json:"summary"`
-	AuthorID    int                  `json:"author_id"`
-	Slug        string               `json:"slug"`
-	TopicIDs    []int32              `json:"topic_ids"`
-	Status      entity.ArticleStatus `json:"status"`
-	PublishedAt *time.Time           `json:"published_at"`
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
-	DeletedAt   *time.Time           `json:"deleted_at"`
+	ID            int                  `json:"id"`
+	Title         string               `json:"title"`
+	Content       string               `json:"content"`
+	Summary       *string              `json:"summary"`
+	AuthorID      int                  `json:"author_id"`
+	Slug          string               `json:"slug"`
+	TopicIDs      []int32              `json:"topic_ids"`
+	Status        entity.ArticleStatus `json:"status"`
+	PublishedAt   *time.Time           `json:"published_at"`
+	PublishAt     *time.Time           `json:"publish_at,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	DeletedAt     *time.Time           `json:"deleted_at"`
+	ReviewerID    *int                 `json:"reviewer_id,omitempty"`
+	ReviewComment *string              `json:"review_comment,omitempty"`
+	Rank          *float32             `json:"rank,omitempty"`
+	Snippet       *string              `json:"snippet,omitempty"`
 }
 
 func NewsArticleSeriliazer(entity entity.NewsArticleWithTopicID) NewsArticle {
 	pub := &entity.PublishedAt.Time
+	sched := &entity.PublishAt.Time
 	del := &entity.DeletedAt.Time
 
 	if !entity.PublishedAt.Valid {
 		pub = nil
 	}
+	if !entity.PublishAt.Valid {
+		sched = nil
+	}
 	if !entity.DeletedAt.Valid {
 		del = nil
 	}
 	return NewsArticle{
-		ID:          entity.ID,
-		Title:       entity.Title,
-		Content:     entity.Content,
-		Summary:     entity.Summary,
-		AuthorID:    entity.AuthorID,
-		Slug:        entity.Slug,
-		Status:      entity.Status,
-		TopicIDs:    append([]int32(nil), entity.TopicIDs...),
-		PublishedAt: pub,
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
-		DeletedAt:   del,
+		ID:            entity.ID,
+		Title:         entity.Title,
+		Content:       entity.Content,
+		Summary:       entity.Summary,
+		AuthorID:      entity.AuthorID,
+		Slug:          entity.Slug,
+		Status:        entity.Status,
+		TopicIDs:      append([]int32(nil), entity.TopicIDs...),
+		PublishedAt:   pub,
+		PublishAt:     sched,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+		DeletedAt:     del,
+		ReviewerID:    entity.ReviewerID,
+		ReviewComment: entity.ReviewComment,
+		Rank:          entity.Rank,
+		Snippet:       entity.Snippet,
 	}
 }
 
@@ -51,9 +65,10 @@ type NewsArticleWithTopic struct {
 	Title       string     `json:"title"`
 	Content     string     `json:"content"`
 	Slug        string     `json:"slug"`
-	AuthorName  string     `json:"author_name"`
+	AuthorName  string     `json:"author_name,omitempty"`
 	PublishedAt *time.Time `json:"published_at"`
 	Topics      []string   `json:"topics"`
+	Version     int        `json:"version"`
 }
 
 func NewsArticleWithTopicSerializer(entity entity.ActiveNewsWithTopic) NewsArticleWithTopic {
@@ -70,5 +85,134 @@ func NewsArticleWithTopicSerializer(entity entity.ActiveNewsWithTopic) NewsArtic
 		AuthorName:  entity.AuthorName,
 		PublishedAt: pub,
 		Topics:      append([]string(nil), entity.Topics...),
+		Version:     entity.Version,
+	}
+}
+
+// NewsStatus is the editorial state of an article after a transition
+type NewsStatus struct {
+	Slug          string               `json:"slug"`
+	Status        entity.ArticleStatus `json:"status"`
+	ReviewerID    *int                 `json:"reviewer_id"`
+	ReviewComment *string              `json:"review_comment"`
+	PublishedAt   *time.Time           `json:"published_at"`
+	PublishAt     *time.Time           `json:"publish_at"`
+}
+
+func NewsStatusSerializer(entity entity.NewsArticleWithTopic) NewsStatus {
+	pub := &entity.PublishedAt.Time
+	sched := &entity.PublishAt.Time
+
+	if !entity.PublishedAt.Valid {
+		pub = nil
+	}
+	if !entity.PublishAt.Valid {
+		sched = nil
+	}
+	return NewsStatus{
+		Slug:          entity.Slug,
+		Status:        entity.Status,
+		ReviewerID:    entity.ReviewerID,
+		ReviewComment: entity.ReviewComment,
+		PublishedAt:   pub,
+		PublishAt:     sched,
+	}
+}
+
+// ArticleSummary is a published article as listed on author and topic pages
+type ArticleSummary struct {
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	Summary     *string    `json:"summary"`
+	Slug        string     `json:"slug"`
+	PublishedAt *time.Time `json:"published_at"`
+	Topics      []string   `json:"topics"`
+}
+
+func ArticleSummarySerializer(entity entity.PublishedNewsWithTopic) ArticleSummary {
+	pub := &entity.PublishedAt.Time
+	if !entity.PublishedAt.Valid {
+		pub = nil
+	}
+
+	return ArticleSummary{
+		ID:          entity.ID,
+		Title:       entity.Title,
+		Summary:     entity.Summary,
+		Slug:        entity.Slug,
+		PublishedAt: pub,
+		Topics:      append([]string{}, entity.Topics...),
+	}
+}
+
+// BulkNewsResult reports a bulk action article by article
+type BulkNewsResult struct {
+	Action    string         `json:"action"`
+	Atomic    bool           `json:"atomic"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	Items     []BulkNewsItem `json:"items"`
+}
+
+// BulkNewsItem is the outcome of a bulk action on one article
+type BulkNewsItem struct {
+	Slug  string `json:"slug"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// NewsImport reports an import, a dry run counts the articles that would have
+// been imported
+type NewsImport struct {
+	DryRun   bool              `json:"dry_run"`
+	Total    int               `json:"total"`
+	Imported int               `json:"imported"`
+	Failed   int               `json:"failed"`
+	Errors   []NewsImportError `json:"errors"`
+}
+
+// NewsImportError is a line of an import that was not imported
+type NewsImportError struct {
+	Line  int    `json:"line"`
+	Slug  string `json:"slug,omitempty"`
+	Error string `json:"error"`
+}
+
+// NewsExport is one exported article with its author and topics
+type NewsExport struct {
+	ID          int                  `json:"id"`
+	Title       string               `json:"title"`
+	Summary     *string              `json:"summary"`
+	Content     string               `json:"content"`
+	Slug        string               `json:"slug"`
+	Status      entity.ArticleStatus `json:"status"`
+	AuthorID    int                  `json:"author_id"`
+	AuthorName  string               `json:"author_name"`
+	PublishedAt *time.Time           `json:"published_at"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	TopicIDs    []int32              `json:"topic_ids"`
+	TopicSlugs  []string             `json:"topic_slugs"`
+}
+
+func NewsExportSerializer(entity entity.NewsExport) NewsExport {
+	pub := &entity.PublishedAt.Time
+	if !entity.PublishedAt.Valid {
+		pub = nil
+	}
+	return NewsExport{
+		ID:          entity.ID,
+		Title:       entity.Title,
+		Summary:     entity.Summary,
+		Content:     entity.Content,
+		Slug:        entity.Slug,
+		Status:      entity.Status,
+		AuthorID:    entity.AuthorID,
+		AuthorName:  entity.AuthorName,
+		PublishedAt: pub,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+		TopicIDs:    entity.TopicIDs,
+		TopicSlugs:  entity.TopicSlugs,
 	}
 }
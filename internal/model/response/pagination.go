@@ -0,0 +1,18 @@
+package response
+
+import "newsapi/internal/model/dto"
+
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func PaginationSerializer(pagination dto.Pagination, total int) Pagination {
+	return Pagination{
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+		Total: total,
+	}
+}
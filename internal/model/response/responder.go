@@ -8,6 +8,7 @@ import (
 
 type Response struct {
 	Data       any    `json:"data,omitempty"`
+	Meta       any    `json:"meta,omitempty"`
 	Message    string `json:"message,omitempty"`
 	HTTPStatus int    `json:"http_status,omitempty"`
 }
@@ -28,6 +29,15 @@ func RespondOK(c echo.Context, data interface{}, message string) error {
 	})
 }
 
+func RespondOKWithMeta(c echo.Context, data interface{}, meta interface{}, message string) error {
+	return BuildResponse(c, Response{
+		Message:    message,
+		Data:       data,
+		Meta:       meta,
+		HTTPStatus: http.StatusOK,
+	})
+}
+
 func ResponseCreated(c echo.Context, message string) error {
 	temp := message
 	if message == "" {
@@ -71,3 +81,36 @@ func ResponseUnauthorize(c echo.Context, message string) error {
 		HTTPStatus: http.StatusUnauthorized,
 	})
 }
+
+func ResponseForbidden(c echo.Context, message string) error {
+	temp := message
+	if message == "" {
+		temp = http.StatusText(http.StatusForbidden)
+	}
+	return BuildResponse(c, Response{
+		Message:    temp,
+		HTTPStatus: http.StatusForbidden,
+	})
+}
+
+func ResponseConflict(c echo.Context, message string) error {
+	temp := message
+	if message == "" {
+		temp = http.StatusText(http.StatusConflict)
+	}
+	return BuildResponse(c, Response{
+		Message:    temp,
+		HTTPStatus: http.StatusConflict,
+	})
+}
+
+func ResponsePreconditionFailed(c echo.Context, message string) error {
+	temp := message
+	if message == "" {
+		temp = http.StatusText(http.StatusPreconditionFailed)
+	}
+	return BuildResponse(c, Response{
+		Message:    temp,
+		HTTPStatus: http.StatusPreconditionFailed,
+	})
+}
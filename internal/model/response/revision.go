@@ -0,0 +1,44 @@
+package response
+
+import (
+	"newsapi/internal/model/entity"
+	"time"
+)
+
+type ArticleRevision struct {
+	Revision   int       `json:"revision"`
+	Title      string    `json:"title"`
+	Content    string    `json:"content"`
+	Summary    *string   `json:"summary"`
+	Slug       string    `json:"slug"`
+	TopicIDs   []int32   `json:"topic_ids"`
+	EditorID   *int      `json:"editor_id"`
+	EditorName *string   `json:"editor_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func ArticleRevisionSerializer(entity entity.ArticleRevision) ArticleRevision {
+	return ArticleRevision{
+		Revision:   entity.Revision,
+		Title:      entity.Title,
+		Content:    entity.Content,
+		Summary:    entity.Summary,
+		Slug:       entity.Slug,
+		TopicIDs:   append([]int32{}, entity.TopicIDs...),
+		EditorID:   entity.EditorID,
+		EditorName: entity.EditorName,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
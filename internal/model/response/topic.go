@@ -6,19 +6,97 @@ import (
 )
 
 type Topic struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Description *string   `json:"description"` // nullable
-	Slug        string    `json:"slug" db:"slug"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"` // nullable
+	Slug        string     `json:"slug" db:"slug"`
+	ParentID    *int       `json:"parent_id"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Version     int        `json:"version"`
+	// ArticleCount is only filled in when the client asks for counts
+	ArticleCount *int `json:"article_count,omitempty"`
 }
 
 func TopicSeriliazer(entity entity.Topic) Topic {
+	del := &entity.DeletedAt.Time
+	if !entity.DeletedAt.Valid {
+		del = nil
+	}
+
 	return Topic{
 		ID:          entity.ID,
 		Name:        entity.Name,
 		Description: entity.Description,
 		Slug:        entity.Slug,
+		ParentID:    entity.ParentID,
 		UpdatedAt:   entity.UpdatedAt,
+		DeletedAt:   del,
+		Version:     entity.Version,
 	}
 }
+
+// TopicDetail is a topic with its most recently published articles
+type TopicDetail struct {
+	Topic
+	LatestArticles []ArticleSummary `json:"latest_articles"`
+}
+
+// TopicMerge reports a merged topic and how many articles moved to the target
+type TopicMerge struct {
+	SourceID      int `json:"source_id"`
+	TargetID      int `json:"target_id"`
+	ArticlesMoved int `json:"articles_moved"`
+}
+
+// TopicNode is a topic with its subtopics nested below it
+type TopicNode struct {
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	Slug        string      `json:"slug"`
+	Children    []TopicNode `json:"children"`
+}
+
+// TopicTreeSerializer nests topics below their parents, topics whose parent is
+// not among them become roots. Siblings keep the order of topics.
+func TopicTreeSerializer(topics []entity.Topic) []TopicNode {
+	known := make(map[int]bool, len(topics))
+	for _, t := range topics {
+		known[t.ID] = true
+	}
+
+	children := make(map[int][]entity.Topic)
+	var roots []entity.Topic
+	for _, t := range topics {
+		if t.ParentID != nil && known[*t.ParentID] {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+			continue
+		}
+		roots = append(roots, t)
+	}
+
+	visited := make(map[int]bool, len(topics))
+	var nest func(level []entity.Topic) []TopicNode
+	nest = func(level []entity.Topic) []TopicNode {
+		nodes := []TopicNode{}
+		for _, t := range level {
+			// a cyclic hierarchy must not recurse forever
+			if visited[t.ID] {
+				continue
+			}
+			visited[t.ID] = true
+
+			nodes = append(nodes, TopicNode{
+				ID:          t.ID,
+				Name:        t.Name,
+				Description: t.Description,
+				Slug:        t.Slug,
+				Children:    nest(children[t.ID]),
+			})
+		}
+		return nodes
+	}
+
+	return nest(roots)
+}
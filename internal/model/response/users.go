@@ -0,0 +1,26 @@
+package response
+
+import (
+	"newsapi/internal/model/entity"
+	"time"
+)
+
+type User struct {
+	ID        int             `json:"id"`
+	Name      string          `json:"name"`
+	Email     string          `json:"email"`
+	Role      entity.UserRole `json:"role"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func UserSerializer(entity entity.User) User {
+	return User{
+		ID:        entity.ID,
+		Name:      entity.Name,
+		Email:     entity.Email,
+		Role:      entity.Role,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
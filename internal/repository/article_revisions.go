@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type articleRevisionsRepository struct {
+	db *sqlx.DB
+}
+
+func NewArticleRevisionsRepository(db *sqlx.DB) ArticleRevisionsRepository {
+	return articleRevisionsRepository{db: db}
+}
+
+// Create stores revision as the next revision number of its article and fills
+// in the assigned id, revision and created_at
+func (r articleRevisionsRepository) Create(ctx context.Context, revision *entity.ArticleRevision) error {
+	query := `
+		INSERT INTO article_revisions (news_article_id, revision, title, content, summary, slug, topic_ids, editor_id)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5, $6, $7
+		FROM article_revisions
+		WHERE news_article_id = $1
+		RETURNING id, revision, created_at`
+
+	topicIDs := revision.TopicIDs
+	if topicIDs == nil {
+		topicIDs = []int32{}
+	}
+
+	return conn(ctx, r.db).QueryRowxContext(
+		ctx,
+		query,
+		revision.NewsArticleID,
+		revision.Title,
+		revision.Content,
+		revision.Summary,
+		revision.Slug,
+		topicIDs,
+		revision.EditorID,
+	).Scan(&revision.ID, &revision.Revision, &revision.CreatedAt)
+}
+
+// CreateSnapshots records the current state of every article of articleIDs as
+// its next revision in one statement
+func (r articleRevisionsRepository) CreateSnapshots(ctx context.Context, articleIDs []int, editorID int) error {
+	query := `
+		INSERT INTO article_revisions (news_article_id, revision, title, content, summary, slug, topic_ids, editor_id)
+		SELECT a.id,
+			COALESCE((SELECT MAX(ar.revision) FROM article_revisions ar WHERE ar.news_article_id = a.id), 0) + 1,
+			a.title, a.content, a.summary, a.slug,
+			COALESCE((
+				SELECT array_agg(nt.topic_id ORDER BY nt.topic_id) FROM news_topics nt
+				WHERE nt.news_article_id = a.id AND ` + liveTopicLinks + `
+			), '{}'),
+			$2
+		FROM news_articles a
+		WHERE a.id = ANY($1::int[])`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, int32Array(articleIDs), editorID)
+	return err
+}
+
+func (r articleRevisionsRepository) GetByArticleID(
+	ctx context.Context,
+	articleID int,
+	pagination dto.Pagination,
+) ([]entity.ArticleRevision, error) {
+	query := `
+		SELECT ar.id, ar.news_article_id, ar.revision, ar.title, ar.content, ar.summary, ar.slug,
+			ar.topic_ids, ar.editor_id, u.name AS editor_name, ar.created_at
+		FROM article_revisions ar
+		LEFT JOIN users u ON u.id = ar.editor_id
+		WHERE ar.news_article_id = $1
+		ORDER BY ar.revision DESC
+		LIMIT $2 OFFSET $3`
+
+	var revisions []entity.ArticleRevision
+	err := conn(ctx, r.db).SelectContext(ctx, &revisions, query, articleID, pagination.Limit, pagination.Offset())
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r articleRevisionsRepository) CountByArticleID(ctx context.Context, articleID int) (int, error) {
+	query := `SELECT COUNT(*) FROM article_revisions WHERE news_article_id = $1`
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query, articleID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r articleRevisionsRepository) GetByRevision(
+	ctx context.Context,
+	articleID int,
+	revision int,
+) (entity.ArticleRevision, error) {
+	query := `
+		SELECT ar.id, ar.news_article_id, ar.revision, ar.title, ar.content, ar.summary, ar.slug,
+			ar.topic_ids, ar.editor_id, u.name AS editor_name, ar.created_at
+		FROM article_revisions ar
+		LEFT JOIN users u ON u.id = ar.editor_id
+		WHERE ar.news_article_id = $1 AND ar.revision = $2`
+
+	var result entity.ArticleRevision
+	err := conn(ctx, r.db).GetContext(ctx, &result, query, articleID, revision)
+	if err != nil {
+		return entity.ArticleRevision{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,166 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/repository"
+	"newsapi/internal/utils"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateArticleRevision(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewArticleRevisionsRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := regexp.QuoteMeta(`
+		INSERT INTO article_revisions (news_article_id, revision, title, content, summary, slug, topic_ids, editor_id)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5, $6, $7
+		FROM article_revisions
+		WHERE news_article_id = $1
+		RETURNING id, revision, created_at`)
+	now := time.Now()
+
+	tests := []struct {
+		testname  string
+		revision  entity.ArticleRevision
+		initMock  func()
+		assertion func(revision entity.ArticleRevision, err error)
+	}{
+		{
+			testname: "next revision number is assigned",
+			revision: entity.ArticleRevision{
+				NewsArticleID: 10,
+				Title:         "Title",
+				Content:       "Content",
+				Slug:          "slug-10",
+				TopicIDs:      []int32{1, 2},
+				EditorID:      utils.IntPtr(3),
+			},
+			initMock: func() {
+				mockSql.ExpectQuery(query).
+					WithArgs(10, "Title", "Content", nil, "slug-10", pq.Int32Array{1, 2}, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "revision", "created_at"}).AddRow(7, 3, now))
+			},
+			assertion: func(revision entity.ArticleRevision, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 7, revision.ID)
+				assert.Equal(t, 3, revision.Revision)
+				assert.Equal(t, now, revision.CreatedAt)
+			},
+		},
+		{
+			testname: "nil topics are stored as an empty array",
+			revision: entity.ArticleRevision{NewsArticleID: 11, Title: "Title", Content: "Content", Slug: "slug-11"},
+			initMock: func() {
+				mockSql.ExpectQuery(query).
+					WithArgs(11, "Title", "Content", nil, "slug-11", pq.Int32Array{}, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "revision", "created_at"}).AddRow(8, 1, now))
+			},
+			assertion: func(revision entity.ArticleRevision, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, revision.Revision)
+			},
+		},
+		{
+			testname: "insert returns error",
+			revision: entity.ArticleRevision{NewsArticleID: 12, Title: "Title", Content: "Content", Slug: "slug-12"},
+			initMock: func() {
+				mockSql.ExpectQuery(query).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(revision entity.ArticleRevision, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			revision := tt.revision
+			err := repos.Create(ctx, &revision)
+			tt.assertion(revision, err)
+		})
+	}
+}
+
+func Test_GetArticleRevisions(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewArticleRevisionsRepository(sqlxDB)
+	ctx := context.Background()
+
+	selectQuery := `
+		SELECT ar.id, ar.news_article_id, ar.revision, ar.title, ar.content, ar.summary, ar.slug,
+			ar.topic_ids, ar.editor_id, u.name AS editor_name, ar.created_at
+		FROM article_revisions ar
+		LEFT JOIN users u ON u.id = ar.editor_id`
+	columns := []string{"id", "news_article_id", "revision", "title", "content", "summary", "slug", "topic_ids", "editor_id", "editor_name", "created_at"}
+
+	t.Run("list revisions newest first", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(selectQuery+`
+		WHERE ar.news_article_id = $1
+		ORDER BY ar.revision DESC
+		LIMIT $2 OFFSET $3`)).
+			WithArgs(10, 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, 10, 2, "Title v2", "Content", nil, "slug-10", pq.Int32Array{1}, 3, "Editor", time.Now()).
+				AddRow(1, 10, 1, "Title v1", "Content", nil, "slug-10", pq.Int32Array{}, nil, nil, time.Now()))
+
+		revisions, err := repos.GetByArticleID(ctx, 10, dto.NewPagination(1, 10))
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 2)
+		assert.Equal(t, "Editor", *revisions[0].EditorName)
+		assert.Nil(t, revisions[1].EditorID)
+	})
+
+	t.Run("list revisions returns error", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnError(errors.New("db error"))
+
+		revisions, err := repos.GetByArticleID(ctx, 10, dto.NewPagination(1, 10))
+		assert.Error(t, err)
+		assert.Nil(t, revisions)
+	})
+
+	t.Run("count revisions", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM article_revisions WHERE news_article_id = $1`)).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		total, err := repos.CountByArticleID(ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+	})
+
+	t.Run("get single revision", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(selectQuery+`
+		WHERE ar.news_article_id = $1 AND ar.revision = $2`)).
+			WithArgs(10, 1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, 10, 1, "Title v1", "Content", "Summary", "slug-10", pq.Int32Array{1, 2}, 3, "Editor", time.Now()))
+
+		revision, err := repos.GetByRevision(ctx, 10, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, revision.Revision)
+		assert.Equal(t, pq.Int32Array{1, 2}, revision.TopicIDs)
+	})
+
+	t.Run("get unknown revision", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(10, 9).WillReturnError(sql.ErrNoRows)
+
+		_, err := repos.GetByRevision(ctx, 10, 9)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -2,13 +2,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type newsArticlesRepository struct {
@@ -23,11 +27,11 @@ func NewNewsArticlesRepository(db *sqlx.DB) NewsArticlesRepository {
 
 func (r newsArticlesRepository) Create(ctx context.Context, entity *entity.NewsArticle) (int, error) {
 	query := `INSERT INTO news_articles 
-		(title, content, summary, author_id, slug, status, published_at)
-		VALUES (:title, :content, :summary, :author_id, :slug, :status, :published_at) 
+		(title, content, summary, author_id, slug, status, published_at, publish_at)
+		VALUES (:title, :content, :summary, :author_id, :slug, :status, :published_at, :publish_at) 
 		RETURNING id`
 
-	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	stmt, err := conn(ctx, r.db).PrepareNamedContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -47,18 +51,68 @@ func (r newsArticlesRepository) Create(ctx context.Context, entity *entity.NewsA
 	return entity.ID, nil
 }
 
+// CreateBatch inserts articles with a single multi-row insert and returns their
+// ids in the order of articles
+func (r newsArticlesRepository) CreateBatch(ctx context.Context, articles []entity.NewsArticle) ([]int, error) {
+	if len(articles) == 0 {
+		return nil, nil
+	}
+
+	const columns = 8
+	valueArgs := make([]string, 0, len(articles))
+	values := make([]interface{}, 0, len(articles)*columns)
+	for i, article := range articles {
+		n := i * columns
+		valueArgs = append(valueArgs, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8))
+		values = append(values, article.Title, article.Content, article.Summary, article.AuthorID,
+			article.Slug, article.Status, article.PublishedAt, article.PublishAt)
+	}
+
+	query := `INSERT INTO news_articles
+		(title, content, summary, author_id, slug, status, published_at, publish_at)
+		VALUES ` + strings.Join(valueArgs, ",") + `
+		RETURNING id, slug`
+
+	var created []struct {
+		ID   int    `db:"id"`
+		Slug string `db:"slug"`
+	}
+	err := conn(ctx, r.db).SelectContext(ctx, &created, query, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	// slugs are unique, they tie the returned rows back to articles
+	idBySlug := make(map[string]int, len(created))
+	for _, row := range created {
+		idBySlug[row.Slug] = row.ID
+	}
+	ids := make([]int, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, idBySlug[article.Slug])
+	}
+
+	return ids, nil
+}
+
+// liveTopicLinks keeps the news_topics rows aliased nt that were not removed
+// and point to a topic that is not deleted, so deleted topics drop out of reads
+const liveTopicLinks = `nt.deleted_at IS NULL AND EXISTS (SELECT 1 FROM topics lt WHERE lt.id = nt.topic_id AND lt.deleted_at IS NULL)`
+
 func (r newsArticlesRepository) GetArticleBySlug(ctx context.Context, slug string) (entity.NewsArticleWithTopic, error) {
 	query := `
 			SELECT a.id, a.title, a.content, a.summary, a.author_id, a.slug, 
-			a.status, a.published_at, a.created_at, a.updated_at,
-			COALESCE(array_agg(nt.topic_id ORDER BY nt.topic_id), '{}') AS topic_ids 
+			a.status, a.published_at, a.publish_at, a.created_at, a.updated_at,
+			a.reviewer_id, a.review_comment, a.version,
+			COALESCE(array_agg(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids
 			FROM news_articles a
-			INNER JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND ` + liveTopicLinks + `
 			WHERE slug = $1 AND a.deleted_at is NULL
 			GROUP BY a.id`
 	var newsArticle entity.NewsArticleWithTopic
 
-	err := r.db.GetContext(ctx, &newsArticle, query, slug)
+	err := conn(ctx, r.db).GetContext(ctx, &newsArticle, query, slug)
 	if err != nil {
 		return entity.NewsArticleWithTopic{}, err
 	}
@@ -66,6 +120,28 @@ func (r newsArticlesRepository) GetArticleBySlug(ctx context.Context, slug strin
 	return newsArticle, nil
 }
 
+// GetArticlesBySlugs loads the live articles behind slugs like GetArticleBySlug,
+// slugs without an article are left out
+func (r newsArticlesRepository) GetArticlesBySlugs(ctx context.Context, slugs []string) ([]entity.NewsArticleWithTopic, error) {
+	query := `
+			SELECT a.id, a.title, a.content, a.summary, a.author_id, a.slug,
+			a.status, a.published_at, a.publish_at, a.created_at, a.updated_at,
+			a.reviewer_id, a.review_comment, a.version,
+			COALESCE(array_agg(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids
+			FROM news_articles a
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND ` + liveTopicLinks + `
+			WHERE a.slug = ANY($1::text[]) AND a.deleted_at IS NULL
+			GROUP BY a.id`
+
+	articles := []entity.NewsArticleWithTopic{}
+	err := conn(ctx, r.db).SelectContext(ctx, &articles, query, pq.StringArray(slugs))
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
 func (r newsArticlesRepository) GetActiveArticleBySlug(ctx context.Context, slug string) (entity.ActiveNewsWithTopic, error) {
 	query := `SELECT
 				a.id,
@@ -73,18 +149,21 @@ func (r newsArticlesRepository) GetActiveArticleBySlug(ctx context.Context, slug
 				a.content,
 				a.slug,
 				a.published_at,
-				u.name,
+				a.version,
+				a.status,
+				a.author_id,
+				COALESCE(u.name, '') AS name,
 				COALESCE(array_agg(t.name ORDER BY t.name) FILTER (WHERE t.name IS NOT NULL), '{}') AS topics
 			FROM news_articles a
-			INNER JOIN users u on u.id = a.author_id 
-			INNER JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
-			INNER JOIN topics t ON t.id = nt.topic_id
+			LEFT JOIN users u on u.id = a.author_id AND u.deleted_at IS NULL
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN topics t ON t.id = nt.topic_id AND t.deleted_at IS NULL
 			WHERE a.slug = $1 AND a.deleted_at IS NULL
 			GROUP BY a.id, u.name`
 
 	var newsArticle entity.ActiveNewsWithTopic
 
-	err := r.db.GetContext(ctx, &newsArticle, query, slug)
+	err := conn(ctx, r.db).GetContext(ctx, &newsArticle, query, slug)
 	if err != nil {
 		return entity.ActiveNewsWithTopic{}, err
 	}
@@ -92,6 +171,74 @@ func (r newsArticlesRepository) GetActiveArticleBySlug(ctx context.Context, slug
 	return newsArticle, nil
 }
 
+func (r newsArticlesRepository) GetPublishedByAuthor(
+	ctx context.Context,
+	authorID int,
+	pagination dto.Pagination,
+) ([]entity.PublishedNewsWithTopic, error) {
+	query := `SELECT
+				a.id,
+				a.title,
+				a.summary,
+				a.slug,
+				a.published_at,
+				COALESCE(array_agg(t.name ORDER BY t.name) FILTER (WHERE t.name IS NOT NULL), '{}') AS topics
+			FROM news_articles a
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN topics t ON t.id = nt.topic_id AND t.deleted_at IS NULL
+			WHERE a.author_id = $1 AND a.status = 'published' AND a.deleted_at IS NULL
+			GROUP BY a.id
+			ORDER BY a.published_at DESC, a.id DESC
+			LIMIT $2 OFFSET $3`
+
+	articles := []entity.PublishedNewsWithTopic{}
+	err := conn(ctx, r.db).SelectContext(ctx, &articles, query, authorID, pagination.Limit, pagination.Offset())
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (r newsArticlesRepository) CountPublishedByAuthor(ctx context.Context, authorID int) (int, error) {
+	query := `SELECT COUNT(*) FROM news_articles
+			WHERE author_id = $1 AND status = 'published' AND deleted_at IS NULL`
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query, authorID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// the search text is always bound as $1 by newsFilterConditions so the rank,
+// snippet and match expressions can share a single parameter
+const (
+	newsSearchQuery   = "websearch_to_tsquery('english', $1)"
+	newsSearchRank    = "ts_rank(na.search_vector, " + newsSearchQuery + ")"
+	newsSearchSnippet = "ts_headline('english', na.content, " + newsSearchQuery + ", 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10')"
+)
+
+// newsSortColumn maps a whitelisted sort field to its SQL expression and the
+// type its cursor value is cast to. Drafts that were never published fall
+// back to their creation time so the publish time keyset stays total
+func newsSortColumn(field dto.NewsSortField) (string, string) {
+	switch field {
+	case dto.SortByRelevance:
+		return newsSearchRank, "real"
+	case dto.SortByCreatedAt:
+		return "na.created_at", "timestamptz"
+	case dto.SortByUpdatedAt:
+		return "na.updated_at", "timestamptz"
+	case dto.SortByTitle:
+		return "na.title", "text"
+	default:
+		return "COALESCE(na.published_at, na.created_at)", "timestamptz"
+	}
+}
+
 func (r newsArticlesRepository) GetAll(ctx context.Context, filter dto.NewsFilter) ([]entity.NewsArticleWithTopicID, error) {
 	query := `
 			SELECT
@@ -102,38 +249,318 @@ func (r newsArticlesRepository) GetAll(ctx context.Context, filter dto.NewsFilte
 				na.slug,
 				na.status,
 				na.published_at,
+				na.publish_at,
 				na.created_at,
-				ARRAY_AGG(nt.topic_id) AS topic_ids
+				na.updated_at,
+				na.reviewer_id,
+				na.review_comment,
+				COALESCE(ARRAY_AGG(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids`
+
+	if filter.Query != "" {
+		query += fmt.Sprintf(", %s AS rank, %s AS snippet", newsSearchRank, newsSearchSnippet)
+	}
+
+	query += `
+			FROM news_articles na
+			LEFT JOIN news_topics nt ON na.id = nt.news_article_id AND ` + liveTopicLinks + `
+			WHERE
+				na.deleted_at IS NULL
+		`
+
+	conditions, args := newsFilterConditions(filter)
+	query += conditions
+	paramIdx := len(args) + 1
+
+	sort := filter.Sort.OrDefault()
+	if sort.Field == dto.SortByRelevance && filter.Query == "" {
+		sort = dto.DefaultNewsSort
+	}
+	sortColumn, sortType := newsSortColumn(sort.Field)
+	direction, comparator := "ASC", ">"
+	if sort.Desc {
+		direction, comparator = "DESC", "<"
+	}
+
+	if cursor := filter.Pagination.Cursor; cursor != nil {
+		query += fmt.Sprintf(" AND (%s, na.id) %s ($%d::%s, $%d)", sortColumn, comparator, paramIdx, sortType, paramIdx+1)
+		args = append(args, cursor.Value, cursor.ID)
+		paramIdx += 2
+	}
+
+	query += " GROUP BY na.id, na.title, na.summary, na.author_id, na.slug, na.status, na.published_at, na.publish_at, na.created_at, na.updated_at, na.reviewer_id, na.review_comment"
+	query += fmt.Sprintf(" ORDER BY %s %s, na.id %s", sortColumn, direction, direction)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIdx, paramIdx+1)
+	args = append(args, filter.Pagination.Limit, filter.Pagination.Offset())
+
+	var newsArticles []entity.NewsArticleWithTopicID
+	err := conn(ctx, r.db).SelectContext(ctx, &newsArticles, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newsArticles, nil
+}
+
+// exportFetchSize is the number of rows Export fetches from its cursor at a time
+const exportFetchSize = 1000
+
+// Export walks every article matching filter in its sort order, ignoring
+// pagination, and hands them to fn one by one. The rows come from a server-side
+// cursor in a read-only transaction, so only exportFetchSize of them are held in
+// memory at any time. An error from fn stops the export and is returned.
+func (r newsArticlesRepository) Export(ctx context.Context, filter dto.NewsFilter, fn func(entity.NewsExport) error) error {
+	query := `
+			SELECT na.id, na.title, na.summary, na.content, na.slug, na.status,
+				na.author_id, COALESCE(u.name, '') AS author_name,
+				na.published_at, na.created_at, na.updated_at,
+				COALESCE(tp.topic_ids, '{}') AS topic_ids, COALESCE(tp.topic_slugs, '{}') AS topic_slugs
 			FROM news_articles na
-			LEFT JOIN news_topics nt ON na.id = nt.news_article_id
+			LEFT JOIN users u ON u.id = na.author_id
+			LEFT JOIN LATERAL (
+				SELECT array_agg(t.id ORDER BY t.id) AS topic_ids, array_agg(t.slug ORDER BY t.id) AS topic_slugs
+				FROM news_topics nt
+				INNER JOIN topics t ON t.id = nt.topic_id AND t.deleted_at IS NULL
+				WHERE nt.news_article_id = na.id AND nt.deleted_at IS NULL
+			) tp ON TRUE
 			WHERE
 				na.deleted_at IS NULL
-				AND nt.deleted_at IS NULL
 		`
 
+	conditions, args := newsFilterConditions(filter)
+	query += conditions
+
+	sort := filter.Sort.OrDefault()
+	if sort.Field == dto.SortByRelevance && filter.Query == "" {
+		sort = dto.DefaultNewsSort
+	}
+	sortColumn, _ := newsSortColumn(sort.Field)
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, na.id %s", sortColumn, direction, direction)
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DECLARE news_export NO SCROLL CURSOR FOR "+query, args...)
+	if err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM news_export", exportFetchSize)
+	for {
+		fetched, err := fetchExport(ctx, tx, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			return tx.Commit()
+		}
+	}
+}
+
+// fetchExport hands one fetch of the export cursor to fn and returns its size
+func fetchExport(ctx context.Context, tx *sqlx.Tx, fetch string, fn func(entity.NewsExport) error) (int, error) {
+	rows, err := tx.QueryxContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		var row entity.NewsExport
+		if err := rows.StructScan(&row); err != nil {
+			return fetched, err
+		}
+		fetched++
+
+		if err := fn(row); err != nil {
+			return fetched, err
+		}
+	}
+
+	return fetched, rows.Err()
+}
+
+// Count returns the number of articles matching the filter, ignoring pagination
+func (r newsArticlesRepository) Count(ctx context.Context, filter dto.NewsFilter) (int, error) {
+	query := `
+			SELECT COUNT(DISTINCT na.id)
+			FROM news_articles na
+			LEFT JOIN news_topics nt ON na.id = nt.news_article_id AND ` + liveTopicLinks + `
+			WHERE
+				na.deleted_at IS NULL
+		`
+
+	conditions, args := newsFilterConditions(filter)
+	query += conditions
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func newsFilterConditions(filter dto.NewsFilter) (string, []interface{}) {
+	var conditions string
 	var args []interface{}
 	paramIdx := 1
 
+	if filter.Query != "" {
+		conditions += " AND na.search_vector @@ " + newsSearchQuery
+		args = append(args, filter.Query)
+		paramIdx++
+	}
 	if filter.Status != "" {
-		query += fmt.Sprintf(" AND na.status = $%d", paramIdx)
+		conditions += fmt.Sprintf(" AND na.status = $%d", paramIdx)
 		args = append(args, filter.Status)
 		paramIdx++
 	}
-	if filter.TopicID != "" {
-		query += fmt.Sprintf(" AND nt.topic_id = $%d", paramIdx)
-		args = append(args, filter.TopicID)
+	if filter.AuthorID != 0 {
+		conditions += fmt.Sprintf(" AND na.author_id = $%d", paramIdx)
+		args = append(args, filter.AuthorID)
 		paramIdx++
 	}
+	if filter.PublishedOnly && filter.OwnerID != 0 {
+		conditions += fmt.Sprintf(" AND (na.status = 'published' OR na.author_id = $%d)", paramIdx)
+		args = append(args, filter.OwnerID)
+		paramIdx++
+	} else if filter.PublishedOnly {
+		conditions += " AND na.status = 'published'"
+	}
+	for _, bound := range []struct {
+		column string
+		op     string
+		value  *time.Time
+	}{
+		{"na.published_at", ">=", filter.Published.From},
+		{"na.published_at", "<", filter.Published.To},
+		{"na.created_at", ">=", filter.Created.From},
+		{"na.created_at", "<", filter.Created.To},
+	} {
+		if bound.value == nil {
+			continue
+		}
+		conditions += fmt.Sprintf(" AND %s %s $%d", bound.column, bound.op, paramIdx)
+		args = append(args, *bound.value)
+		paramIdx++
+	}
+	if len(filter.TopicIDs) > 0 || len(filter.TopicSlugs) > 0 {
+		topicConditions, topicArgs := newsTopicConditions(filter, paramIdx)
+		conditions += topicConditions
+		args = append(args, topicArgs...)
+	}
 
-	query += " GROUP BY na.id, na.title, na.summary, na.author_id, na.slug, na.status, na.published_at, na.created_at"
+	return conditions, args
+}
 
-	var newsArticles []entity.NewsArticleWithTopicID
-	err := r.db.SelectContext(ctx, &newsArticles, query, args...)
-	if err != nil {
-		return nil, err
+// active topics of the outer article, kept in a subquery so filtering never
+// trims the aggregated topic_ids of the matched rows
+const newsActiveTopics = `SELECT %s FROM news_topics ft
+		INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+		WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL`
+
+// canonicalTopicSlugs maps the requested topic slugs to the current slug of
+// their topic, so filters using a renamed topic slug keep matching
+const canonicalTopicSlugs = `ARRAY(SELECT COALESCE(
+		(SELECT ct.slug FROM topic_slugs ts INNER JOIN topics ct ON ct.id = ts.topic_id WHERE ts.slug = rs.slug),
+		rs.slug) FROM unnest($%d::text[]) AS rs(slug))`
+
+// topicSubtrees expands a seed of (root, id) rows naming the requested topics
+// with every live descendant, keeping the requested topic as root. UNION skips
+// rows already seen so a cyclic hierarchy cannot loop forever.
+const topicSubtrees = `WITH RECURSIVE subtree(root, id) AS (
+		%s
+		UNION
+		SELECT s.root, c.id FROM topics c
+		INNER JOIN subtree s ON c.parent_id = s.id
+		WHERE c.deleted_at IS NULL
+	)`
+
+// seeds of topicSubtrees, an unknown slug still yields a root without topic
+const (
+	topicIDSeed   = `SELECT r.id::text, r.id FROM unnest($%d::int[]) AS r(id)`
+	topicSlugSeed = `SELECT req.slug, t.id FROM unnest(` + canonicalTopicSlugs + `) AS req(slug)
+		LEFT JOIN topics t ON t.slug = req.slug AND t.deleted_at IS NULL`
+)
+
+// newsTopicConditions matches articles linked to any of the requested topic
+// ids or slugs, or with TopicMatchAll to every one of them
+func newsTopicConditions(filter dto.NewsFilter, paramIdx int) (string, []interface{}) {
+	if filter.Subtopics {
+		return newsSubtopicConditions(filter, paramIdx)
 	}
 
-	return newsArticles, nil
+	var args []interface{}
+
+	if filter.TopicMatch == dto.TopicMatchAll {
+		var conditions string
+		if len(filter.TopicIDs) > 0 {
+			conditions += fmt.Sprintf(" AND ARRAY("+newsActiveTopics+") @> $%d::int[]", "ft.topic_id", paramIdx)
+			args = append(args, pq.Int32Array(filter.TopicIDs))
+			paramIdx++
+		}
+		if len(filter.TopicSlugs) > 0 {
+			conditions += fmt.Sprintf(" AND ARRAY("+newsActiveTopics+") @> "+canonicalTopicSlugs, "t.slug", paramIdx)
+			args = append(args, pq.StringArray(filter.TopicSlugs))
+		}
+		return conditions, args
+	}
+
+	var matches []string
+	if len(filter.TopicIDs) > 0 {
+		matches = append(matches, fmt.Sprintf("ft.topic_id = ANY($%d::int[])", paramIdx))
+		args = append(args, pq.Int32Array(filter.TopicIDs))
+		paramIdx++
+	}
+	if len(filter.TopicSlugs) > 0 {
+		matches = append(matches, fmt.Sprintf("t.slug = ANY("+canonicalTopicSlugs+")", paramIdx))
+		args = append(args, pq.StringArray(filter.TopicSlugs))
+	}
+
+	return fmt.Sprintf(" AND EXISTS ("+newsActiveTopics+" AND (%s))", "1", strings.Join(matches, " OR ")), args
+}
+
+// newsSubtopicConditions behaves like newsTopicConditions but a requested topic
+// is also matched by any of its descendants
+func newsSubtopicConditions(filter dto.NewsFilter, paramIdx int) (string, []interface{}) {
+	var seeds []string
+	var args []interface{}
+	if len(filter.TopicIDs) > 0 {
+		seeds = append(seeds, fmt.Sprintf(topicIDSeed, paramIdx))
+		args = append(args, pq.Int32Array(filter.TopicIDs))
+		paramIdx++
+	}
+	if len(filter.TopicSlugs) > 0 {
+		seeds = append(seeds, fmt.Sprintf(topicSlugSeed, paramIdx))
+		args = append(args, pq.StringArray(filter.TopicSlugs))
+	}
+
+	var conditions string
+	if filter.TopicMatch == dto.TopicMatchAll {
+		// no requested topic may be left without a linked topic in its subtree
+		for _, seed := range seeds {
+			conditions += fmt.Sprintf(` AND NOT EXISTS (`+topicSubtrees+`
+				SELECT 1 FROM subtree s GROUP BY s.root
+				HAVING NOT COALESCE(bool_or(s.id IN (`+newsActiveTopics+`)), false))`, seed, "ft.topic_id")
+		}
+		return conditions, args
+	}
+
+	matches := make([]string, 0, len(seeds))
+	for _, seed := range seeds {
+		matches = append(matches, fmt.Sprintf("ft.topic_id IN ("+topicSubtrees+" SELECT id FROM subtree)", seed))
+	}
+
+	return fmt.Sprintf(" AND EXISTS ("+newsActiveTopics+" AND (%s))", "1", strings.Join(matches, " OR ")), args
 }
 
 func (r newsArticlesRepository) UpdateArticleFields(
@@ -159,29 +586,300 @@ func (r newsArticlesRepository) UpdateArticleFields(
 		case "slug":
 			setClauses = append(setClauses, fmt.Sprintf("slug = $%d", i+1))
 			args = append(args, news.Slug)
-		case "status":
-			setClauses = append(setClauses, fmt.Sprintf("status = $%d", i+1))
-			args = append(args, news.Status)
 		}
 	}
 
-	setClauses = append(setClauses, fmt.Sprintf("published_at = $%d", len(updateFields)+1))
+	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", len(updateFields)+1), "version = version + 1")
 	args = append(args, time.Now())
 
-	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", len(updateFields)+2))
-	args = append(args, time.Now())
+	// only the version the caller read may be overwritten
+	query += strings.Join(setClauses, ", ") + fmt.Sprintf(" WHERE id = $%d AND version = $%d", len(updateFields)+2, len(updateFields)+3)
+	args = append(args, news.ID, news.Version)
 
-	query += strings.Join(setClauses, ", ") + " WHERE id = $" + fmt.Sprintf("%d", len(updateFields)+3)
-	args = append(args, news.ID)
+	var err error
+	if slices.Contains(updateFields, "slug") {
+		// keep the replaced slug so links using it can be redirected
+		err = newsArticleSlugs.update(ctx, r.db, news.ID, news.Slug, query, args)
+	} else {
+		err = execVersioned(ctx, conn(ctx, r.db), query, args)
+	}
+	if err != nil {
+		return err
+	}
 
-	_, err := r.db.ExecContext(ctx, query, args...)
+	news.Version++
+	return nil
+}
 
-	return err
+func (r newsArticlesRepository) GetByRetiredSlug(ctx context.Context, slug string) (entity.RetiredSlug, error) {
+	return newsArticleSlugs.getRetired(ctx, conn(ctx, r.db), slug)
+}
+
+func (r newsArticlesRepository) GetTakenSlugs(ctx context.Context, base string) ([]string, error) {
+	return newsArticleSlugs.taken(ctx, conn(ctx, r.db), base)
+}
+
+// GetTakenSlugsOf is GetTakenSlugs for several bases at once
+func (r newsArticlesRepository) GetTakenSlugsOf(ctx context.Context, bases []string) ([]string, error) {
+	return newsArticleSlugs.takenAny(ctx, conn(ctx, r.db), bases)
 }
 
-func (r newsArticlesRepository) DeleteBySlug(ctx context.Context, slug string) error {
-	query := `UPDATE news_articles SET deleted_at = NOW() WHERE slug = $1 AND deleted_at IS NULL`
+// UpdateStatus writes the workflow columns of news only while the article is still
+// in status from, it reports false when a concurrent change moved it first
+func (r newsArticlesRepository) UpdateStatus(
+	ctx context.Context,
+	news *entity.NewsArticleWithTopic,
+	from entity.ArticleStatus,
+) (bool, error) {
+	query := `
+			UPDATE news_articles
+			SET status = $1, published_at = $2, publish_at = $3, reviewer_id = $4, review_comment = $5,
+				updated_at = NOW(), version = version + 1
+			WHERE id = $6 AND status = $7 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		news.Status, news.PublishedAt, news.PublishAt, news.ReviewerID, news.ReviewComment, news.ID, from)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
 
-	_, err := r.db.ExecContext(ctx, query, slug)
+// UpdateStatuses moves every article of articles to status to in one
+// statement and writes the columns UpdateStatus does. An article only moves
+// while it still has the status and version it was read with, the ids that
+// moved are returned. reviewerID and comment replace the reviewer and review
+// comment of every one when set, otherwise both are kept.
+func (r newsArticlesRepository) UpdateStatuses(
+	ctx context.Context,
+	articles []entity.NewsArticleWithTopic,
+	to entity.ArticleStatus,
+	reviewerID *int,
+	comment *string,
+) ([]int, error) {
+	query := `
+			UPDATE news_articles a
+			SET status = $4::article_status,
+				published_at = CASE WHEN $4::article_status = 'published' THEN NOW() ELSE a.published_at END,
+				publish_at = NULL, reviewer_id = COALESCE($5::int, a.reviewer_id),
+				review_comment = COALESCE($6::text, a.review_comment), updated_at = NOW(), version = a.version + 1
+			FROM unnest($1::int[], $2::article_status[], $3::int[]) AS s(id, status, version)
+			WHERE a.id = s.id AND a.status = s.status AND a.version = s.version AND a.deleted_at IS NULL
+			RETURNING a.id`
+
+	ids := make([]int, 0, len(articles))
+	statuses := make(pq.StringArray, 0, len(articles))
+	versions := make([]int, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+		statuses = append(statuses, string(article.Status))
+		versions = append(versions, article.Version)
+	}
+
+	moved := []int{}
+	err := conn(ctx, r.db).SelectContext(ctx, &moved, query,
+		int32Array(ids), statuses, int32Array(versions), to, reviewerID, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// TouchArticles moves the version of every article of ids on, for changes that
+// only touch their links
+func (r newsArticlesRepository) TouchArticles(ctx context.Context, ids []int) error {
+	query := `UPDATE news_articles SET updated_at = NOW(), version = version + 1 WHERE id = ANY($1::int[]) AND deleted_at IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, int32Array(ids))
 	return err
 }
+
+// DeleteArticles soft-deletes every article of articles in one statement. An
+// article is only deleted while it still has the version it was read with, the
+// ids that were deleted are returned.
+func (r newsArticlesRepository) DeleteArticles(ctx context.Context, articles []entity.NewsArticleWithTopic) ([]int, error) {
+	query := `
+			UPDATE news_articles a
+			SET deleted_at = NOW(), updated_at = NOW(), version = a.version + 1
+			FROM unnest($1::int[], $2::int[]) AS s(id, version)
+			WHERE a.id = s.id AND a.version = s.version AND a.deleted_at IS NULL
+			RETURNING a.id`
+
+	ids := make([]int, 0, len(articles))
+	versions := make([]int, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+		versions = append(versions, article.Version)
+	}
+
+	deleted := []int{}
+	err := conn(ctx, r.db).SelectContext(ctx, &deleted, query, int32Array(ids), int32Array(versions))
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+// DeleteBySlug soft-deletes the article of slug when it is still at version.
+// It returns ErrStaleVersion when the article changed since it was read
+func (r newsArticlesRepository) DeleteBySlug(ctx context.Context, slug string, version int) error {
+	query := `UPDATE news_articles SET deleted_at = NOW(), updated_at = NOW(), version = version + 1
+		WHERE slug = $1 AND version = $2 AND deleted_at IS NULL`
+
+	return execVersioned(ctx, conn(ctx, r.db), query, []interface{}{slug, version})
+}
+
+// GetTrashed lists soft-deleted articles, most recently deleted first. The
+// topic_ids are the links deleted together with the article, which Restore
+// brings back.
+func (r newsArticlesRepository) GetTrashed(ctx context.Context, pagination dto.Pagination) ([]entity.NewsArticleWithTopicID, error) {
+	query := `
+			SELECT
+				na.id,
+				na.title,
+				na.summary,
+				na.author_id,
+				na.slug,
+				na.status,
+				na.published_at,
+				na.publish_at,
+				na.created_at,
+				na.updated_at,
+				na.deleted_at,
+				COALESCE(ARRAY_AGG(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids
+			FROM news_articles na
+			LEFT JOIN news_topics nt ON nt.news_article_id = na.id AND nt.deleted_at >= na.deleted_at
+			WHERE na.deleted_at IS NOT NULL
+			GROUP BY na.id
+			ORDER BY na.deleted_at DESC, na.id DESC
+			LIMIT $1 OFFSET $2`
+
+	articles := []entity.NewsArticleWithTopicID{}
+	err := conn(ctx, r.db).SelectContext(ctx, &articles, query, pagination.Limit, pagination.Offset())
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (r newsArticlesRepository) CountTrashed(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM news_articles WHERE deleted_at IS NOT NULL`
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// Restore brings back the soft-deleted article with slug and the topic links
+// DeleteByArticleID removed after it, links dropped by earlier edits stay
+// deleted. It reports false when no trashed article has the slug.
+func (r newsArticlesRepository) Restore(ctx context.Context, slug string) (bool, error) {
+	restored := false
+	err := runInTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var trashed entity.NewsArticle
+		err := tx.GetContext(ctx, &trashed,
+			`SELECT id, deleted_at FROM news_articles WHERE slug = $1 AND deleted_at IS NOT NULL FOR UPDATE`, slug)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE news_articles SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1`, trashed.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE news_topics SET deleted_at = NULL WHERE news_article_id = $1 AND deleted_at >= $2`,
+			trashed.ID, trashed.DeletedAt.Time)
+		if err != nil {
+			return err
+		}
+
+		restored = true
+		return nil
+	})
+
+	return restored, err
+}
+
+// Purge removes a trashed article for good, its topic links, revisions and slug
+// history go with it. It reports false when no trashed article has the slug.
+func (r newsArticlesRepository) Purge(ctx context.Context, slug string) (bool, error) {
+	query := `DELETE FROM news_articles WHERE slug = $1 AND deleted_at IS NOT NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, slug)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// PurgeTrashed removes up to limit articles trashed before the given time and
+// returns their ids, rows locked by another replica are left for its run
+func (r newsArticlesRepository) PurgeTrashed(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	query := `
+			DELETE FROM news_articles
+			WHERE id IN (
+				SELECT id FROM news_articles
+				WHERE deleted_at < $1
+				ORDER BY deleted_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id`
+
+	ids := []int{}
+	err := conn(ctx, r.db).SelectContext(ctx, &ids, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// PublishDue publishes up to limit scheduled articles whose publish_at has passed
+// and returns their ids. Rows locked by another replica are skipped rather than
+// waited on, so concurrent publishers never pick the same article.
+func (r newsArticlesRepository) PublishDue(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	query := `
+			UPDATE news_articles na
+			SET status = 'published', published_at = na.publish_at, publish_at = NULL, updated_at = NOW(), version = na.version + 1
+			WHERE na.id IN (
+				SELECT id FROM news_articles
+				WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+				ORDER BY publish_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING na.id`
+
+	ids := []int{}
+	err := conn(ctx, r.db).SelectContext(ctx, &ids, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
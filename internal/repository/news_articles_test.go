@@ -24,7 +24,7 @@ func Test_CreateNewsArticle(t *testing.T) {
 	repos := repository.NewNewsArticlesRepository(sqlxDB)
 	ctx := context.Background()
 
-	query := `INSERT INTO news_articles \(title, content, summary, author_id, slug, status, published_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?\) RETURNING id`
+	query := `INSERT INTO news_articles \(title, content, summary, author_id, slug, status, published_at, publish_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?\) RETURNING id`
 	tests := []struct {
 		testname  string
 		entity    entity.NewsArticle
@@ -47,7 +47,7 @@ func Test_CreateNewsArticle(t *testing.T) {
 			},
 			initMock: func(na entity.NewsArticle) {
 				mockSql.ExpectPrepare(query).ExpectQuery().
-					WithArgs(na.Title, na.Content, na.Summary, na.AuthorID, na.Slug, na.Status, na.PublishedAt).
+					WithArgs(na.Title, na.Content, na.Summary, na.AuthorID, na.Slug, na.Status, na.PublishedAt, na.PublishAt).
 					WillReturnError(errors.New("failed insert"))
 			},
 			assertion: func(err error) {
@@ -71,7 +71,7 @@ func Test_CreateNewsArticle(t *testing.T) {
 			initMock: func(na entity.NewsArticle) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(10)
 				mockSql.ExpectPrepare(query).ExpectQuery().
-					WithArgs(na.Title, na.Content, na.Summary, na.AuthorID, na.Slug, na.Status, na.PublishedAt).
+					WithArgs(na.Title, na.Content, na.Summary, na.AuthorID, na.Slug, na.Status, na.PublishedAt, na.PublishAt).
 					WillReturnRows(rows)
 			},
 			assertion: func(err error) {
@@ -98,10 +98,11 @@ func Test_GetArticleBySlug(t *testing.T) {
 
 	query := `
 			SELECT a.id, a.title, a.content, a.summary, a.author_id, a.slug, 
-			a.status, a.published_at, a.created_at, a.updated_at,
-			COALESCE\(array_agg\(nt.topic_id ORDER BY nt.topic_id\), '\{\}'\) AS topic_ids 
+			a.status, a.published_at, a.publish_at, a.created_at, a.updated_at,
+			a.reviewer_id, a.review_comment, a.version,
+			COALESCE\(array_agg\(nt.topic_id ORDER BY nt.topic_id\) FILTER \(WHERE nt.topic_id IS NOT NULL\), '\{\}'\) AS topic_ids
 			FROM news_articles a
-			INNER JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL AND EXISTS \(SELECT 1 FROM topics lt WHERE lt.id = nt.topic_id AND lt.deleted_at IS NULL\)
 			WHERE slug = \$1 AND a.deleted_at is NULL
 			GROUP BY a.id`
 
@@ -117,8 +118,8 @@ func Test_GetArticleBySlug(t *testing.T) {
 			initMock: func(slug string) {
 				rows := sqlmock.NewRows([]string{
 					"id", "title", "content", "summary", "author_id", "slug",
-					"status", "published_at", "created_at", "updated_at", "topic_ids",
-				}).AddRow(1, "Title", "Content", "Summary", 10, slug, "published", time.Now(), time.Now(), time.Now(), pq.Int64Array{1, 2})
+					"status", "published_at", "created_at", "updated_at", "version", "topic_ids",
+				}).AddRow(1, "Title", "Content", "Summary", 10, slug, "published", time.Now(), time.Now(), time.Now(), 2, pq.Int64Array{1, 2})
 
 				mockSql.ExpectQuery(query).WithArgs(slug).WillReturnRows(rows)
 			},
@@ -161,12 +162,15 @@ func Test_GetActiveArticleBySlug(t *testing.T) {
 				a.content,
 				a.slug,
 				a.published_at,
-				u.name,
+				a.version,
+				a.status,
+				a.author_id,
+				COALESCE\(u.name, ''\) AS name,
 				COALESCE\(array_agg\(t.name ORDER BY t.name\) FILTER \(WHERE t.name IS NOT NULL\), '\{\}'\) AS topics
 			FROM news_articles a
-			INNER JOIN users u on u.id = a.author_id 
-			INNER JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
-			INNER JOIN topics t ON t.id = nt.topic_id
+			LEFT JOIN users u on u.id = a.author_id AND u.deleted_at IS NULL
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN topics t ON t.id = nt.topic_id AND t.deleted_at IS NULL
 			WHERE a.slug = \$1 AND a.deleted_at IS NULL
 			GROUP BY a.id, u.name`
 
@@ -181,8 +185,8 @@ func Test_GetActiveArticleBySlug(t *testing.T) {
 			slug:     "active-slug",
 			initMock: func(slug string) {
 				rows := sqlmock.NewRows([]string{
-					"id", "title", "content", "slug", "published_at", "name", "topics",
-				}).AddRow(1, "Active Title", "Content", slug, time.Now(), "Author Name", pq.StringArray{"Tech", "Go"})
+					"id", "title", "content", "slug", "published_at", "version", "status", "author_id", "name", "topics",
+				}).AddRow(1, "Active Title", "Content", slug, time.Now(), 3, "published", 7, "Author Name", pq.StringArray{"Tech", "Go"})
 
 				mockSql.ExpectQuery(query).WithArgs(slug).WillReturnRows(rows)
 			},
@@ -212,27 +216,122 @@ func Test_GetActiveArticleBySlug(t *testing.T) {
 	}
 }
 
-func Test_GetAll(t *testing.T) {
+func Test_GetPublishedByAuthor(t *testing.T) {
 	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
 	defer mockDb.Close()
 
 	repos := repository.NewNewsArticlesRepository(sqlxDB)
 	ctx := context.Background()
 
+	query := regexp.QuoteMeta(`SELECT
+				a.id,
+				a.title,
+				a.summary,
+				a.slug,
+				a.published_at,
+				COALESCE(array_agg(t.name ORDER BY t.name) FILTER (WHERE t.name IS NOT NULL), '{}') AS topics
+			FROM news_articles a
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN topics t ON t.id = nt.topic_id AND t.deleted_at IS NULL
+			WHERE a.author_id = $1 AND a.status = 'published' AND a.deleted_at IS NULL
+			GROUP BY a.id
+			ORDER BY a.published_at DESC, a.id DESC
+			LIMIT $2 OFFSET $3`)
+	pagination := dto.NewPagination(3, 10)
+
 	tests := []struct {
-		name      string
-		filter    dto.NewsFilter
+		testname  string
 		initMock  func()
-		assertion func([]entity.NewsArticleWithTopicID, error)
+		assertion func(articles []entity.PublishedNewsWithTopic, err error)
 	}{
 		{
-			name: "returns articles successfully",
-			filter: dto.NewsFilter{
-				Status:  "published",
-				TopicID: "1",
+			testname: "get author articles successfully",
+			initMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "summary", "slug", "published_at", "topics"}).
+					AddRow(1, "Title", "Summary", "title-slug", time.Now(), pq.StringArray{"Go", "Tech"}).
+					AddRow(2, "Other", nil, "other-slug", time.Now(), pq.StringArray{})
+				mockSql.ExpectQuery(query).WithArgs(7, 10, 20).WillReturnRows(rows)
+			},
+			assertion: func(articles []entity.PublishedNewsWithTopic, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, articles, 2)
+				assert.Equal(t, pq.StringArray{"Go", "Tech"}, articles[0].Topics)
 			},
+		},
+		{
+			testname: "get author articles returns error",
 			initMock: func() {
-				query := regexp.QuoteMeta(`
+				mockSql.ExpectQuery(query).WithArgs(7, 10, 20).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(articles []entity.PublishedNewsWithTopic, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, articles)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			articles, err := repos.GetPublishedByAuthor(ctx, 7, pagination)
+			tt.assertion(articles, err)
+		})
+	}
+}
+
+func Test_CountPublishedByAuthor(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := regexp.QuoteMeta(`SELECT COUNT(*) FROM news_articles
+			WHERE author_id = $1 AND status = 'published' AND deleted_at IS NULL`)
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(total int, err error)
+	}{
+		{
+			testname: "count author articles successfully",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+			},
+			assertion: func(total int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 12, total)
+			},
+		},
+		{
+			testname: "count author articles returns error",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs(7).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(total int, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			total, err := repos.CountPublishedByAuthor(ctx, 7)
+			tt.assertion(total, err)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	selectQuery := `
 					SELECT
 						na.id,
 						na.title,
@@ -241,26 +340,52 @@ func Test_GetAll(t *testing.T) {
 						na.slug,
 						na.status,
 						na.published_at,
+						na.publish_at,
 						na.created_at,
-						ARRAY_AGG(nt.topic_id) AS topic_ids
+						na.updated_at,
+						na.reviewer_id,
+						na.review_comment,
+						COALESCE(ARRAY_AGG(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids
 					FROM news_articles na
-					LEFT JOIN news_topics nt ON na.id = nt.news_article_id
+					LEFT JOIN news_topics nt ON na.id = nt.news_article_id AND nt.deleted_at IS NULL AND EXISTS (SELECT 1 FROM topics lt WHERE lt.id = nt.topic_id AND lt.deleted_at IS NULL)
 					WHERE
-						na.deleted_at IS NULL
-						AND nt.deleted_at IS NULL
+						na.deleted_at IS NULL`
+	groupBy := ` GROUP BY na.id, na.title, na.summary, na.author_id, na.slug, na.status, na.published_at, na.publish_at, na.created_at, na.updated_at, na.reviewer_id, na.review_comment`
+	orderBy := ` ORDER BY COALESCE(na.published_at, na.created_at) DESC, na.id DESC`
+	weekStart := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name      string
+		filter    dto.NewsFilter
+		initMock  func()
+		assertion func([]entity.NewsArticleWithTopicID, error)
+	}{
+		{
+			name: "returns articles successfully",
+			filter: dto.NewsFilter{
+				Status:     "published",
+				TopicIDs:   []int32{1},
+				Pagination: dto.NewPagination(2, 10),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
 						AND na.status = $1
-						AND nt.topic_id = $2
-					GROUP BY na.id, na.title, na.summary, na.author_id, na.slug, na.status, na.published_at, na.created_at
+						AND EXISTS (SELECT 1 FROM news_topics ft
+							INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+							WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL
+							AND (ft.topic_id = ANY($2::int[])))` + groupBy + orderBy + `
+					LIMIT $3 OFFSET $4
 				`)
 
 				rows := sqlmock.NewRows([]string{
 					"id", "title", "summary", "author_id", "slug", "status",
-					"published_at", "created_at", "topic_ids",
+					"published_at", "created_at", "updated_at", "topic_ids",
 				}).
-					AddRow(1, "Test Title", "Summary", 100, "test-slug", "published", time.Now(), time.Now(), pq.Int32Array{1, 2})
+					AddRow(1, "Test Title", "Summary", 100, "test-slug", "published", time.Now(), time.Now(), time.Now(), pq.Int32Array{1, 2})
 
 				mockSql.ExpectQuery(query).
-					WithArgs("published", "1").
+					WithArgs("published", pq.Int32Array{1}, 10, 10).
 					WillReturnRows(rows)
 			},
 			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
@@ -271,8 +396,284 @@ func Test_GetAll(t *testing.T) {
 			},
 		},
 		{
-			name:   "returns error on query failure",
-			filter: dto.NewsFilter{},
+			name: "matches any of the topic ids or slugs",
+			filter: dto.NewsFilter{
+				TopicIDs:   []int32{1, 2},
+				TopicSlugs: []string{"science"},
+				TopicMatch: dto.TopicMatchAny,
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND EXISTS (SELECT 1 FROM news_topics ft
+							INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+							WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL
+							AND (ft.topic_id = ANY($1::int[]) OR t.slug = ANY(ARRAY(SELECT COALESCE(
+								(SELECT ct.slug FROM topic_slugs ts INNER JOIN topics ct ON ct.id = ts.topic_id WHERE ts.slug = rs.slug),
+								rs.slug) FROM unnest($2::text[]) AS rs(slug)))))` + groupBy + orderBy + `
+					LIMIT $3 OFFSET $4
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(pq.Int32Array{1, 2}, pq.StringArray{"science"}, 20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "topic_ids"}).AddRow(4, pq.Int32Array{1, 3, 5}))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, pq.Int32Array{1, 3, 5}, result[0].TopicIDs)
+			},
+		},
+		{
+			name: "matches all of the topic ids and slugs",
+			filter: dto.NewsFilter{
+				TopicIDs:   []int32{1, 2},
+				TopicSlugs: []string{"science"},
+				TopicMatch: dto.TopicMatchAll,
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND ARRAY(SELECT ft.topic_id FROM news_topics ft
+							INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+							WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL) @> $1::int[]
+						AND ARRAY(SELECT t.slug FROM news_topics ft
+							INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+							WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL) @> ARRAY(SELECT COALESCE(
+								(SELECT ct.slug FROM topic_slugs ts INNER JOIN topics ct ON ct.id = ts.topic_id WHERE ts.slug = rs.slug),
+								rs.slug) FROM unnest($2::text[]) AS rs(slug))` + groupBy + orderBy + `
+					LIMIT $3 OFFSET $4
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(pq.Int32Array{1, 2}, pq.StringArray{"science"}, 20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			},
+		},
+		{
+			name: "matches descendants of any topic id",
+			filter: dto.NewsFilter{
+				TopicIDs:   []int32{3},
+				Subtopics:  true,
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND EXISTS (SELECT 1 FROM news_topics ft
+							INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+							WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL
+							AND (ft.topic_id IN (WITH RECURSIVE subtree(root, id) AS (
+								SELECT r.id::text, r.id FROM unnest($1::int[]) AS r(id)
+								UNION
+								SELECT s.root, c.id FROM topics c
+								INNER JOIN subtree s ON c.parent_id = s.id
+								WHERE c.deleted_at IS NULL
+							) SELECT id FROM subtree)))` + groupBy + orderBy + `
+					LIMIT $2 OFFSET $3
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(pq.Int32Array{3}, 20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "topic_ids"}).AddRow(6, pq.Int32Array{7}))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, pq.Int32Array{7}, result[0].TopicIDs)
+			},
+		},
+		{
+			name: "matches a descendant of every topic slug",
+			filter: dto.NewsFilter{
+				TopicSlugs: []string{"sports", "europe"},
+				TopicMatch: dto.TopicMatchAll,
+				Subtopics:  true,
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND NOT EXISTS (WITH RECURSIVE subtree(root, id) AS (
+							SELECT req.slug, t.id FROM unnest(ARRAY(SELECT COALESCE(
+								(SELECT ct.slug FROM topic_slugs ts INNER JOIN topics ct ON ct.id = ts.topic_id WHERE ts.slug = rs.slug),
+								rs.slug) FROM unnest($1::text[]) AS rs(slug))) AS req(slug)
+							LEFT JOIN topics t ON t.slug = req.slug AND t.deleted_at IS NULL
+							UNION
+							SELECT s.root, c.id FROM topics c
+							INNER JOIN subtree s ON c.parent_id = s.id
+							WHERE c.deleted_at IS NULL
+						)
+						SELECT 1 FROM subtree s GROUP BY s.root
+						HAVING NOT COALESCE(bool_or(s.id IN (SELECT ft.topic_id FROM news_topics ft
+							INNER JOIN topics t ON t.id = ft.topic_id AND t.deleted_at IS NULL
+							WHERE ft.news_article_id = na.id AND ft.deleted_at IS NULL)), false))` + groupBy + orderBy + `
+					LIMIT $2 OFFSET $3
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(pq.StringArray{"sports", "europe"}, 20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			},
+		},
+		{
+			name: "combines author and date range filters with status",
+			filter: dto.NewsFilter{
+				Status:     "published",
+				AuthorID:   7,
+				Published:  dto.TimeRange{From: &weekStart, To: &weekEnd},
+				Created:    dto.TimeRange{To: &weekEnd},
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND na.status = $1
+						AND na.author_id = $2
+						AND na.published_at >= $3
+						AND na.published_at < $4
+						AND na.created_at < $5` + groupBy + orderBy + `
+					LIMIT $6 OFFSET $7
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs("published", 7, weekStart, weekEnd, weekEnd, 20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id"}).AddRow(4, 7))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+				assert.Equal(t, 7, result[0].AuthorID)
+			},
+		},
+		{
+			name: "hides unpublished articles except the owner's",
+			filter: dto.NewsFilter{
+				Status:        "draft",
+				PublishedOnly: true,
+				OwnerID:       7,
+				Pagination:    dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND na.status = $1
+						AND (na.status = 'published' OR na.author_id = $2)` + groupBy + orderBy + `
+					LIMIT $3 OFFSET $4
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs("draft", 7, 20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id"}).AddRow(4, 7))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			},
+		},
+		{
+			name: "anonymous callers only get published articles",
+			filter: dto.NewsFilter{
+				PublishedOnly: true,
+				Pagination:    dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND na.status = 'published'` + groupBy + orderBy + `
+					LIMIT $1 OFFSET $2
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result)
+			},
+		},
+		{
+			name: "returns articles after cursor",
+			filter: dto.NewsFilter{
+				Status:     "published",
+				Pagination: dto.NewCursorPagination(dto.Cursor{Value: "2025-06-05T14:25:24Z", ID: 9}, 5),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND na.status = $1
+						AND (COALESCE(na.published_at, na.created_at), na.id) < ($2::timestamptz, $3)` + groupBy + orderBy + `
+					LIMIT $4 OFFSET $5
+				`)
+
+				rows := sqlmock.NewRows([]string{
+					"id", "title", "summary", "author_id", "slug", "status",
+					"published_at", "created_at", "updated_at", "topic_ids",
+				}).
+					AddRow(8, "Older Title", "Summary", 100, "older-slug", "published", time.Now(), time.Now(), time.Now(), pq.Int32Array{1})
+
+				mockSql.ExpectQuery(query).
+					WithArgs("published", "2025-06-05T14:25:24Z", 9, 5, 0).
+					WillReturnRows(rows)
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+				assert.Equal(t, 8, result[0].ID)
+			},
+		},
+		{
+			name: "returns articles sorted by title ascending after cursor",
+			filter: dto.NewsFilter{
+				Sort:       dto.NewsSort{Field: dto.SortByTitle},
+				Pagination: dto.NewCursorPagination(dto.Cursor{Value: "Middle", ID: 4}, 5),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + `
+						AND (na.title, na.id) > ($1::text, $2)` + groupBy + `
+					ORDER BY na.title ASC, na.id ASC
+					LIMIT $3 OFFSET $4
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs("Middle", 4, 5, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(5, "Next"))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Next", result[0].Title)
+			},
+		},
+		{
+			name: "returns articles sorted by updated_at descending",
+			filter: dto.NewsFilter{
+				Sort:       dto.NewsSort{Field: dto.SortByUpdatedAt, Desc: true},
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + groupBy + `
+					ORDER BY na.updated_at DESC, na.id DESC
+					LIMIT $1 OFFSET $2
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			},
+		},
+		{
+			name: "returns search matches ranked by relevance",
+			filter: dto.NewsFilter{
+				Query:      "election results",
+				Status:     "published",
+				Sort:       dto.RelevanceNewsSort,
+				Pagination: dto.NewPagination(1, 20),
+			},
 			initMock: func() {
 				query := regexp.QuoteMeta(`
 					SELECT
@@ -283,17 +684,66 @@ func Test_GetAll(t *testing.T) {
 						na.slug,
 						na.status,
 						na.published_at,
+						na.publish_at,
 						na.created_at,
-						ARRAY_AGG(nt.topic_id) AS topic_ids
+						na.updated_at,
+						na.reviewer_id,
+						na.review_comment,
+						COALESCE(ARRAY_AGG(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids, ts_rank(na.search_vector, websearch_to_tsquery('english', $1)) AS rank, ts_headline('english', na.content, websearch_to_tsquery('english', $1), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10') AS snippet
 					FROM news_articles na
-					LEFT JOIN news_topics nt ON na.id = nt.news_article_id
+					LEFT JOIN news_topics nt ON na.id = nt.news_article_id AND nt.deleted_at IS NULL AND EXISTS (SELECT 1 FROM topics lt WHERE lt.id = nt.topic_id AND lt.deleted_at IS NULL)
 					WHERE
 						na.deleted_at IS NULL
-						AND nt.deleted_at IS NULL
-					GROUP BY na.id, na.title, na.summary, na.author_id, na.slug, na.status, na.published_at, na.created_at
+						AND na.search_vector @@ websearch_to_tsquery('english', $1)
+						AND na.status = $2` + groupBy + `
+					ORDER BY ts_rank(na.search_vector, websearch_to_tsquery('english', $1)) DESC, na.id DESC
+					LIMIT $3 OFFSET $4
+				`)
+
+				rows := sqlmock.NewRows([]string{"id", "title", "rank", "snippet"}).
+					AddRow(7, "Election Night", 0.6, "the <mark>election</mark> <mark>results</mark> are in")
+
+				mockSql.ExpectQuery(query).
+					WithArgs("election results", "published", 20, 0).
+					WillReturnRows(rows)
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+				assert.Equal(t, float32(0.6), *result[0].Rank)
+				assert.Contains(t, *result[0].Snippet, "<mark>election</mark>")
+			},
+		},
+		{
+			name: "relevance sort without search falls back to default order",
+			filter: dto.NewsFilter{
+				Sort:       dto.RelevanceNewsSort,
+				Pagination: dto.NewPagination(1, 20),
+			},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + groupBy + orderBy + `
+					LIMIT $1 OFFSET $2
+				`)
+
+				mockSql.ExpectQuery(query).
+					WithArgs(20, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			},
+		},
+		{
+			name:   "returns error on query failure",
+			filter: dto.NewsFilter{Pagination: dto.NewPagination(1, 20)},
+			initMock: func() {
+				query := regexp.QuoteMeta(selectQuery + groupBy + orderBy + `
+					LIMIT $1 OFFSET $2
 				`)
 
 				mockSql.ExpectQuery(query).
+					WithArgs(20, 0).
 					WillReturnError(errors.New("db error"))
 			},
 			assertion: func(result []entity.NewsArticleWithTopicID, err error) {
@@ -312,20 +762,73 @@ func Test_GetAll(t *testing.T) {
 	}
 }
 
-func Test_UpdateArticleFields(t *testing.T) {
+func Test_CountNewsArticles(t *testing.T) {
 	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
 	defer mockDb.Close()
 
 	repos := repository.NewNewsArticlesRepository(sqlxDB)
 	ctx := context.Background()
 
+	query := regexp.QuoteMeta(`
+			SELECT COUNT(DISTINCT na.id)
+			FROM news_articles na
+			LEFT JOIN news_topics nt ON na.id = nt.news_article_id AND nt.deleted_at IS NULL AND EXISTS (SELECT 1 FROM topics lt WHERE lt.id = nt.topic_id AND lt.deleted_at IS NULL)
+			WHERE
+				na.deleted_at IS NULL
+				AND na.status = $1
+	`)
+	filter := dto.NewsFilter{Status: "draft", Pagination: dto.NewPagination(3, 10)}
+
 	tests := []struct {
-		testname     string
-		news         entity.NewsArticleWithTopic
-		updateFields []string
-		initMock     func(news entity.NewsArticleWithTopic, updateFields []string)
-		assertion    func(err error)
-	}{
+		name      string
+		initMock  func()
+		assertion func(total int, err error)
+	}{
+		{
+			name: "returns total ignoring pagination",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs("draft").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(31))
+			},
+			assertion: func(total int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 31, total)
+			},
+		},
+		{
+			name: "returns error on query failure",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs("draft").WillReturnError(errors.New("db error"))
+			},
+			assertion: func(total int, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+			total, err := repos.Count(ctx, filter)
+			tt.assertion(total, err)
+		})
+	}
+}
+
+func Test_UpdateArticleFields(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	tests := []struct {
+		testname     string
+		news         entity.NewsArticleWithTopic
+		updateFields []string
+		initMock     func(news entity.NewsArticleWithTopic, updateFields []string)
+		assertion    func(err error)
+	}{
 		{
 			testname: "update title and content successfully",
 			news: entity.NewsArticleWithTopic{
@@ -336,14 +839,14 @@ func Test_UpdateArticleFields(t *testing.T) {
 			updateFields: []string{"title", "content"},
 			initMock: func(news entity.NewsArticleWithTopic, updateFields []string) {
 				// Generate expected query and args
-				query := `UPDATE news_articles SET title = \$1, content = \$2, published_at = \$3, updated_at = \$4 WHERE id = \$5`
+				query := `UPDATE news_articles SET title = \$1, content = \$2, updated_at = \$3, version = version \+ 1 WHERE id = \$4 AND version = \$5`
 				mockSql.ExpectExec(query).
 					WithArgs(
 						news.Title,
 						news.Content,
 						sqlmock.AnyArg(),
-						sqlmock.AnyArg(),
 						news.ID,
+						news.Version,
 					).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -359,20 +862,70 @@ func Test_UpdateArticleFields(t *testing.T) {
 			},
 			updateFields: []string{"slug"},
 			initMock: func(news entity.NewsArticleWithTopic, updateFields []string) {
-				query := `UPDATE news_articles SET slug = \$1, published_at = \$2, updated_at = \$3 WHERE id = \$4`
+				query := `UPDATE news_articles SET slug = \$1, updated_at = \$2, version = version \+ 1 WHERE id = \$3 AND version = \$4`
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery(`SELECT slug FROM news_articles WHERE id = \$1 FOR UPDATE`).
+					WithArgs(news.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("old-slug"))
 				mockSql.ExpectExec(query).
 					WithArgs(
 						news.Slug,
 						sqlmock.AnyArg(),
-						sqlmock.AnyArg(),
 						news.ID,
+						news.Version,
 					).
 					WillReturnError(errors.New("db error"))
+				mockSql.ExpectRollback()
 			},
 			assertion: func(err error) {
 				assert.Error(t, err)
 			},
 		},
+		{
+			testname: "update slug keeps the old one in history",
+			news: entity.NewsArticleWithTopic{
+				ID:   3,
+				Slug: "new-slug",
+			},
+			updateFields: []string{"slug"},
+			initMock: func(news entity.NewsArticleWithTopic, updateFields []string) {
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery(`SELECT slug FROM news_articles WHERE id = \$1 FOR UPDATE`).
+					WithArgs(news.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("old-slug"))
+				mockSql.ExpectExec(`UPDATE news_articles SET slug = \$1, updated_at = \$2, version = version \+ 1 WHERE id = \$3 AND version = \$4`).
+					WithArgs(news.Slug, sqlmock.AnyArg(), news.ID, news.Version).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// taking back an own retired slug removes it from the history
+				mockSql.ExpectExec(`DELETE FROM news_article_slugs WHERE news_article_id = \$1 AND slug = \$2`).
+					WithArgs(news.ID, news.Slug).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mockSql.ExpectExec(`INSERT INTO news_article_slugs \(news_article_id, slug\) VALUES \(\$1, \$2\) ON CONFLICT \(slug\) DO NOTHING`).
+					WithArgs(news.ID, "old-slug").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockSql.ExpectCommit()
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "article changed since it was read",
+			news: entity.NewsArticleWithTopic{
+				ID:      4,
+				Title:   "Stale Title",
+				Version: 2,
+			},
+			updateFields: []string{"title"},
+			initMock: func(news entity.NewsArticleWithTopic, updateFields []string) {
+				mockSql.ExpectExec(`UPDATE news_articles SET title = \$1, updated_at = \$2, version = version \+ 1 WHERE id = \$3 AND version = \$4`).
+					WithArgs(news.Title, sqlmock.AnyArg(), news.ID, news.Version).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			assertion: func(err error) {
+				assert.ErrorIs(t, err, repository.ErrStaleVersion)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -384,6 +937,75 @@ func Test_UpdateArticleFields(t *testing.T) {
 	}
 }
 
+func Test_UpdateStatus(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := regexp.QuoteMeta(`
+			UPDATE news_articles
+			SET status = $1, published_at = $2, publish_at = $3, reviewer_id = $4, review_comment = $5,
+			updated_at = NOW(), version = version + 1
+			WHERE id = $6 AND status = $7 AND deleted_at IS NULL`)
+	news := entity.NewsArticleWithTopic{
+		ID:            3,
+		Status:        entity.StatusDraft,
+		ReviewerID:    utils.IntPtr(5),
+		ReviewComment: utils.StringPtr("needs sources"),
+	}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(moved bool, err error)
+	}{
+		{
+			testname: "moves article still in expected status",
+			initMock: func() {
+				mockSql.ExpectExec(query).
+					WithArgs(news.Status, news.PublishedAt, news.PublishAt, news.ReviewerID, news.ReviewComment, news.ID, entity.StatusInReview).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			assertion: func(moved bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, moved)
+			},
+		},
+		{
+			testname: "reports false when status changed concurrently",
+			initMock: func() {
+				mockSql.ExpectExec(query).
+					WithArgs(news.Status, news.PublishedAt, news.PublishAt, news.ReviewerID, news.ReviewComment, news.ID, entity.StatusInReview).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			assertion: func(moved bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, moved)
+			},
+		},
+		{
+			testname: "returns error on exec failure",
+			initMock: func() {
+				mockSql.ExpectExec(query).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(moved bool, err error) {
+				assert.Error(t, err)
+				assert.False(t, moved)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			moved, err := repos.UpdateStatus(ctx, &news, entity.StatusInReview)
+			tt.assertion(moved, err)
+		})
+	}
+}
+
 func Test_DeleteBySlug(t *testing.T) {
 	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
 	defer mockDb.Close()
@@ -391,7 +1013,7 @@ func Test_DeleteBySlug(t *testing.T) {
 	repos := repository.NewNewsArticlesRepository(sqlxDB)
 	ctx := context.Background()
 
-	query := `UPDATE news_articles SET deleted_at = NOW\(\) WHERE slug = \$1 AND deleted_at IS NULL`
+	query := `UPDATE news_articles SET deleted_at = NOW\(\), updated_at = NOW\(\), version = version \+ 1 WHERE slug = \$1 AND version = \$2 AND deleted_at IS NULL`
 
 	tests := []struct {
 		testname  string
@@ -404,19 +1026,31 @@ func Test_DeleteBySlug(t *testing.T) {
 			slug:     "to-be-deleted",
 			initMock: func(slug string) {
 				mockSql.ExpectExec(query).
-					WithArgs(slug).
+					WithArgs(slug, 2).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testname: "article changed since it was read",
+			slug:     "stale",
+			initMock: func(slug string) {
+				mockSql.ExpectExec(query).
+					WithArgs(slug, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, repository.ErrStaleVersion, err)
+			},
+		},
 		{
 			testname: "delete article fails",
 			slug:     "nonexistent",
 			initMock: func(slug string) {
 				mockSql.ExpectExec(query).
-					WithArgs(slug).
+					WithArgs(slug, 2).
 					WillReturnError(errors.New("db error"))
 			},
 			assertion: func(err error) {
@@ -428,8 +1062,501 @@ func Test_DeleteBySlug(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock(tt.slug)
-			err := repos.DeleteBySlug(ctx, tt.slug)
+			err := repos.DeleteBySlug(ctx, tt.slug, 2)
 			tt.assertion(err)
 		})
 	}
 }
+
+func Test_PublishDue(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+	now := time.Date(2025, 6, 5, 14, 0, 0, 0, time.UTC)
+
+	query := regexp.QuoteMeta(`
+			UPDATE news_articles na
+			SET status = 'published', published_at = na.publish_at, publish_at = NULL, updated_at = NOW(), version = na.version + 1
+			WHERE na.id IN (
+				SELECT id FROM news_articles
+				WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+				ORDER BY publish_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING na.id`)
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(ids []int, err error)
+	}{
+		{
+			testname: "publishes due articles and returns their ids",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs(now, 50).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(9))
+			},
+			assertion: func(ids []int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []int{4, 9}, ids)
+			},
+		},
+		{
+			testname: "returns error on query failure",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs(now, 50).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(ids []int, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, ids)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			ids, err := repos.PublishDue(ctx, now, 50)
+			tt.assertion(ids, err)
+		})
+	}
+}
+
+func Test_GetByRetiredSlug(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	newsRepo := repository.NewNewsArticlesRepository(sqlxDB)
+	topicRepo := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	columns := []string{"slug", "owner_id", "current_slug", "deleted_at"}
+
+	t.Run("news slug resolves to the current one", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT s.slug, s.news_article_id AS owner_id, o.slug AS current_slug, o.deleted_at
+			FROM news_article_slugs s
+			INNER JOIN news_articles o ON o.id = s.news_article_id
+			WHERE s.slug = $1`)).
+			WithArgs("old-slug").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("old-slug", 4, "new-slug", nil))
+
+		retired, err := newsRepo.GetByRetiredSlug(ctx, "old-slug")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RetiredSlug{Slug: "old-slug", OwnerID: 4, CurrentSlug: "new-slug"}, retired)
+	})
+
+	t.Run("topic slug never used", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT s.slug, s.topic_id AS owner_id, o.slug AS current_slug, o.deleted_at
+			FROM topic_slugs s
+			INNER JOIN topics o ON o.id = s.topic_id
+			WHERE s.slug = $1`)).
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := topicRepo.GetByRetiredSlug(ctx, "unknown")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func Test_GetTakenSlugs(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	newsRepo := repository.NewNewsArticlesRepository(sqlxDB)
+	topicRepo := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	t.Run("news slugs current and retired", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT slug FROM news_articles WHERE slug = $1 OR slug LIKE $2
+			UNION
+			SELECT slug FROM news_article_slugs WHERE slug = $1 OR slug LIKE $2`)).
+			WithArgs("breaking-news", "breaking-news-%").
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("breaking-news").AddRow("breaking-news-2"))
+
+		slugs, err := newsRepo.GetTakenSlugs(ctx, "breaking-news")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"breaking-news", "breaking-news-2"}, slugs)
+	})
+
+	t.Run("topic slugs failed", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT slug FROM topics WHERE slug = $1 OR slug LIKE $2
+			UNION
+			SELECT slug FROM topic_slugs WHERE slug = $1 OR slug LIKE $2`)).
+			WithArgs("science", "science-%").
+			WillReturnError(errors.New("db error"))
+
+		_, err := topicRepo.GetTakenSlugs(ctx, "science")
+		assert.Error(t, err)
+	})
+}
+
+func Test_GetTakenSlugsOf(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	mockSql.ExpectQuery(regexp.QuoteMeta(`
+		SELECT slug FROM news_articles WHERE slug = ANY($1::text[]) OR slug LIKE ANY($2::text[])
+		UNION
+		SELECT slug FROM news_article_slugs WHERE slug = ANY($1::text[]) OR slug LIKE ANY($2::text[])`)).
+		WithArgs(pq.StringArray{"breaking-news", "weather"}, pq.StringArray{"breaking-news-%", "weather-%"}).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("breaking-news").AddRow("weather-2"))
+
+	slugs, err := repos.GetTakenSlugsOf(ctx, []string{"breaking-news", "weather"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"breaking-news", "weather-2"}, slugs)
+}
+
+func Test_CreateBatch(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	articles := []entity.NewsArticle{
+		{Title: "First title", Content: "First content", AuthorID: 1, Slug: "first", Status: entity.StatusDraft},
+		{Title: "Second title", Content: "Second content", AuthorID: 2, Slug: "second", Status: entity.StatusDraft},
+	}
+	query := regexp.QuoteMeta(`VALUES ($1, $2, $3, $4, $5, $6, $7, $8),($9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id, slug`)
+
+	t.Run("returns ids in the order of articles", func(t *testing.T) {
+		mockSql.ExpectQuery(query).
+			WithArgs(
+				"First title", "First content", nil, 1, "first", entity.StatusDraft, sql.NullTime{}, sql.NullTime{},
+				"Second title", "Second content", nil, 2, "second", entity.StatusDraft, sql.NullTime{}, sql.NullTime{},
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(8, "second").AddRow(7, "first"))
+
+		ids, err := repos.CreateBatch(ctx, articles)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{7, 8}, ids)
+	})
+
+	t.Run("returns error on insert failure", func(t *testing.T) {
+		mockSql.ExpectQuery(query).WillReturnError(errors.New("db error"))
+
+		_, err := repos.CreateBatch(ctx, articles)
+		assert.Error(t, err)
+	})
+}
+
+func Test_GetTrashedNews(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+	deletedAt := time.Date(2025, 6, 5, 14, 0, 0, 0, time.UTC)
+
+	mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT
+				na.id,
+				na.title,
+				na.summary,
+				na.author_id,
+				na.slug,
+				na.status,
+				na.published_at,
+				na.publish_at,
+				na.created_at,
+				na.updated_at,
+				na.deleted_at,
+				COALESCE(ARRAY_AGG(nt.topic_id ORDER BY nt.topic_id) FILTER (WHERE nt.topic_id IS NOT NULL), '{}') AS topic_ids
+			FROM news_articles na
+			LEFT JOIN news_topics nt ON nt.news_article_id = na.id AND nt.deleted_at >= na.deleted_at
+			WHERE na.deleted_at IS NOT NULL
+			GROUP BY na.id
+			ORDER BY na.deleted_at DESC, na.id DESC
+			LIMIT $1 OFFSET $2`)).
+		WithArgs(10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "deleted_at", "topic_ids"}).
+			AddRow(3, "slug-3", deletedAt, "{1,2}"))
+
+	articles, err := repos.GetTrashed(ctx, dto.NewPagination(2, 10))
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Equal(t, "slug-3", articles[0].Slug)
+	assert.Equal(t, deletedAt, articles[0].DeletedAt.Time)
+	assert.Equal(t, pq.Int32Array{1, 2}, articles[0].TopicIDs)
+
+	mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM news_articles WHERE deleted_at IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+
+	total, err := repos.CountTrashed(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, total)
+}
+
+func Test_RestoreNewsArticle(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+	deletedAt := time.Date(2025, 6, 5, 14, 0, 0, 0, time.UTC)
+
+	selectQuery := regexp.QuoteMeta(`SELECT id, deleted_at FROM news_articles WHERE slug = $1 AND deleted_at IS NOT NULL FOR UPDATE`)
+	restoreQuery := regexp.QuoteMeta(`UPDATE news_articles SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1`)
+	linksQuery := regexp.QuoteMeta(`UPDATE news_topics SET deleted_at = NULL WHERE news_article_id = $1 AND deleted_at >= $2`)
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(restored bool, err error)
+	}{
+		{
+			testname: "restores the article and the links deleted with it",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery(selectQuery).WithArgs("slug-3").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(3, deletedAt))
+				mockSql.ExpectExec(restoreQuery).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockSql.ExpectExec(linksQuery).WithArgs(3, deletedAt).WillReturnResult(sqlmock.NewResult(0, 2))
+				mockSql.ExpectCommit()
+			},
+			assertion: func(restored bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, restored)
+			},
+		},
+		{
+			testname: "article is not in the trash",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery(selectQuery).WithArgs("slug-3").WillReturnError(sql.ErrNoRows)
+				mockSql.ExpectCommit()
+			},
+			assertion: func(restored bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, restored)
+			},
+		},
+		{
+			testname: "failed restore links then rollback",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery(selectQuery).WithArgs("slug-3").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(3, deletedAt))
+				mockSql.ExpectExec(restoreQuery).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockSql.ExpectExec(linksQuery).WithArgs(3, deletedAt).WillReturnError(errors.New("db error"))
+				mockSql.ExpectRollback()
+			},
+			assertion: func(restored bool, err error) {
+				assert.Error(t, err)
+				assert.False(t, restored)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			restored, err := repos.Restore(ctx, "slug-3")
+			tt.assertion(restored, err)
+			assert.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PurgeNewsArticle(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := regexp.QuoteMeta(`DELETE FROM news_articles WHERE slug = $1 AND deleted_at IS NOT NULL`)
+
+	mockSql.ExpectExec(query).WithArgs("slug-3").WillReturnResult(sqlmock.NewResult(0, 1))
+	purged, err := repos.Purge(ctx, "slug-3")
+	assert.NoError(t, err)
+	assert.True(t, purged)
+
+	mockSql.ExpectExec(query).WithArgs("live-slug").WillReturnResult(sqlmock.NewResult(0, 0))
+	purged, err = repos.Purge(ctx, "live-slug")
+	assert.NoError(t, err)
+	assert.False(t, purged)
+
+	before := time.Date(2025, 6, 5, 14, 0, 0, 0, time.UTC)
+	mockSql.ExpectQuery(regexp.QuoteMeta(`
+			DELETE FROM news_articles
+			WHERE id IN (
+				SELECT id FROM news_articles
+				WHERE deleted_at < $1
+				ORDER BY deleted_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id`)).
+		WithArgs(before, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(9))
+
+	ids, err := repos.PurgeTrashed(ctx, before, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 9}, ids)
+}
+
+func Test_UpdateStatuses(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `UPDATE news_articles a\s+SET status = \$4::article_status,.*reviewer_id = COALESCE\(\$5::int, a.reviewer_id\),\s+review_comment = COALESCE\(\$6::text, a.review_comment\).*FROM unnest\(\$1::int\[\], \$2::article_status\[\], \$3::int\[\]\) AS s\(id, status, version\)\s+WHERE a.id = s.id AND a.status = s.status AND a.version = s.version AND a.deleted_at IS NULL\s+RETURNING a.id`
+	articles := []entity.NewsArticleWithTopic{
+		{ID: 3, Status: entity.StatusApproved, Version: 2},
+		{ID: 7, Status: entity.StatusScheduled, Version: 5},
+	}
+
+	mockSql.ExpectQuery(query).
+		WithArgs(pq.Int32Array{3, 7}, pq.StringArray{"approved", "scheduled"}, pq.Int32Array{2, 5}, entity.StatusPublished, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	moved, err := repos.UpdateStatuses(ctx, articles, entity.StatusPublished, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, moved)
+
+	mockSql.ExpectQuery(query).
+		WithArgs(pq.Int32Array{3}, pq.StringArray{"approved"}, pq.Int32Array{2}, entity.StatusInReview, 9, "check the sources").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	moved, err = repos.UpdateStatuses(ctx, articles[:1], entity.StatusInReview, utils.IntPtr(9), utils.StringPtr("check the sources"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, moved)
+
+	mockSql.ExpectQuery(query).WillReturnError(errors.New("db error"))
+	_, err = repos.UpdateStatuses(ctx, articles, entity.StatusPublished, nil, nil)
+	assert.Error(t, err)
+}
+
+func Test_DeleteArticles(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `UPDATE news_articles a\s+SET deleted_at = NOW\(\), updated_at = NOW\(\), version = a.version \+ 1\s+FROM unnest\(\$1::int\[\], \$2::int\[\]\) AS s\(id, version\)\s+WHERE a.id = s.id AND a.version = s.version AND a.deleted_at IS NULL\s+RETURNING a.id`
+	articles := []entity.NewsArticleWithTopic{{ID: 3, Version: 2}, {ID: 7, Version: 5}}
+
+	mockSql.ExpectQuery(query).
+		WithArgs(pq.Int32Array{3, 7}, pq.Int32Array{2, 5}).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	deleted, err := repos.DeleteArticles(ctx, articles)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, deleted)
+
+	mockSql.ExpectQuery(query).WillReturnError(errors.New("db error"))
+	_, err = repos.DeleteArticles(ctx, articles)
+	assert.Error(t, err)
+
+	mockSql.ExpectExec(regexp.QuoteMeta(`UPDATE news_articles SET updated_at = NOW(), version = version + 1 WHERE id = ANY($1::int[])`)).
+		WithArgs(pq.Int32Array{3, 7}).
+		WillReturnError(errors.New("db error"))
+
+	assert.Error(t, repos.TouchArticles(ctx, []int{3, 7}))
+}
+
+func Test_Export(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	declare := `(?s)DECLARE news_export NO SCROLL CURSOR FOR\s+SELECT na\.id, .*LEFT JOIN users u ON u\.id = na\.author_id.*` +
+		`na\.deleted_at IS NULL\s+AND na\.status = \$1 ORDER BY COALESCE\(na\.published_at, na\.created_at\) DESC, na\.id DESC`
+	fetch := `FETCH FORWARD 1000 FROM news_export`
+	columns := []string{
+		"id", "title", "summary", "content", "slug", "status", "author_id", "author_name",
+		"published_at", "created_at", "updated_at", "topic_ids", "topic_slugs",
+	}
+	now := time.Now()
+	exportRows := func(from, to int) *sqlmock.Rows {
+		rows := sqlmock.NewRows(columns)
+		for id := from; id < to; id++ {
+			rows.AddRow(id, "Title", nil, "Content", "slug", "published", 3, "Jane", now, now, now, pq.Int32Array{1}, pq.StringArray{"sports"})
+		}
+		return rows
+	}
+	filter := dto.NewsFilter{Status: entity.StatusPublished}
+
+	tests := []struct {
+		name      string
+		fail      bool
+		initMock  func()
+		assertion func(seen []entity.NewsExport, err error)
+	}{
+		{
+			name: "fetches until the cursor runs dry",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectExec(declare).WithArgs("published").WillReturnResult(sqlmock.NewResult(0, 0))
+				mockSql.ExpectQuery(fetch).WillReturnRows(exportRows(0, 1000))
+				mockSql.ExpectQuery(fetch).WillReturnRows(exportRows(1000, 1001))
+				mockSql.ExpectCommit()
+			},
+			assertion: func(seen []entity.NewsExport, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, seen, 1001)
+				assert.Equal(t, "Jane", seen[0].AuthorName)
+				assert.Equal(t, pq.StringArray{"sports"}, seen[1000].TopicSlugs)
+			},
+		},
+		{
+			name: "error from fn stops the export",
+			fail: true,
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectExec(declare).WithArgs("published").WillReturnResult(sqlmock.NewResult(0, 0))
+				mockSql.ExpectQuery(fetch).WillReturnRows(exportRows(0, 2))
+				mockSql.ExpectRollback()
+			},
+			assertion: func(seen []entity.NewsExport, err error) {
+				assert.EqualError(t, err, "write failed")
+				assert.Len(t, seen, 1)
+			},
+		},
+		{
+			name: "failed declare cursor",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectExec(declare).WithArgs("published").WillReturnError(errors.New("db error"))
+				mockSql.ExpectRollback()
+			},
+			assertion: func(seen []entity.NewsExport, err error) {
+				assert.Error(t, err)
+				assert.Empty(t, seen)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.initMock()
+			seen := []entity.NewsExport{}
+			err := repos.Export(ctx, filter, func(row entity.NewsExport) error {
+				seen = append(seen, row)
+				if tt.fail {
+					return errors.New("write failed")
+				}
+				return nil
+			})
+			tt.assertion(seen, err)
+			assert.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,8 +2,9 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"newsapi/internal/utils"
+	"slices"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -17,97 +18,247 @@ func NewNewsTopicsRepository(db *sqlx.DB) NewsTopicsRepository {
 	return newsTopicsRepository{db: db}
 }
 
+// Create links articleID to every topic of topicIDs. It returns ErrUnknownTopic
+// when one of the topics does not exist or is deleted.
 func (r newsTopicsRepository) Create(ctx context.Context, articleID int, topicIDs []int) error {
 	if len(topicIDs) == 0 {
 		return nil
 	}
 
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
+	return runInTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := checkLiveTopics(ctx, tx, topicIDs); err != nil {
+			return err
+		}
 
-	query := "INSERT INTO news_topics (news_article_id, topic_id) VALUES "
-	values := make([]interface{}, 0, len(topicIDs)*2)
-	valueArgs := make([]string, 0, len(topicIDs))
+		query := "INSERT INTO news_topics (news_article_id, topic_id) VALUES "
+		values := make([]interface{}, 0, len(topicIDs)*2)
+		valueArgs := make([]string, 0, len(topicIDs))
 
-	for i, topicID := range topicIDs {
-		valueArgs = append(valueArgs, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
-		values = append(values, articleID, topicID)
-	}
+		for i, topicID := range topicIDs {
+			valueArgs = append(valueArgs, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+			values = append(values, articleID, topicID)
+		}
 
-	query += strings.Join(valueArgs, ",")
+		query += strings.Join(valueArgs, ",")
 
-	_, err = tx.ExecContext(ctx, query, values...)
-	if err != nil {
-		if strings.Contains(err.Error(), "foreign key constraint") {
-			return errors.New("one or more topic IDs are invalid")
+		_, err := tx.ExecContext(ctx, query, values...)
+		if err != nil {
+			if utils.IsForeignKeyViolation(err) {
+				return ErrUnknownTopic
+			}
+			return err
 		}
-		return err
-	}
 
-	return tx.Commit()
+		return nil
+	})
 }
 
+// ReplaceArticleTopics makes topicIDs the topics of articleID. It returns
+// ErrUnknownTopic when one of the topics does not exist or is deleted.
 func (r newsTopicsRepository) ReplaceArticleTopics(ctx context.Context, articleID int, topicIDs []int32) error {
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// Get current (non-deleted) topic_ids
-	var currentTopicIDs []int32
-	err = tx.SelectContext(ctx, &currentTopicIDs,
-		`SELECT topic_id FROM news_topics WHERE news_article_id = $1 AND deleted_at IS NULL`,
-		articleID,
-	)
-	if err != nil {
-		return err
-	}
+	return runInTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if len(topicIDs) > 0 {
+			ids := make([]int, 0, len(topicIDs))
+			for _, id := range topicIDs {
+				ids = append(ids, int(id))
+			}
+			if err := checkLiveTopics(ctx, tx, ids); err != nil {
+				return err
+			}
+		}
 
-	// Convert slices to maps for quick lookup
-	newTopicMap := make(map[int32]struct{}, len(topicIDs))
-	for _, id := range topicIDs {
-		newTopicMap[id] = struct{}{}
-	}
+		// Get current (non-deleted) topic_ids
+		var currentTopicIDs []int32
+		err := tx.SelectContext(ctx, &currentTopicIDs,
+			`SELECT topic_id FROM news_topics WHERE news_article_id = $1 AND deleted_at IS NULL`,
+			articleID,
+		)
+		if err != nil {
+			return err
+		}
 
-	currentMap := make(map[int32]struct{}, len(currentTopicIDs))
-	for _, id := range currentTopicIDs {
-		currentMap[id] = struct{}{}
-	}
+		// Convert slices to maps for quick lookup
+		newTopicMap := make(map[int32]struct{}, len(topicIDs))
+		for _, id := range topicIDs {
+			newTopicMap[id] = struct{}{}
+		}
 
-	// Soft-delete topics that are no longer present
-	for _, id := range currentTopicIDs {
-		if _, keep := newTopicMap[id]; !keep {
+		currentMap := make(map[int32]struct{}, len(currentTopicIDs))
+		for _, id := range currentTopicIDs {
+			currentMap[id] = struct{}{}
+		}
+
+		// Soft-delete topics that are no longer present
+		for _, id := range currentTopicIDs {
+			if _, keep := newTopicMap[id]; !keep {
+				_, err := tx.ExecContext(ctx,
+					`UPDATE news_topics SET deleted_at = NOW() WHERE news_article_id = $1 AND topic_id = $2 AND deleted_at IS NULL`,
+					articleID, id)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// Insert new topics or undelete them if previously deleted
+		for _, id := range topicIDs {
 			_, err := tx.ExecContext(ctx,
-				`UPDATE news_topics SET deleted_at = NOW() WHERE news_article_id = $1 AND topic_id = $2 AND deleted_at IS NULL`,
+				`INSERT INTO news_topics (news_article_id, topic_id, created_at, deleted_at)
+				 VALUES ($1, $2, NOW(), NULL)
+				 ON CONFLICT (news_article_id, topic_id)
+				 DO UPDATE SET deleted_at = NULL`,
 				articleID, id)
+			if utils.IsForeignKeyViolation(err) {
+				return ErrUnknownTopic
+			}
 			if err != nil {
 				return err
 			}
 		}
+
+		return nil
+	})
+}
+
+func (r newsTopicsRepository) DeleteByArticleID(ctx context.Context, articleID int) error {
+	query := `UPDATE news_topics SET deleted_at = NOW() WHERE news_article_id = $1 AND deleted_at IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, articleID)
+	return err
+}
+
+// DetachTopic removes every link to topicID and returns how many articles lost it
+func (r newsTopicsRepository) DetachTopic(ctx context.Context, topicID int) (int, error) {
+	query := `UPDATE news_topics SET deleted_at = NOW() WHERE topic_id = $1 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, topicID)
+	if err != nil {
+		return 0, err
 	}
 
-	// Insert new topics or undelete them if previously deleted
-	for _, id := range topicIDs {
-		_, err := tx.ExecContext(ctx,
-			`INSERT INTO news_topics (news_article_id, topic_id, created_at, deleted_at)
-			 VALUES ($1, $2, NOW(), NULL)
-			 ON CONFLICT (news_article_id, topic_id)
-			 DO UPDATE SET deleted_at = NULL`,
-			articleID, id)
+	detached, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(detached), nil
+}
+
+// MoveTopic relinks every article filed under fromID to toID and returns how
+// many were moved. Articles already linked to toID keep that single link, one
+// whose link to toID was removed gets it back. The links a trashed article
+// lost with its delete move too, so restoring it files it under toID.
+func (r newsTopicsRepository) MoveTopic(ctx context.Context, fromID, toID int) (int, error) {
+	moved := 0
+	err := runInTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		query := `
+		WITH moved AS (
+			UPDATE news_topics SET deleted_at = NOW()
+			WHERE topic_id = $1 AND deleted_at IS NULL
+			RETURNING news_article_id
+		)
+		INSERT INTO news_topics (news_article_id, topic_id, created_at, deleted_at)
+		SELECT news_article_id, $2, NOW(), NULL FROM moved
+		ON CONFLICT (news_article_id, topic_id)
+		DO UPDATE SET deleted_at = NULL`
+
+		result, err := tx.ExecContext(ctx, query, fromID, toID)
 		if err != nil {
 			return err
 		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		moved = int(affected)
+
+		// keep the deleted_at Restore of news_articles compares with
+		trashedQuery := `
+		WITH trashed AS (
+			DELETE FROM news_topics nt
+			USING news_articles a
+			WHERE nt.topic_id = $1 AND nt.news_article_id = a.id
+				AND a.deleted_at IS NOT NULL AND nt.deleted_at >= a.deleted_at
+			RETURNING nt.news_article_id, nt.created_at, nt.deleted_at
+		)
+		INSERT INTO news_topics (news_article_id, topic_id, created_at, deleted_at)
+		SELECT news_article_id, $2, created_at, deleted_at FROM trashed
+		ON CONFLICT (news_article_id, topic_id)
+		DO UPDATE SET deleted_at = GREATEST(news_topics.deleted_at, EXCLUDED.deleted_at)`
+
+		_, err = tx.ExecContext(ctx, trashedQuery, fromID, toID)
+		return err
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return tx.Commit()
+	return moved, nil
 }
 
-func (r newsTopicsRepository) DeleteByArticleID(ctx context.Context, articleID int) error {
-	query := `UPDATE news_topics SET deleted_at = NOW() WHERE news_article_id = $1 AND deleted_at IS NULL`
-	_, err := r.db.ExecContext(ctx, query, articleID)
+// AddTopics links every article of articleIDs to every topic of topicIDs and
+// brings back links that were removed. It returns ErrUnknownTopic when one of
+// the topics does not exist or is deleted.
+func (r newsTopicsRepository) AddTopics(ctx context.Context, articleIDs, topicIDs []int) error {
+	return runInTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := checkLiveTopics(ctx, tx, topicIDs); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO news_topics (news_article_id, topic_id, created_at, deleted_at)
+			SELECT a.id, t.id, NOW(), NULL
+			FROM unnest($1::int[]) AS a(id)
+			CROSS JOIN unnest($2::int[]) AS t(id)
+			ON CONFLICT (news_article_id, topic_id)
+			DO UPDATE SET deleted_at = NULL`
+		_, err := tx.ExecContext(ctx, query, int32Array(articleIDs), int32Array(topicIDs))
+		return err
+	})
+}
+
+// checkLiveTopics returns ErrUnknownTopic unless every topic of topicIDs exists
+// and is not deleted. The foreign key only catches missing topics.
+func checkLiveTopics(ctx context.Context, db dbtx, topicIDs []int) error {
+	var live int
+	err := db.GetContext(ctx, &live,
+		`SELECT COUNT(*) FROM topics WHERE id = ANY($1::int[]) AND deleted_at IS NULL`,
+		int32Array(topicIDs))
+	if err != nil {
+		return err
+	}
+	if live != len(slices.Compact(slices.Sorted(slices.Values(topicIDs)))) {
+		return ErrUnknownTopic
+	}
+
+	return nil
+}
+
+// RemoveTopics unlinks every topic of topicIDs from every article of articleIDs
+func (r newsTopicsRepository) RemoveTopics(ctx context.Context, articleIDs, topicIDs []int) error {
+	query := `UPDATE news_topics SET deleted_at = NOW()
+		WHERE news_article_id = ANY($1::int[]) AND topic_id = ANY($2::int[]) AND deleted_at IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, int32Array(articleIDs), int32Array(topicIDs))
+	return err
+}
+
+// DeleteByArticleIDs removes the links of every article of articleIDs
+func (r newsTopicsRepository) DeleteByArticleIDs(ctx context.Context, articleIDs []int) error {
+	query := `UPDATE news_topics SET deleted_at = NOW() WHERE news_article_id = ANY($1::int[]) AND deleted_at IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, int32Array(articleIDs))
+	return err
+}
+
+// CreateLinks links articleIDs[i] to topicIDs[i] for every i in one statement
+func (r newsTopicsRepository) CreateLinks(ctx context.Context, articleIDs, topicIDs []int) error {
+	if len(articleIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO news_topics (news_article_id, topic_id)
+		SELECT l.article_id, l.topic_id FROM unnest($1::int[], $2::int[]) AS l(article_id, topic_id)
+		ON CONFLICT (news_article_id, topic_id) DO NOTHING`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, int32Array(articleIDs), int32Array(topicIDs))
 	return err
 }
@@ -3,15 +3,20 @@ package repository_test
 import (
 	"context"
 	"errors"
-	"fmt"
 	"newsapi/internal/repository"
 	"newsapi/internal/utils"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
+// liveTopics is the check that every topic to link exists and is not deleted
+var liveTopics = regexp.QuoteMeta(`SELECT COUNT(*) FROM topics WHERE id = ANY($1::int[]) AND deleted_at IS NULL`)
+
 func Test_CreateNewsTopics(t *testing.T) {
 	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
 	defer mockDb.Close()
@@ -34,6 +39,7 @@ func Test_CreateNewsTopics(t *testing.T) {
 			articleID := 10
 
 			mockSql.ExpectBegin()
+			mockSql.ExpectQuery(liveTopics).WithArgs(pq.Int32Array{1, 2}).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 			mockSql.ExpectExec(`INSERT INTO news_topics \(news_article_id, topic_id\) VALUES \(\$1, \$2\),\(\$3, \$4\)`).
 				WithArgs(articleID, 1, articleID, 2).
 				WillReturnResult(sqlmock.NewResult(0, 2))
@@ -49,12 +55,23 @@ func Test_CreateNewsTopics(t *testing.T) {
 		articleID := 10
 
 		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(liveTopics).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 		mockSql.ExpectExec(`INSERT INTO news_topics`).WithArgs(articleID, 999).
-			WillReturnError(fmt.Errorf("pq: insert or update on table violates foreign key constraint"))
+			WillReturnError(&pq.Error{Code: "23503", Message: "insert or update on table violates foreign key constraint"})
 		mockSql.ExpectRollback()
 
 		err := repos.Create(ctx, articleID, topicIDs)
-		assert.EqualError(t, err, "one or more topic IDs are invalid")
+		assert.Equal(t, repository.ErrUnknownTopic, err)
+	})
+
+	t.Run("soft-deleted topic is not linked", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(liveTopics).WithArgs(pq.Int32Array{1, 7}).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockSql.ExpectRollback()
+
+		err := repos.Create(ctx, 10, []int{1, 7})
+		assert.Equal(t, repository.ErrUnknownTopic, err)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
 	})
 }
 
@@ -70,6 +87,7 @@ func Test_ReplaceArticleTopics(t *testing.T) {
 		topicIDs := []int32{10, 20}
 
 		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(liveTopics).WithArgs(pq.Int32Array{10, 20}).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
 		// existing topics in DB
 		mockSql.ExpectQuery(`SELECT topic_id FROM news_topics`).
@@ -102,6 +120,7 @@ func Test_ReplaceArticleTopics(t *testing.T) {
 		topicIDs := []int32{1, 2}
 
 		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(liveTopics).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 		mockSql.ExpectQuery(`SELECT topic_id FROM news_topics`).
 			WithArgs(articleID).
 			WillReturnError(errors.New("db error"))
@@ -110,4 +129,183 @@ func Test_ReplaceArticleTopics(t *testing.T) {
 		err := repos.ReplaceArticleTopics(ctx, articleID, topicIDs)
 		assert.Error(t, err)
 	})
+
+	t.Run("soft-deleted topic is not linked", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(liveTopics).WithArgs(pq.Int32Array{10, 40}).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockSql.ExpectRollback()
+
+		err := repos.ReplaceArticleTopics(ctx, 3, []int32{10, 40})
+		assert.Equal(t, repository.ErrUnknownTopic, err)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+}
+
+func Test_MoveTopic(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsTopicsRepository(sqlxDB)
+	newsRepo := repository.NewNewsArticlesRepository(sqlxDB)
+	ctx := context.Background()
+
+	moveLive := `WITH moved AS \(\s*UPDATE news_topics SET deleted_at = NOW\(\)\s*WHERE topic_id = \$1 AND deleted_at IS NULL\s*RETURNING news_article_id\s*\)\s*INSERT INTO news_topics .* ON CONFLICT \(news_article_id, topic_id\)\s*DO UPDATE SET deleted_at = NULL`
+	moveTrashed := `WITH trashed AS \(\s*DELETE FROM news_topics nt\s*USING news_articles a\s*WHERE nt.topic_id = \$1 AND nt.news_article_id = a.id\s*AND a.deleted_at IS NOT NULL AND nt.deleted_at >= a.deleted_at\s*RETURNING nt.news_article_id, nt.created_at, nt.deleted_at\s*\)\s*INSERT INTO news_topics .* SELECT news_article_id, \$2, created_at, deleted_at FROM trashed\s*ON CONFLICT \(news_article_id, topic_id\)\s*DO UPDATE SET deleted_at = GREATEST\(news_topics.deleted_at, EXCLUDED.deleted_at\)`
+
+	t.Run("relinks the articles of a topic", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectExec(moveLive).
+			WithArgs(2, 5).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mockSql.ExpectExec(moveTrashed).
+			WithArgs(2, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSql.ExpectCommit()
+
+		moved, err := repos.MoveTopic(ctx, 2, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, moved)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+
+	t.Run("trashed article restored after a merge is filed under the target", func(t *testing.T) {
+		deletedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		mockSql.ExpectBegin()
+		mockSql.ExpectExec(moveLive).WithArgs(2, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockSql.ExpectExec(moveTrashed).WithArgs(2, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSql.ExpectCommit()
+
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT id, deleted_at FROM news_articles WHERE slug = $1 AND deleted_at IS NOT NULL FOR UPDATE`)).
+			WithArgs("trashed").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(8, deletedAt))
+		mockSql.ExpectExec(regexp.QuoteMeta(`UPDATE news_articles SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1`)).
+			WithArgs(8).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		// the link moved to topic 5 kept the deleted_at of the article delete
+		mockSql.ExpectExec(regexp.QuoteMeta(`UPDATE news_topics SET deleted_at = NULL WHERE news_article_id = $1 AND deleted_at >= $2`)).
+			WithArgs(8, deletedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSql.ExpectCommit()
+
+		moved, err := repos.MoveTopic(ctx, 2, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, moved)
+
+		restored, err := newsRepo.Restore(ctx, "trashed")
+		assert.NoError(t, err)
+		assert.True(t, restored)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+
+	t.Run("returns error on exec failure", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectExec(`WITH moved AS`).
+			WithArgs(2, 5).
+			WillReturnError(errors.New("db error"))
+		mockSql.ExpectRollback()
+
+		_, err := repos.MoveTopic(ctx, 2, 5)
+		assert.Error(t, err)
+	})
+}
+
+func Test_DetachTopic(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsTopicsRepository(sqlxDB)
+	ctx := context.Background()
+
+	t.Run("removes every link to the topic", func(t *testing.T) {
+		mockSql.ExpectExec(`UPDATE news_topics SET deleted_at = NOW\(\) WHERE topic_id = \$1 AND deleted_at IS NULL`).
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		detached, err := repos.DetachTopic(ctx, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, detached)
+	})
+
+	t.Run("returns error on exec failure", func(t *testing.T) {
+		mockSql.ExpectExec(`UPDATE news_topics SET deleted_at`).
+			WithArgs(4).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repos.DetachTopic(ctx, 4)
+		assert.Error(t, err)
+	})
+}
+
+func Test_AddTopics(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsTopicsRepository(sqlxDB)
+	ctx := context.Background()
+
+	countQuery := `SELECT COUNT\(\*\) FROM topics WHERE id = ANY\(\$1::int\[\]\) AND deleted_at IS NULL`
+
+	t.Run("links every article to every topic", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(countQuery).
+			WithArgs(pq.Int32Array{2, 5}).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockSql.ExpectExec(`INSERT INTO news_topics .* CROSS JOIN unnest\(\$2::int\[\]\) AS t\(id\)\s*ON CONFLICT \(news_article_id, topic_id\)\s*DO UPDATE SET deleted_at = NULL`).
+			WithArgs(pq.Int32Array{3, 7}, pq.Int32Array{2, 5}).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mockSql.ExpectCommit()
+
+		err := repos.AddTopics(ctx, []int{3, 7}, []int{2, 5})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects unknown topics", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(countQuery).
+			WithArgs(pq.Int32Array{2, 99}).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockSql.ExpectRollback()
+
+		err := repos.AddTopics(ctx, []int{3, 7}, []int{2, 99})
+		assert.Equal(t, repository.ErrUnknownTopic, err)
+	})
+}
+
+func Test_RemoveTopics(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsTopicsRepository(sqlxDB)
+	ctx := context.Background()
+
+	mockSql.ExpectExec(`UPDATE news_topics SET deleted_at = NOW\(\)\s*WHERE news_article_id = ANY\(\$1::int\[\]\) AND topic_id = ANY\(\$2::int\[\]\)`).
+		WithArgs(pq.Int32Array{3, 7}, pq.Int32Array{2}).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repos.RemoveTopics(ctx, []int{3, 7}, []int{2}))
+
+	mockSql.ExpectExec(`UPDATE news_topics SET deleted_at = NOW\(\) WHERE news_article_id = ANY`).
+		WithArgs(pq.Int32Array{3, 7}).
+		WillReturnError(errors.New("db error"))
+
+	assert.Error(t, repos.DeleteByArticleIDs(ctx, []int{3, 7}))
+}
+
+func Test_CreateLinks(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewNewsTopicsRepository(sqlxDB)
+	ctx := context.Background()
+
+	mockSql.ExpectExec(`INSERT INTO news_topics \(news_article_id, topic_id\)\s*SELECT l.article_id, l.topic_id FROM unnest\(\$1::int\[\], \$2::int\[\]\)`).
+		WithArgs(pq.Int32Array{7, 7, 8}, pq.Int32Array{1, 2, 1}).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	assert.NoError(t, repos.CreateLinks(ctx, []int{7, 7, 8}, []int{1, 2, 1}))
+
+	// nothing to link, nothing to run
+	assert.NoError(t, repos.CreateLinks(ctx, nil, nil))
 }
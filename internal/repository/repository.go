@@ -4,31 +4,93 @@ import (
 	"context"
 	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
+	"time"
 )
 
+// Transactor makes the repository calls of one usecase operation atomic
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UsersRepository interface {
 	Create(ctx context.Context, entity *entity.User) error
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	GetByID(ctx context.Context, id int) (entity.User, error)
+	UpdateRole(ctx context.Context, id int, role entity.UserRole) error
+	GetAll(ctx context.Context, pagination dto.Pagination) ([]entity.User, error)
+	Count(ctx context.Context) (int, error)
+	UpdateUserFields(ctx context.Context, user *entity.User, updateFields []string) error
+	Deactivate(ctx context.Context, id int) error
 }
 
 type TopicsRepository interface {
 	Create(ctx context.Context, entity *entity.Topic) error
-	GetAll(ctx context.Context) ([]entity.Topic, error)
+	GetAll(ctx context.Context, pagination dto.Pagination) ([]entity.Topic, error)
+	Count(ctx context.Context) (int, error)
 	GetByID(ctx context.Context, id int) (entity.Topic, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Topic, error)
+	GetIDsBySlugs(ctx context.Context, slugs []string) ([]entity.TopicSlugRef, error)
+	GetLiveIDs(ctx context.Context, topicIDs []int) ([]int, error)
+	CountPublishedArticles(ctx context.Context, topicIDs []int) ([]entity.TopicArticleCount, error)
+	GetLatestPublished(ctx context.Context, topicID int, limit int) ([]entity.PublishedNewsWithTopic, error)
+	GetHierarchy(ctx context.Context) ([]entity.Topic, error)
+	GetAncestorIDs(ctx context.Context, id int) ([]int, error)
 	UpdateTopicFileds(ctx context.Context, topic *entity.Topic, updateFields []string) error
-	Delete(ctx context.Context, id int) error
+	GetByRetiredSlug(ctx context.Context, slug string) (entity.RetiredSlug, error)
+	GetTakenSlugs(ctx context.Context, base string) ([]string, error)
+	Delete(ctx context.Context, id int, version int) error
+	Merge(ctx context.Context, source entity.Topic, targetID int) error
+	GetTrashed(ctx context.Context, pagination dto.Pagination) ([]entity.Topic, error)
+	CountTrashed(ctx context.Context) (int, error)
+	Restore(ctx context.Context, id int) (bool, error)
+	Purge(ctx context.Context, id int) (bool, error)
+	PurgeTrashed(ctx context.Context, before time.Time, limit int) ([]int, error)
 }
 
 type NewsArticlesRepository interface {
 	Create(ctx context.Context, entity *entity.NewsArticle) (int, error)
 	GetArticleBySlug(ctx context.Context, slug string) (entity.NewsArticleWithTopic, error)
+	GetArticlesBySlugs(ctx context.Context, slugs []string) ([]entity.NewsArticleWithTopic, error)
 	GetActiveArticleBySlug(ctx context.Context, slug string) (entity.ActiveNewsWithTopic, error)
+	GetPublishedByAuthor(ctx context.Context, authorID int, pagination dto.Pagination) ([]entity.PublishedNewsWithTopic, error)
+	CountPublishedByAuthor(ctx context.Context, authorID int) (int, error)
 	GetAll(ctx context.Context, filter dto.NewsFilter) ([]entity.NewsArticleWithTopicID, error)
+	Count(ctx context.Context, filter dto.NewsFilter) (int, error)
+	Export(ctx context.Context, filter dto.NewsFilter, fn func(entity.NewsExport) error) error
 	UpdateArticleFields(ctx context.Context, entity *entity.NewsArticleWithTopic, updateFields []string) error
-	DeleteBySlug(ctx context.Context, slug string) error
+	GetByRetiredSlug(ctx context.Context, slug string) (entity.RetiredSlug, error)
+	GetTakenSlugs(ctx context.Context, base string) ([]string, error)
+	GetTakenSlugsOf(ctx context.Context, bases []string) ([]string, error)
+	CreateBatch(ctx context.Context, articles []entity.NewsArticle) ([]int, error)
+	UpdateStatus(ctx context.Context, entity *entity.NewsArticleWithTopic, from entity.ArticleStatus) (bool, error)
+	UpdateStatuses(ctx context.Context, articles []entity.NewsArticleWithTopic, to entity.ArticleStatus, reviewerID *int, comment *string) ([]int, error)
+	TouchArticles(ctx context.Context, ids []int) error
+	DeleteBySlug(ctx context.Context, slug string, version int) error
+	DeleteArticles(ctx context.Context, articles []entity.NewsArticleWithTopic) ([]int, error)
+	GetTrashed(ctx context.Context, pagination dto.Pagination) ([]entity.NewsArticleWithTopicID, error)
+	CountTrashed(ctx context.Context) (int, error)
+	Restore(ctx context.Context, slug string) (bool, error)
+	Purge(ctx context.Context, slug string) (bool, error)
+	PurgeTrashed(ctx context.Context, before time.Time, limit int) ([]int, error)
+	PublishDue(ctx context.Context, now time.Time, limit int) ([]int, error)
 }
 
 type NewsTopicsRepository interface {
 	Create(ctx context.Context, articleID int, topicIDs []int) error
 	ReplaceArticleTopics(ctx context.Context, articleID int, topicIDs []int32) error
 	DeleteByArticleID(ctx context.Context, articleID int) error
+	DeleteByArticleIDs(ctx context.Context, articleIDs []int) error
+	AddTopics(ctx context.Context, articleIDs, topicIDs []int) error
+	CreateLinks(ctx context.Context, articleIDs, topicIDs []int) error
+	RemoveTopics(ctx context.Context, articleIDs, topicIDs []int) error
+	DetachTopic(ctx context.Context, topicID int) (int, error)
+	MoveTopic(ctx context.Context, fromID, toID int) (int, error)
+}
+
+type ArticleRevisionsRepository interface {
+	Create(ctx context.Context, revision *entity.ArticleRevision) error
+	CreateSnapshots(ctx context.Context, articleIDs []int, editorID int) error
+	GetByArticleID(ctx context.Context, articleID int, pagination dto.Pagination) ([]entity.ArticleRevision, error)
+	CountByArticleID(ctx context.Context, articleID int) (int, error)
+	GetByRevision(ctx context.Context, articleID int, revision int) (entity.ArticleRevision, error)
 }
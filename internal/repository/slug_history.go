@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"newsapi/internal/model/entity"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// slugHistory describes the table keeping the replaced slugs of one kind of row
+type slugHistory struct {
+	ownerTable  string
+	table       string
+	ownerColumn string
+}
+
+var (
+	newsArticleSlugs = slugHistory{ownerTable: "news_articles", table: "news_article_slugs", ownerColumn: "news_article_id"}
+	topicSlugs       = slugHistory{ownerTable: "topics", table: "topic_slugs", ownerColumn: "topic_id"}
+)
+
+// update runs an update query changing the slug of row id to newSlug and records
+// the slug it replaces in the same transaction. The row may take back one of its
+// own retired slugs, which then leaves the history again.
+func (h slugHistory) update(
+	ctx context.Context,
+	db *sqlx.DB,
+	id int,
+	newSlug string,
+	query string,
+	args []interface{},
+) error {
+	return runInTx(ctx, db, func(tx *sqlx.Tx) error {
+		var oldSlug string
+		err := tx.GetContext(ctx, &oldSlug, fmt.Sprintf(`SELECT slug FROM %s WHERE id = $1 FOR UPDATE`, h.ownerTable), id)
+		if err != nil {
+			return err
+		}
+
+		if err := execVersioned(ctx, tx, query, args); err != nil {
+			return err
+		}
+
+		deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND slug = $2`, h.table, h.ownerColumn)
+		if _, err := tx.ExecContext(ctx, deleteQuery, id, newSlug); err != nil {
+			return err
+		}
+
+		insertQuery := fmt.Sprintf(`INSERT INTO %s (%s, slug) VALUES ($1, $2) ON CONFLICT (slug) DO NOTHING`, h.table, h.ownerColumn)
+		_, err = tx.ExecContext(ctx, insertQuery, id, oldSlug)
+		return err
+	})
+}
+
+// getRetired looks up which row used slug before and the slug it has now
+func (h slugHistory) getRetired(ctx context.Context, db dbtx, slug string) (entity.RetiredSlug, error) {
+	query := fmt.Sprintf(`
+		SELECT s.slug, s.%s AS owner_id, o.slug AS current_slug, o.deleted_at
+		FROM %s s
+		INNER JOIN %s o ON o.id = s.%s
+		WHERE s.slug = $1`, h.ownerColumn, h.table, h.ownerTable, h.ownerColumn)
+
+	var retired entity.RetiredSlug
+	err := db.GetContext(ctx, &retired, query, slug)
+	return retired, err
+}
+
+// taken lists the current and retired slugs that equal base or extend it with
+// a suffix, deleted rows included since they keep their slug
+func (h slugHistory) taken(ctx context.Context, db dbtx, base string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT slug FROM %s WHERE slug = $1 OR slug LIKE $2
+		UNION
+		SELECT slug FROM %s WHERE slug = $1 OR slug LIKE $2`, h.ownerTable, h.table)
+
+	var slugs []string
+	err := db.SelectContext(ctx, &slugs, query, base, base+"-%")
+	if err != nil {
+		return nil, err
+	}
+
+	return slugs, nil
+}
+
+// takenAny is taken for several bases in one query
+func (h slugHistory) takenAny(ctx context.Context, db dbtx, bases []string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT slug FROM %s WHERE slug = ANY($1::text[]) OR slug LIKE ANY($2::text[])
+		UNION
+		SELECT slug FROM %s WHERE slug = ANY($1::text[]) OR slug LIKE ANY($2::text[])`, h.ownerTable, h.table)
+
+	patterns := make(pq.StringArray, 0, len(bases))
+	for _, base := range bases {
+		patterns = append(patterns, base+"-%")
+	}
+
+	var slugs []string
+	err := db.SelectContext(ctx, &slugs, query, pq.StringArray(bases), patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return slugs, nil
+}
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"fmt"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type topicRepository struct {
@@ -21,10 +24,10 @@ func NewTopicRepository(db *sqlx.DB) TopicsRepository {
 }
 
 func (r topicRepository) Create(ctx context.Context, entity *entity.Topic) error {
-	query := `INSERT INTO topics (name, description, slug) 
-			VALUES (:name, :description, :slug)
+	query := `INSERT INTO topics (name, description, slug, parent_id)
+			VALUES (:name, :description, :slug, :parent_id)
 			RETURNING id`
-	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	stmt, err := conn(ctx, r.db).PrepareNamedContext(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -39,13 +42,23 @@ func (r topicRepository) Create(ctx context.Context, entity *entity.Topic) error
 	return nil
 }
 
-func (r topicRepository) GetAll(ctx context.Context) ([]entity.Topic, error) {
+func (r topicRepository) GetAll(ctx context.Context, pagination dto.Pagination) ([]entity.Topic, error) {
 	query := `
-	SELECT id, name, description, slug, created_at, updated_at, deleted_at 
-	FROM topics 
+	SELECT id, name, description, slug, parent_id, created_at, updated_at, deleted_at, version
+	FROM topics
 	WHERE deleted_at IS NULL`
+
+	var args []interface{}
+	if pagination.Cursor != nil {
+		query += " AND id > $1"
+		args = append(args, pagination.Cursor.ID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, pagination.Limit, pagination.Offset())
+
 	var topics []entity.Topic
-	err := r.db.SelectContext(ctx, &topics, query)
+	err := conn(ctx, r.db).SelectContext(ctx, &topics, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -53,15 +66,43 @@ func (r topicRepository) GetAll(ctx context.Context) ([]entity.Topic, error) {
 	return topics, nil
 }
 
+func (r topicRepository) Count(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM topics WHERE deleted_at IS NULL`
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
 func (r topicRepository) GetByID(ctx context.Context, id int) (entity.Topic, error) {
 	query := `
-		SELECT id, name, description, slug, created_at, updated_at 
-		FROM topics 
+		SELECT id, name, description, slug, parent_id, created_at, updated_at, version
+		FROM topics
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 	var topic entity.Topic
 
-	err := r.db.GetContext(ctx, &topic, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, &topic, query, id)
+	if err != nil {
+		return entity.Topic{}, err
+	}
+
+	return topic, nil
+}
+
+func (r topicRepository) GetBySlug(ctx context.Context, slug string) (entity.Topic, error) {
+	query := `
+		SELECT id, name, description, slug, parent_id, created_at, updated_at, version
+		FROM topics
+		WHERE slug = $1 AND deleted_at IS NULL
+	`
+	var topic entity.Topic
+
+	err := conn(ctx, r.db).GetContext(ctx, &topic, query, slug)
 	if err != nil {
 		return entity.Topic{}, err
 	}
@@ -69,6 +110,129 @@ func (r topicRepository) GetByID(ctx context.Context, id int) (entity.Topic, err
 	return topic, nil
 }
 
+// GetIDsBySlugs resolves slugs, current or retired, to the live topics using
+// them. Unknown slugs are left out of the result.
+func (r topicRepository) GetIDsBySlugs(ctx context.Context, slugs []string) ([]entity.TopicSlugRef, error) {
+	query := `
+		SELECT slug, id AS topic_id FROM topics
+		WHERE slug = ANY($1::text[]) AND deleted_at IS NULL
+		UNION
+		SELECT ts.slug, ts.topic_id FROM topic_slugs ts
+		JOIN topics t ON t.id = ts.topic_id AND t.deleted_at IS NULL
+		WHERE ts.slug = ANY($1::text[])`
+
+	refs := []entity.TopicSlugRef{}
+	err := conn(ctx, r.db).SelectContext(ctx, &refs, query, pq.StringArray(slugs))
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// GetLiveIDs returns the ids of topicIDs that belong to topics which are not deleted
+func (r topicRepository) GetLiveIDs(ctx context.Context, topicIDs []int) ([]int, error) {
+	query := `SELECT id FROM topics WHERE id = ANY($1::int[]) AND deleted_at IS NULL`
+
+	ids := []int{}
+	err := conn(ctx, r.db).SelectContext(ctx, &ids, query, int32Array(topicIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// CountPublishedArticles counts the live published articles linked to each of
+// topicIDs, topics without any are left out of the result
+func (r topicRepository) CountPublishedArticles(ctx context.Context, topicIDs []int) ([]entity.TopicArticleCount, error) {
+	query := `
+		SELECT nt.topic_id, COUNT(*) AS article_count
+		FROM news_topics nt
+		INNER JOIN news_articles a ON a.id = nt.news_article_id
+		WHERE nt.topic_id = ANY($1::int[]) AND nt.deleted_at IS NULL
+			AND a.status = 'published' AND a.deleted_at IS NULL
+		GROUP BY nt.topic_id`
+
+	counts := []entity.TopicArticleCount{}
+	err := conn(ctx, r.db).SelectContext(ctx, &counts, query, int32Array(topicIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetLatestPublished returns the limit most recently published live articles
+// linked to topicID
+func (r topicRepository) GetLatestPublished(ctx context.Context, topicID int, limit int) ([]entity.PublishedNewsWithTopic, error) {
+	query := `SELECT
+				a.id,
+				a.title,
+				a.summary,
+				a.slug,
+				a.published_at,
+				COALESCE(array_agg(t.name ORDER BY t.name) FILTER (WHERE t.name IS NOT NULL), '{}') AS topics
+			FROM news_articles a
+			LEFT JOIN news_topics nt ON nt.news_article_id = a.id AND nt.deleted_at IS NULL
+			LEFT JOIN topics t ON t.id = nt.topic_id AND t.deleted_at IS NULL
+			WHERE a.status = 'published' AND a.deleted_at IS NULL
+				AND EXISTS (
+					SELECT 1 FROM news_topics ft
+					WHERE ft.news_article_id = a.id AND ft.topic_id = $1 AND ft.deleted_at IS NULL
+				)
+			GROUP BY a.id
+			ORDER BY a.published_at DESC, a.id DESC
+			LIMIT $2`
+
+	articles := []entity.PublishedNewsWithTopic{}
+	err := conn(ctx, r.db).SelectContext(ctx, &articles, query, topicID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// GetHierarchy returns every live topic with its parent, ordered by name, for
+// the caller to nest
+func (r topicRepository) GetHierarchy(ctx context.Context) ([]entity.Topic, error) {
+	query := `
+		SELECT id, name, description, slug, parent_id, created_at, updated_at, version
+		FROM topics
+		WHERE deleted_at IS NULL
+		ORDER BY name, id`
+
+	var topics []entity.Topic
+	err := conn(ctx, r.db).SelectContext(ctx, &topics, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// GetAncestorIDs walks up the parents of id and returns them together with id
+// itself. UNION drops rows already seen, so even a cyclic chain terminates.
+func (r topicRepository) GetAncestorIDs(ctx context.Context, id int) ([]int, error) {
+	query := `
+		WITH RECURSIVE ancestors(id, parent_id) AS (
+			SELECT id, parent_id FROM topics WHERE id = $1
+			UNION
+			SELECT t.id, t.parent_id FROM topics t
+			INNER JOIN ancestors a ON t.id = a.parent_id
+		)
+		SELECT id FROM ancestors`
+
+	var ids []int
+	err := conn(ctx, r.db).SelectContext(ctx, &ids, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (r topicRepository) UpdateTopicFileds(ctx context.Context, topic *entity.Topic, updateFields []string) error {
 	query := "UPDATE topics SET "
 	setClauses := make([]string, 0, len(updateFields)+1)
@@ -85,21 +249,183 @@ func (r topicRepository) UpdateTopicFileds(ctx context.Context, topic *entity.To
 		case "slug":
 			setClauses = append(setClauses, fmt.Sprintf("slug = $%d", i+1))
 			args = append(args, topic.Slug)
+		case "parent_id":
+			setClauses = append(setClauses, fmt.Sprintf("parent_id = $%d", i+1))
+			args = append(args, topic.ParentID)
 		}
 	}
 
-	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", len(updateFields)+1))
+	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", len(updateFields)+1), "version = version + 1")
 	args = append(args, time.Now())
 
-	query += strings.Join(setClauses, ", ") + " WHERE id = $" + fmt.Sprintf("%d", len(updateFields)+2)
-	args = append(args, topic.ID)
+	// only the version the caller read may be overwritten
+	query += strings.Join(setClauses, ", ") + fmt.Sprintf(" WHERE id = $%d AND version = $%d", len(updateFields)+2, len(updateFields)+3)
+	args = append(args, topic.ID, topic.Version)
+
+	var err error
+	if slices.Contains(updateFields, "slug") {
+		// keep the replaced slug so links using it keep working
+		err = topicSlugs.update(ctx, r.db, topic.ID, topic.Slug, query, args)
+	} else {
+		err = execVersioned(ctx, conn(ctx, r.db), query, args)
+	}
+	if err != nil {
+		return err
+	}
+
+	topic.Version++
+	return nil
+}
+
+func (r topicRepository) GetByRetiredSlug(ctx context.Context, slug string) (entity.RetiredSlug, error) {
+	return topicSlugs.getRetired(ctx, conn(ctx, r.db), slug)
+}
+
+func (r topicRepository) GetTakenSlugs(ctx context.Context, base string) ([]string, error) {
+	return topicSlugs.taken(ctx, conn(ctx, r.db), base)
+}
+
+// Delete soft-deletes topic id when it is still at version. It returns
+// ErrStaleVersion when the topic changed since it was read
+func (r topicRepository) Delete(ctx context.Context, id int, version int) error {
+	query := `UPDATE topics SET deleted_at = NOW(), updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND version = $2 AND deleted_at IS NULL`
+	return execVersioned(ctx, conn(ctx, r.db), query, []interface{}{id, version})
+}
+
+// Merge retires source into the target topic: subtopics of source move below
+// target, the current and retired slugs of source become aliases of target and
+// source is soft-deleted. It returns ErrStaleVersion when source changed since
+// it was read.
+func (r topicRepository) Merge(ctx context.Context, source entity.Topic, targetID int) error {
+	return runInTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		deleteQuery := `UPDATE topics SET deleted_at = NOW(), updated_at = NOW(), version = version + 1
+			WHERE id = $1 AND version = $2 AND deleted_at IS NULL`
+		if err := execVersioned(ctx, tx, deleteQuery, []interface{}{source.ID, source.Version}); err != nil {
+			return err
+		}
+
+		reparentQuery := `UPDATE topics SET parent_id = $2, updated_at = NOW(), version = version + 1 WHERE parent_id = $1`
+		if _, err := tx.ExecContext(ctx, reparentQuery, source.ID, targetID); err != nil {
+			return err
+		}
+
+		moveSlugsQuery := `UPDATE topic_slugs SET topic_id = $2 WHERE topic_id = $1`
+		if _, err := tx.ExecContext(ctx, moveSlugsQuery, source.ID, targetID); err != nil {
+			return err
+		}
+
+		aliasQuery := `INSERT INTO topic_slugs (topic_id, slug) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET topic_id = EXCLUDED.topic_id`
+		_, err := tx.ExecContext(ctx, aliasQuery, targetID, source.Slug)
+		return err
+	})
+}
+
+// GetTrashed lists soft-deleted topics, most recently deleted first
+func (r topicRepository) GetTrashed(ctx context.Context, pagination dto.Pagination) ([]entity.Topic, error) {
+	query := `
+	SELECT id, name, description, slug, parent_id, created_at, updated_at, deleted_at, version
+	FROM topics
+	WHERE deleted_at IS NOT NULL
+	ORDER BY deleted_at DESC, id DESC
+	LIMIT $1 OFFSET $2`
+
+	topics := []entity.Topic{}
+	err := conn(ctx, r.db).SelectContext(ctx, &topics, query, pagination.Limit, pagination.Offset())
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+func (r topicRepository) CountTrashed(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM topics WHERE deleted_at IS NOT NULL`
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query)
+	if err != nil {
+		return 0, err
+	}
 
-	_, err := r.db.ExecContext(ctx, query, args...)
-	return err
+	return total, nil
 }
 
-func (r topicRepository) Delete(ctx context.Context, id int) error {
-	query := `UPDATE topics SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+// Restore brings back a soft-deleted topic. A plain delete leaves its article
+// links untouched so they come back with it, links detached by a forced delete
+// stay detached. It reports false when no trashed topic has the id and returns
+// ErrTopicMerged for a topic merged into another one, as its slug resolves to
+// the merge target.
+func (r topicRepository) Restore(ctx context.Context, id int) (bool, error) {
+	db := conn(ctx, r.db)
+
+	mergedQuery := `
+	SELECT EXISTS (
+		SELECT 1 FROM topics t
+		INNER JOIN topic_slugs s ON s.slug = t.slug AND s.topic_id <> t.id
+		WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+	)`
+	var merged bool
+	if err := db.GetContext(ctx, &merged, mergedQuery, id); err != nil {
+		return false, err
+	}
+	if merged {
+		return false, ErrTopicMerged
+	}
+
+	query := `UPDATE topics SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// Purge removes a trashed topic for good, its article links and slug history
+// go with it. It reports false when no trashed topic has the id.
+func (r topicRepository) Purge(ctx context.Context, id int) (bool, error) {
+	query := `DELETE FROM topics WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// PurgeTrashed removes up to limit topics trashed before the given time and
+// returns their ids, rows locked by another replica are left for its run
+func (r topicRepository) PurgeTrashed(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	query := `
+	DELETE FROM topics
+	WHERE id IN (
+		SELECT id FROM topics
+		WHERE deleted_at < $1
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id`
+
+	ids := []int{}
+	err := conn(ctx, r.db).SelectContext(ctx, &ids, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
 }
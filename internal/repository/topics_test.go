@@ -3,13 +3,16 @@ package repository_test
 import (
 	"context"
 	"errors"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
 	"newsapi/internal/repository"
 	"newsapi/internal/utils"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,7 +23,7 @@ func Test_CreateTopics(t *testing.T) {
 	repos := repository.NewTopicRepository(sqlxDB)
 	ctx := context.Background()
 
-	query := `INSERT INTO topics \(name, description, slug\) VALUES \(\?, \?, \?\) RETURNING id`
+	query := `INSERT INTO topics \(name, description, slug, parent_id\) VALUES \(\?, \?, \?, \?\) RETURNING id`
 	tests := []struct {
 		testname  string
 		entity    entity.Topic
@@ -36,7 +39,7 @@ func Test_CreateTopics(t *testing.T) {
 			},
 			initMock: func(t entity.Topic) {
 				mockSql.ExpectPrepare(query).ExpectQuery().
-					WithArgs(t.Name, t.Description, t.Slug).
+					WithArgs(t.Name, t.Description, t.Slug, t.ParentID).
 					WillReturnError(errors.New("failed insert"))
 			},
 			assertion: func(err error) {
@@ -49,10 +52,11 @@ func Test_CreateTopics(t *testing.T) {
 				Name:        "test",
 				Description: utils.StringPtr("test description"),
 				Slug:        "slug-example",
+				ParentID:    utils.IntPtr(2),
 			},
 			initMock: func(t entity.Topic) {
 				mockSql.ExpectPrepare(query).ExpectQuery().
-					WithArgs(t.Name, t.Description, t.Slug).
+					WithArgs(t.Name, t.Description, t.Slug, t.ParentID).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			},
 			assertion: func(err error) {
@@ -74,8 +78,8 @@ func Test_GetAllTopics(t *testing.T) {
 	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
 	defer mockDb.Close()
 
-	query := `SELECT id, name, description, slug, created_at, updated_at, deleted_at 
-			FROM topics 
+	query := `SELECT id, name, description, slug, parent_id, created_at, updated_at, deleted_at, version
+			FROM topics
 			WHERE deleted_at IS NULL`
 	now := time.Now()
 	testTime := now.Truncate(time.Second)
@@ -83,14 +87,15 @@ func Test_GetAllTopics(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
-		testname  string
-		initMock  func()
-		assertion func(t []entity.Topic, err error)
+		testname   string
+		pagination dto.Pagination
+		initMock   func()
+		assertion  func(t []entity.Topic, err error)
 	}{
 		{
 			testname: "get topics then return error",
 			initMock: func() {
-				mockSql.ExpectQuery(query).WillReturnError(errors.New("database error"))
+				mockSql.ExpectQuery(query).WithArgs(20, 0).WillReturnError(errors.New("database error"))
 			},
 			assertion: func(tp []entity.Topic, err error) {
 				assert.Error(t, err)
@@ -99,28 +104,91 @@ func Test_GetAllTopics(t *testing.T) {
 		{
 			testname: "get topics then return valid topic",
 			initMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "slug", "created_at", "updated_at", "deleted_at"}).
-					AddRow(1, "Topic 1", "Name 1", "Description 1", testTime, testTime, nil).
-					AddRow(2, "Topic 2", "Name 2", "Description 2", testTime.Add(5*time.Minute), testTime.Add(5*time.Minute), nil)
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "slug", "created_at", "updated_at", "deleted_at", "version"}).
+					AddRow(1, "Topic 1", "Name 1", "Description 1", testTime, testTime, nil, 1).
+					AddRow(2, "Topic 2", "Name 2", "Description 2", testTime.Add(5*time.Minute), testTime.Add(5*time.Minute), nil, 4)
 
-				mockSql.ExpectQuery(query).WillReturnRows(rows)
+				mockSql.ExpectQuery(query+` ORDER BY id LIMIT \$1 OFFSET \$2`).WithArgs(20, 0).WillReturnRows(rows)
 			},
 			assertion: func(tp []entity.Topic, err error) {
 				assert.Equal(t, 2, len(tp))
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testname:   "get topics after cursor",
+			pagination: dto.NewCursorPagination(dto.Cursor{ID: 2}, 10),
+			initMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "slug", "created_at", "updated_at", "deleted_at", "version"}).
+					AddRow(3, "Topic 3", "Name 3", "Description 3", testTime, testTime, nil, 1)
+
+				mockSql.ExpectQuery(query+` AND id > \$1 ORDER BY id LIMIT \$2 OFFSET \$3`).
+					WithArgs(2, 10, 0).
+					WillReturnRows(rows)
+			},
+			assertion: func(tp []entity.Topic, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, tp[0].ID)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			res, err := repos.GetAll(ctx)
+			pagination := tt.pagination
+			if pagination.Limit == 0 {
+				pagination = dto.NewPagination(1, 20)
+			}
+			res, err := repos.GetAll(ctx, pagination)
 			tt.assertion(res, err)
 		})
 	}
 }
 
+func Test_CountTopics(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `SELECT COUNT\(\*\) FROM topics WHERE deleted_at IS NULL`
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(total int, err error)
+	}{
+		{
+			testname: "count topics then return total",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			},
+			assertion: func(total int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, total)
+			},
+		},
+		{
+			testname: "count topics then return error",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WillReturnError(errors.New("database error"))
+			},
+			assertion: func(total int, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			total, err := repos.Count(ctx)
+			tt.assertion(total, err)
+		})
+	}
+}
+
 func Test_UpdateTopics(t *testing.T) {
 	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
 	defer mockDb.Close()
@@ -143,9 +211,9 @@ func Test_UpdateTopics(t *testing.T) {
 			},
 			updateFields: []string{"name"},
 			initMock: func(tp *entity.Topic) {
-				expectedQuery := "UPDATE topics SET name = \\$1, updated_at = \\$2 WHERE id = \\$3"
+				expectedQuery := "UPDATE topics SET name = \\$1, updated_at = \\$2, version = version \\+ 1 WHERE id = \\$3 AND version = \\$4"
 				mockSql.ExpectExec(expectedQuery).
-					WithArgs(tp.Name, sqlmock.AnyArg(), tp.ID).
+					WithArgs(tp.Name, sqlmock.AnyArg(), tp.ID, tp.Version).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			assertion: func(err error) {
@@ -162,9 +230,9 @@ func Test_UpdateTopics(t *testing.T) {
 			},
 			updateFields: []string{"name", "description"},
 			initMock: func(tp *entity.Topic) {
-				expectedQuery := "UPDATE topics SET name = \\$1, description = \\$2, updated_at = \\$3 WHERE id = \\$4"
+				expectedQuery := "UPDATE topics SET name = \\$1, description = \\$2, updated_at = \\$3, version = version \\+ 1 WHERE id = \\$4 AND version = \\$5"
 				mockSql.ExpectExec(expectedQuery).
-					WithArgs(tp.Name, tp.Description, sqlmock.AnyArg(), tp.ID).
+					WithArgs(tp.Name, tp.Description, sqlmock.AnyArg(), tp.ID, tp.Version).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			assertion: func(err error) {
@@ -182,10 +250,21 @@ func Test_UpdateTopics(t *testing.T) {
 			},
 			updateFields: []string{"name", "description", "slug"},
 			initMock: func(tp *entity.Topic) {
-				expectedQuery := "UPDATE topics SET name = \\$1, description = \\$2, slug = \\$3, updated_at = \\$4 WHERE id = \\$5"
+				expectedQuery := "UPDATE topics SET name = \\$1, description = \\$2, slug = \\$3, updated_at = \\$4, version = version \\+ 1 WHERE id = \\$5 AND version = \\$6"
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery("SELECT slug FROM topics WHERE id = \\$1 FOR UPDATE").
+					WithArgs(tp.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("old-slug"))
 				mockSql.ExpectExec(expectedQuery).
-					WithArgs(tp.Name, tp.Description, tp.Slug, sqlmock.AnyArg(), tp.ID).
+					WithArgs(tp.Name, tp.Description, tp.Slug, sqlmock.AnyArg(), tp.ID, tp.Version).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockSql.ExpectExec("DELETE FROM topic_slugs WHERE topic_id = \\$1 AND slug = \\$2").
+					WithArgs(tp.ID, tp.Slug).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mockSql.ExpectExec("INSERT INTO topic_slugs \\(topic_id, slug\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT \\(slug\\) DO NOTHING").
+					WithArgs(tp.ID, "old-slug").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockSql.ExpectCommit()
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -210,7 +289,7 @@ func Test_Delete(t *testing.T) {
 	repos := repository.NewTopicRepository(sqlxDB)
 	ctx := context.Background()
 
-	query := `UPDATE topics SET deleted_at = NOW\(\) WHERE id = \$1 AND deleted_at IS NULL`
+	query := `UPDATE topics SET deleted_at = NOW\(\), updated_at = NOW\(\), version = version \+ 1 WHERE id = \$1 AND version = \$2 AND deleted_at IS NULL`
 
 	tests := []struct {
 		testname  string
@@ -223,19 +302,31 @@ func Test_Delete(t *testing.T) {
 			id:       1,
 			initMock: func(id int) {
 				mockSql.ExpectExec(query).
-					WithArgs(id).
+					WithArgs(id, 3).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testname: "topic changed since it was read",
+			id:       2,
+			initMock: func(id int) {
+				mockSql.ExpectExec(query).
+					WithArgs(id, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, repository.ErrStaleVersion, err)
+			},
+		},
 		{
 			testname: "delete topic fails",
 			id:       4,
 			initMock: func(id int) {
 				mockSql.ExpectExec(query).
-					WithArgs(id).
+					WithArgs(id, 3).
 					WillReturnError(errors.New("db error"))
 			},
 			assertion: func(err error) {
@@ -247,8 +338,245 @@ func Test_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock(tt.id)
-			err := repos.Delete(ctx, tt.id)
+			err := repos.Delete(ctx, tt.id, 3)
 			tt.assertion(err)
 		})
 	}
 }
+
+func Test_RestoreAndPurgeTopic(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	restoreQuery := regexp.QuoteMeta(`UPDATE topics SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL`)
+	mergedQuery := regexp.QuoteMeta(`
+	SELECT EXISTS (
+		SELECT 1 FROM topics t
+		INNER JOIN topic_slugs s ON s.slug = t.slug AND s.topic_id <> t.id
+		WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+	)`)
+	purgeQuery := regexp.QuoteMeta(`DELETE FROM topics WHERE id = $1 AND deleted_at IS NOT NULL`)
+
+	mockSql.ExpectQuery(mergedQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mockSql.ExpectExec(restoreQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	restored, err := repos.Restore(ctx, 2)
+	assert.NoError(t, err)
+	assert.True(t, restored)
+
+	mockSql.ExpectQuery(mergedQuery).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mockSql.ExpectExec(restoreQuery).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+	restored, err = repos.Restore(ctx, 3)
+	assert.NoError(t, err)
+	assert.False(t, restored)
+
+	mockSql.ExpectQuery(mergedQuery).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	restored, err = repos.Restore(ctx, 4)
+	assert.Equal(t, repository.ErrTopicMerged, err)
+	assert.False(t, restored)
+
+	mockSql.ExpectExec(purgeQuery).WithArgs(2).WillReturnError(errors.New("db error"))
+	purged, err := repos.Purge(ctx, 2)
+	assert.Error(t, err)
+	assert.False(t, purged)
+
+	mockSql.ExpectExec(purgeQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	purged, err = repos.Purge(ctx, 2)
+	assert.NoError(t, err)
+	assert.True(t, purged)
+
+	assert.NoError(t, mockSql.ExpectationsWereMet())
+}
+
+func Test_GetTopicHierarchy(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	t.Run("returns live topics with their parents", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT id, name, description, slug, parent_id, created_at, updated_at, version
+			FROM topics
+			WHERE deleted_at IS NULL
+			ORDER BY name, id`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "parent_id"}).
+				AddRow(2, "Football", "football", 1).
+				AddRow(1, "Sports", "sports", nil))
+
+		topics, err := repos.GetHierarchy(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, topics, 2)
+		assert.Equal(t, utils.IntPtr(1), topics[0].ParentID)
+		assert.Nil(t, topics[1].ParentID)
+	})
+
+	t.Run("walks up the ancestors of a topic", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			WITH RECURSIVE ancestors(id, parent_id) AS (
+				SELECT id, parent_id FROM topics WHERE id = $1
+				UNION
+				SELECT t.id, t.parent_id FROM topics t
+				INNER JOIN ancestors a ON t.id = a.parent_id
+			)
+			SELECT id FROM ancestors`)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(2).AddRow(1))
+
+		ids, err := repos.GetAncestorIDs(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{3, 2, 1}, ids)
+	})
+
+	t.Run("returns error on query failure", func(t *testing.T) {
+		mockSql.ExpectQuery("WITH RECURSIVE ancestors").WithArgs(3).WillReturnError(errors.New("db error"))
+
+		_, err := repos.GetAncestorIDs(ctx, 3)
+		assert.Error(t, err)
+	})
+}
+
+func Test_GetTopicDetail(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	t.Run("finds a live topic by slug", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT id, name, description, slug, parent_id, created_at, updated_at, version
+			FROM topics
+			WHERE slug = $1 AND deleted_at IS NULL`)).
+			WithArgs("sports").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "version"}).AddRow(3, "Sports", "sports", 2))
+
+		topic, err := repos.GetBySlug(ctx, "sports")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, topic.ID)
+		assert.Equal(t, 2, topic.Version)
+	})
+
+	t.Run("counts published articles per topic", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+			SELECT nt.topic_id, COUNT(*) AS article_count
+			FROM news_topics nt
+			INNER JOIN news_articles a ON a.id = nt.news_article_id
+			WHERE nt.topic_id = ANY($1::int[]) AND nt.deleted_at IS NULL
+				AND a.status = 'published' AND a.deleted_at IS NULL
+			GROUP BY nt.topic_id`)).
+			WithArgs(pq.Int32Array{1, 2}).
+			WillReturnRows(sqlmock.NewRows([]string{"topic_id", "article_count"}).AddRow(2, 4))
+
+		counts, err := repos.CountPublishedArticles(ctx, []int{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TopicArticleCount{{TopicID: 2, Count: 4}}, counts)
+	})
+
+	t.Run("lists the latest published articles of a topic", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`WHERE a.status = 'published' AND a.deleted_at IS NULL
+				AND EXISTS (
+					SELECT 1 FROM news_topics ft
+					WHERE ft.news_article_id = a.id AND ft.topic_id = $1 AND ft.deleted_at IS NULL
+				)
+			GROUP BY a.id
+			ORDER BY a.published_at DESC, a.id DESC
+			LIMIT $2`)).
+			WithArgs(3, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "summary", "slug", "published_at", "topics"}).
+				AddRow(9, "Final", nil, "final", time.Now(), "{Football,Sports}"))
+
+		articles, err := repos.GetLatestPublished(ctx, 3, 5)
+		assert.NoError(t, err)
+		assert.Len(t, articles, 1)
+		assert.Equal(t, []string{"Football", "Sports"}, []string(articles[0].Topics))
+	})
+
+	t.Run("returns error on query failure", func(t *testing.T) {
+		mockSql.ExpectQuery("SELECT nt.topic_id").WillReturnError(errors.New("db error"))
+
+		_, err := repos.CountPublishedArticles(ctx, []int{1})
+		assert.Error(t, err)
+	})
+}
+
+func Test_ResolveTopicsForImport(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+
+	t.Run("resolves current and retired slugs", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`
+		SELECT slug, id AS topic_id FROM topics
+		WHERE slug = ANY($1::text[]) AND deleted_at IS NULL
+		UNION
+		SELECT ts.slug, ts.topic_id FROM topic_slugs ts
+		JOIN topics t ON t.id = ts.topic_id AND t.deleted_at IS NULL
+		WHERE ts.slug = ANY($1::text[])`)).
+			WithArgs(pq.StringArray{"sports", "tech", "unknown"}).
+			WillReturnRows(sqlmock.NewRows([]string{"slug", "topic_id"}).AddRow("sports", 3).AddRow("tech", 5))
+
+		refs, err := repos.GetIDsBySlugs(ctx, []string{"sports", "tech", "unknown"})
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TopicSlugRef{{Slug: "sports", TopicID: 3}, {Slug: "tech", TopicID: 5}}, refs)
+	})
+
+	t.Run("keeps the ids of live topics", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM topics WHERE id = ANY($1::int[]) AND deleted_at IS NULL`)).
+			WithArgs(pq.Int32Array{1, 2}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+		ids, err := repos.GetLiveIDs(ctx, []int{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2}, ids)
+	})
+}
+
+func Test_MergeTopic(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewTopicRepository(sqlxDB)
+	ctx := context.Background()
+	source := entity.Topic{ID: 2, Slug: "tech", Version: 3}
+
+	t.Run("retires the source into the target", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectExec(regexp.QuoteMeta(`UPDATE topics SET deleted_at = NOW(), updated_at = NOW(), version = version + 1
+			WHERE id = $1 AND version = $2 AND deleted_at IS NULL`)).
+			WithArgs(2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSql.ExpectExec(regexp.QuoteMeta(`UPDATE topics SET parent_id = $2, updated_at = NOW(), version = version + 1 WHERE parent_id = $1`)).
+			WithArgs(2, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSql.ExpectExec(regexp.QuoteMeta(`UPDATE topic_slugs SET topic_id = $2 WHERE topic_id = $1`)).
+			WithArgs(2, 5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockSql.ExpectExec(regexp.QuoteMeta(`INSERT INTO topic_slugs (topic_id, slug) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET topic_id = EXCLUDED.topic_id`)).
+			WithArgs(5, "tech").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockSql.ExpectCommit()
+
+		err := repos.Merge(ctx, source, 5)
+		assert.NoError(t, err)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+
+	t.Run("source changed since it was read", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectExec("UPDATE topics SET deleted_at").
+			WithArgs(2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockSql.ExpectRollback()
+
+		err := repos.Merge(ctx, source, 5)
+		assert.ErrorIs(t, err, repository.ErrStaleVersion)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+}
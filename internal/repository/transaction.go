@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrStaleVersion is returned by versioned writes when the row changed since
+	// the caller read it
+	ErrStaleVersion = errors.New("row version is stale")
+	// ErrUnknownTopic is returned when a topic to link does not exist or is deleted
+	ErrUnknownTopic = errors.New("one or more topic IDs are invalid")
+	// ErrTopicMerged is returned when restoring a topic that was merged into
+	// another one, its slug is an alias of the merge target
+	ErrTopicMerged = errors.New("topic was merged into another topic")
+)
+
+// txKey is the context key holding the transaction a usecase operation runs in
+type txKey struct{}
+
+// dbtx is the part of sqlx shared by *sqlx.DB and *sqlx.Tx that repositories use
+type dbtx interface {
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row
+	PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error)
+}
+
+// conn returns the transaction carried by ctx, or db when the call is not part
+// of a unit of work
+func conn(ctx context.Context, db *sqlx.DB) dbtx {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// int32Array binds ids as a Postgres int array
+func int32Array(ids []int) pq.Int32Array {
+	arr := make(pq.Int32Array, 0, len(ids))
+	for _, id := range ids {
+		arr = append(arr, int32(id))
+	}
+	return arr
+}
+
+// runInTx runs fn on the transaction carried by ctx, or on a new one that is
+// committed when fn succeeds and rolled back otherwise
+func runInTx(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) Transactor {
+	return transactor{db: db}
+}
+
+// WithinTransaction runs fn in a single transaction, every repository called
+// with the context handed to fn takes part in it. Nested calls join the
+// outer transaction.
+func (t transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTx(ctx, t.db, func(tx *sqlx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// execVersioned runs an update guarded by a version condition and reports
+// ErrStaleVersion when it matched no row
+func execVersioned(ctx context.Context, db dbtx, query string, args []interface{}) error {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleVersion
+	}
+
+	return nil
+}
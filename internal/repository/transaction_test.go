@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/repository"
+	"newsapi/internal/utils"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithinTransaction(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	transactor := repository.NewTransactor(sqlxDB)
+	newsRepo := repository.NewNewsArticlesRepository(sqlxDB)
+	newsTopicsRepo := repository.NewNewsTopicsRepository(sqlxDB)
+	ctx := context.Background()
+
+	deleteNews := regexp.QuoteMeta(`UPDATE news_articles SET deleted_at = NOW(), updated_at = NOW(), version = version + 1 WHERE slug = $1 AND version = $2 AND deleted_at IS NULL`)
+	deleteLinks := regexp.QuoteMeta(`UPDATE news_topics SET deleted_at = NOW() WHERE news_article_id = $1 AND deleted_at IS NULL`)
+	insertLinks := regexp.QuoteMeta(`INSERT INTO news_topics (news_article_id, topic_id) VALUES ($1, $2)`)
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		run       func(ctx context.Context) error
+		assertion func(err error)
+	}{
+		{
+			testname: "repositories share one committed transaction",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectExec(deleteNews).WithArgs("slug-3", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockSql.ExpectExec(deleteLinks).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mockSql.ExpectCommit()
+			},
+			run: func(ctx context.Context) error {
+				if err := newsRepo.DeleteBySlug(ctx, "slug-3", 1); err != nil {
+					return err
+				}
+				return newsTopicsRepo.DeleteByArticleID(ctx, 3)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "repository transactions join the outer one",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectQuery(liveTopics).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockSql.ExpectExec(insertLinks).WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockSql.ExpectCommit()
+			},
+			run: func(ctx context.Context) error {
+				return newsTopicsRepo.Create(ctx, 3, []int{1})
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "failed step rolls back every change",
+			initMock: func() {
+				mockSql.ExpectBegin()
+				mockSql.ExpectExec(deleteNews).WithArgs("slug-3", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockSql.ExpectExec(deleteLinks).WithArgs(3).WillReturnError(errors.New("db error"))
+				mockSql.ExpectRollback()
+			},
+			run: func(ctx context.Context) error {
+				if err := newsRepo.DeleteBySlug(ctx, "slug-3", 1); err != nil {
+					return err
+				}
+				return newsTopicsRepo.DeleteByArticleID(ctx, 3)
+			},
+			assertion: func(err error) {
+				assert.EqualError(t, err, "db error")
+			},
+		},
+		{
+			testname: "failed begin never runs the operation",
+			initMock: func() {
+				mockSql.ExpectBegin().WillReturnError(errors.New("db error"))
+			},
+			run: func(ctx context.Context) error {
+				assert.Fail(t, "operation ran without a transaction")
+				return nil
+			},
+			assertion: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := transactor.WithinTransaction(ctx, tt.run)
+			tt.assertion(err)
+			assert.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -16,9 +20,9 @@ func NewUsersRepository(db *sqlx.DB) UsersRepository {
 }
 
 func (r usersRepository) Create(ctx context.Context, entity *entity.User) error {
-	query := `INSERT INTO users (name, email) VALUES (:name, :email) RETURNING id`
+	query := `INSERT INTO users (name, email, password) VALUES (:name, :email, :password) RETURNING id`
 
-	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	stmt, err := conn(ctx, r.db).PrepareNamedContext(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -32,3 +36,97 @@ func (r usersRepository) Create(ctx context.Context, entity *entity.User) error
 
 	return nil
 }
+
+func (r usersRepository) GetByEmail(ctx context.Context, email string) (entity.User, error) {
+	query := `SELECT id, name, email, password, role, created_at, updated_at
+		FROM users
+		WHERE email = $1 AND deleted_at IS NULL`
+
+	var user entity.User
+	err := conn(ctx, r.db).GetContext(ctx, &user, query, email)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+func (r usersRepository) GetByID(ctx context.Context, id int) (entity.User, error) {
+	query := `SELECT id, name, email, password, role, created_at, updated_at
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	var user entity.User
+	err := conn(ctx, r.db).GetContext(ctx, &user, query, id)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+func (r usersRepository) UpdateRole(ctx context.Context, id int, role entity.UserRole) error {
+	query := `UPDATE users SET role = $1 WHERE id = $2`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, role, id)
+	return err
+}
+
+func (r usersRepository) GetAll(ctx context.Context, pagination dto.Pagination) ([]entity.User, error) {
+	query := `SELECT id, name, email, role, created_at, updated_at
+		FROM users
+		WHERE deleted_at IS NULL
+		ORDER BY id
+		LIMIT $1 OFFSET $2`
+
+	users := []entity.User{}
+	err := conn(ctx, r.db).SelectContext(ctx, &users, query, pagination.Limit, pagination.Offset())
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r usersRepository) Count(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE deleted_at IS NULL`
+
+	var total int
+	err := conn(ctx, r.db).GetContext(ctx, &total, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r usersRepository) UpdateUserFields(ctx context.Context, user *entity.User, updateFields []string) error {
+	query := "UPDATE users SET "
+	setClauses := make([]string, 0, len(updateFields)+1)
+	args := make([]interface{}, 0, len(updateFields)+2)
+
+	for i, field := range updateFields {
+		switch field {
+		case "name":
+			setClauses = append(setClauses, fmt.Sprintf("name = $%d", i+1))
+			args = append(args, user.Name)
+		case "email":
+			setClauses = append(setClauses, fmt.Sprintf("email = $%d", i+1))
+			args = append(args, user.Email)
+		}
+	}
+
+	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", len(updateFields)+1))
+	args = append(args, time.Now())
+
+	query += strings.Join(setClauses, ", ") + fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL", len(updateFields)+2)
+	args = append(args, user.ID)
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r usersRepository) Deactivate(ctx context.Context, id int) error {
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
@@ -2,11 +2,14 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
 	"newsapi/internal/repository"
 	"newsapi/internal/utils"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +22,7 @@ func Test_CreateUser(t *testing.T) {
 	repos := repository.NewUsersRepository(sqlxDB)
 	ctx := context.Background()
 
-	query := `INSERT INTO users \(name, email\) VALUES \(\?, \?\) RETURNING id`
+	query := `INSERT INTO users \(name, email, password\) VALUES \(\?, \?, \?\) RETURNING id`
 	tests := []struct {
 		testname  string
 		entity    entity.User
@@ -29,12 +32,13 @@ func Test_CreateUser(t *testing.T) {
 		{
 			testname: "insert user then return error",
 			entity: entity.User{
-				Name:  "test",
-				Email: "john@example.com",
+				Name:     "test",
+				Email:    "john@example.com",
+				Password: utils.StringPtr("hashed-password"),
 			},
 			initMock: func(t entity.User) {
 				mockSql.ExpectPrepare(query).ExpectQuery().
-					WithArgs(t.Name, t.Email).
+					WithArgs(t.Name, t.Email, t.Password).
 					WillReturnError(errors.New("failed insert"))
 			},
 			assertion: func(err error) {
@@ -44,12 +48,13 @@ func Test_CreateUser(t *testing.T) {
 		{
 			testname: "insert user then return error nil",
 			entity: entity.User{
-				Name:  "test",
-				Email: "john@example.com",
+				Name:     "test",
+				Email:    "john@example.com",
+				Password: utils.StringPtr("hashed-password"),
 			},
 			initMock: func(t entity.User) {
 				mockSql.ExpectPrepare(query).ExpectQuery().
-					WithArgs(t.Name, t.Email).
+					WithArgs(t.Name, t.Email, t.Password).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			},
 			assertion: func(err error) {
@@ -66,3 +71,338 @@ func Test_CreateUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetUserByEmail(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `SELECT id, name, email, password, role, created_at, updated_at
+		FROM users
+		WHERE email = \$1 AND deleted_at IS NULL`
+	tests := []struct {
+		testname  string
+		email     string
+		initMock  func(email string)
+		assertion func(user entity.User, err error)
+	}{
+		{
+			testname: "get user by email successfully",
+			email:    "john@example.com",
+			initMock: func(email string) {
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "created_at", "updated_at"}).
+					AddRow(1, "John", email, "hashed", "author", time.Now(), time.Now())
+				mockSql.ExpectQuery(query).WithArgs(email).WillReturnRows(rows)
+			},
+			assertion: func(user entity.User, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, user.ID)
+				assert.Equal(t, "hashed", *user.Password)
+			},
+		},
+		{
+			testname: "get user by email returns error",
+			email:    "missing@example.com",
+			initMock: func(email string) {
+				mockSql.ExpectQuery(query).WithArgs(email).WillReturnError(sql.ErrNoRows)
+			},
+			assertion: func(user entity.User, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock(tt.email)
+			user, err := repos.GetByEmail(ctx, tt.email)
+			tt.assertion(user, err)
+		})
+	}
+}
+
+func Test_GetUserByID(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `SELECT id, name, email, password, role, created_at, updated_at
+		FROM users
+		WHERE id = \$1 AND deleted_at IS NULL`
+	tests := []struct {
+		testname  string
+		id        int
+		initMock  func(id int)
+		assertion func(user entity.User, err error)
+	}{
+		{
+			testname: "get user by id successfully",
+			id:       1,
+			initMock: func(id int) {
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "created_at", "updated_at"}).
+					AddRow(id, "John", "john@example.com", nil, "reader", time.Now(), time.Now())
+				mockSql.ExpectQuery(query).WithArgs(id).WillReturnRows(rows)
+			},
+			assertion: func(user entity.User, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, user.ID)
+				assert.Nil(t, user.Password)
+			},
+		},
+		{
+			testname: "get user by id returns error",
+			id:       2,
+			initMock: func(id int) {
+				mockSql.ExpectQuery(query).WithArgs(id).WillReturnError(sql.ErrNoRows)
+			},
+			assertion: func(user entity.User, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock(tt.id)
+			user, err := repos.GetByID(ctx, tt.id)
+			tt.assertion(user, err)
+		})
+	}
+}
+
+func Test_UpdateUserRole(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `UPDATE users SET role = \$1 WHERE id = \$2`
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "update role successfully",
+			initMock: func() {
+				mockSql.ExpectExec(query).WithArgs(entity.RoleEditor, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "update role returns error",
+			initMock: func() {
+				mockSql.ExpectExec(query).WithArgs(entity.RoleEditor, 1).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := repos.UpdateRole(ctx, 1, entity.RoleEditor)
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_GetAllUsers(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `SELECT id, name, email, role, created_at, updated_at
+		FROM users
+		WHERE deleted_at IS NULL
+		ORDER BY id
+		LIMIT \$1 OFFSET \$2`
+	pagination := dto.NewPagination(2, 10)
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(users []entity.User, err error)
+	}{
+		{
+			testname: "get users successfully",
+			initMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "created_at", "updated_at"}).
+					AddRow(11, "John", "john@example.com", "author", time.Now(), time.Now()).
+					AddRow(12, "Jane", "jane@example.com", "reader", time.Now(), time.Now())
+				mockSql.ExpectQuery(query).WithArgs(10, 10).WillReturnRows(rows)
+			},
+			assertion: func(users []entity.User, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, users, 2)
+				assert.Equal(t, entity.RoleAuthor, users[0].Role)
+			},
+		},
+		{
+			testname: "get users returns error",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WithArgs(10, 10).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(users []entity.User, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, users)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			users, err := repos.GetAll(ctx, pagination)
+			tt.assertion(users, err)
+		})
+	}
+}
+
+func Test_CountUsers(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL`
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(total int, err error)
+	}{
+		{
+			testname: "count users successfully",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+			},
+			assertion: func(total int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 42, total)
+			},
+		},
+		{
+			testname: "count users returns error",
+			initMock: func() {
+				mockSql.ExpectQuery(query).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(total int, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			total, err := repos.Count(ctx)
+			tt.assertion(total, err)
+		})
+	}
+}
+
+func Test_UpdateUserFields(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	user := entity.User{ID: 3, Name: "New Name", Email: "new@example.com"}
+
+	tests := []struct {
+		testname     string
+		updateFields []string
+		initMock     func()
+		assertion    func(err error)
+	}{
+		{
+			testname:     "update name and email successfully",
+			updateFields: []string{"name", "email"},
+			initMock: func() {
+				query := `UPDATE users SET name = \$1, email = \$2, updated_at = \$3 WHERE id = \$4 AND deleted_at IS NULL`
+				mockSql.ExpectExec(query).
+					WithArgs(user.Name, user.Email, sqlmock.AnyArg(), user.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname:     "update email returns error",
+			updateFields: []string{"email"},
+			initMock: func() {
+				query := `UPDATE users SET email = \$1, updated_at = \$2 WHERE id = \$3 AND deleted_at IS NULL`
+				mockSql.ExpectExec(query).
+					WithArgs(user.Email, sqlmock.AnyArg(), user.ID).
+					WillReturnError(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := repos.UpdateUserFields(ctx, &user, tt.updateFields)
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_DeactivateUser(t *testing.T) {
+	mockDb, sqlxDB, mockSql := utils.GenerateMockDb()
+	defer mockDb.Close()
+
+	repos := repository.NewUsersRepository(sqlxDB)
+	ctx := context.Background()
+
+	query := `UPDATE users SET deleted_at = NOW\(\) WHERE id = \$1 AND deleted_at IS NULL`
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "deactivate user successfully",
+			initMock: func() {
+				mockSql.ExpectExec(query).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "deactivate user returns error",
+			initMock: func() {
+				mockSql.ExpectExec(query).WithArgs(4).WillReturnError(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := repos.Deactivate(ctx, 4)
+			tt.assertion(err)
+		})
+	}
+}
@@ -9,17 +9,23 @@ import (
 )
 
 type AppRoutes struct {
-	echo    *echo.Echo
-	handler handler.HandlerRegistry
+	echo         *echo.Echo
+	handler      handler.HandlerRegistry
+	auth         echo.MiddlewareFunc
+	optionalAuth echo.MiddlewareFunc
 }
 
 func NewAppRoutes(
 	echo *echo.Echo,
 	handler handler.HandlerRegistry,
+	auth echo.MiddlewareFunc,
+	optionalAuth echo.MiddlewareFunc,
 ) AppRoutes {
 	return AppRoutes{
-		echo:    echo,
-		handler: handler,
+		echo:         echo,
+		handler:      handler,
+		auth:         auth,
+		optionalAuth: optionalAuth,
 	}
 }
 
@@ -28,21 +34,49 @@ func (r AppRoutes) RegisterRoute() {
 
 	r.registerRoute(http.MethodGet, "/metrics", echoprometheus.NewHandler())
 
+	auth := r.echo.Group("/api/v1/auth")
+	r.registerGroupRoute(auth, http.MethodPost, "/login", h.AuthHandler.Login)
+	r.registerGroupRoute(auth, http.MethodPost, "/refresh", h.AuthHandler.RefreshToken)
+
 	users := r.echo.Group("/api/v1/users")
 	r.registerGroupRoute(users, http.MethodPost, "", h.UsersHandler.CreateUser)
+	r.registerGroupRoute(users, http.MethodGet, "", h.UsersHandler.GetUsers, r.auth)
+	r.registerGroupRoute(users, http.MethodGet, "/:id", h.UsersHandler.GetUser, r.auth)
+	r.registerGroupRoute(users, http.MethodGet, "/:id/articles", h.NewsArticlesHandler.GetAuthorArticles)
+	r.registerGroupRoute(users, http.MethodPatch, "/:id", h.UsersHandler.UpdateUser, r.auth)
+	r.registerGroupRoute(users, http.MethodDelete, "/:id", h.UsersHandler.DeactivateUser, r.auth)
+	r.registerGroupRoute(users, http.MethodPatch, "/:id/role", h.UsersHandler.UpdateUserRole, r.auth)
 
 	topics := r.echo.Group("/api/v1/topics")
 	r.registerGroupRoute(topics, http.MethodGet, "", h.TopicsHandler.GetTopics)
-	r.registerGroupRoute(topics, http.MethodPost, "", h.TopicsHandler.CreateTopic)
-	r.registerGroupRoute(topics, http.MethodPatch, "/:id", h.TopicsHandler.UpdateTopic)
-	r.registerGroupRoute(topics, http.MethodDelete, "/:id", h.TopicsHandler.DeleteTopic)
+	r.registerGroupRoute(topics, http.MethodPost, "", h.TopicsHandler.CreateTopic, r.auth)
+	r.registerGroupRoute(topics, http.MethodGet, "/tree", h.TopicsHandler.GetTopicTree)
+	r.registerGroupRoute(topics, http.MethodGet, "/:id", h.TopicsHandler.GetTopic)
+	r.registerGroupRoute(topics, http.MethodPatch, "/:id", h.TopicsHandler.UpdateTopic, r.auth)
+	r.registerGroupRoute(topics, http.MethodDelete, "/:id", h.TopicsHandler.DeleteTopic, r.auth)
+	r.registerGroupRoute(topics, http.MethodPost, "/:id/merge", h.TopicsHandler.MergeTopic, r.auth)
 
 	newsArticle := r.echo.Group("/api/v1/news")
-	r.registerGroupRoute(newsArticle, http.MethodGet, "", h.NewsArticlesHandler.GetNewsArticles)
-	r.registerGroupRoute(newsArticle, http.MethodPost, "", h.NewsArticlesHandler.CreateNews)
-	r.registerGroupRoute(newsArticle, http.MethodGet, "/:slug", h.NewsArticlesHandler.GetNewsBySlug)
-	r.registerGroupRoute(newsArticle, http.MethodPatch, "/:slug", h.NewsArticlesHandler.UpdateNewsArticle)
-	r.registerGroupRoute(newsArticle, http.MethodDelete, "/:slug", h.NewsArticlesHandler.DeleteNewsArticle)
+	r.registerGroupRoute(newsArticle, http.MethodGet, "", h.NewsArticlesHandler.GetNewsArticles, r.optionalAuth)
+	r.registerGroupRoute(newsArticle, http.MethodPost, "", h.NewsArticlesHandler.CreateNews, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodGet, "/:slug", h.NewsArticlesHandler.GetNewsBySlug, r.optionalAuth)
+	r.registerGroupRoute(newsArticle, http.MethodPatch, "/:slug", h.NewsArticlesHandler.UpdateNewsArticle, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodPost, "/import", h.NewsArticlesHandler.ImportNews, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodGet, "/export", h.NewsArticlesHandler.ExportNews, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodPost, "/bulk", h.NewsArticlesHandler.BulkNews, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodPost, "/:slug/transition", h.NewsArticlesHandler.TransitionNewsArticle, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodDelete, "/:slug", h.NewsArticlesHandler.DeleteNewsArticle, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodGet, "/:slug/revisions", h.NewsArticlesHandler.GetNewsRevisions, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodGet, "/:slug/revisions/diff", h.NewsArticlesHandler.DiffNewsRevisions, r.auth)
+	r.registerGroupRoute(newsArticle, http.MethodPost, "/:slug/revisions/:revision/restore", h.NewsArticlesHandler.RestoreNewsRevision, r.auth)
+
+	trash := r.echo.Group("/api/v1/trash")
+	r.registerGroupRoute(trash, http.MethodGet, "/news", h.NewsArticlesHandler.GetTrashedNews, r.auth)
+	r.registerGroupRoute(trash, http.MethodPost, "/news/:slug/restore", h.NewsArticlesHandler.RestoreNewsArticle, r.auth)
+	r.registerGroupRoute(trash, http.MethodDelete, "/news/:slug", h.NewsArticlesHandler.PurgeNewsArticle, r.auth)
+	r.registerGroupRoute(trash, http.MethodGet, "/topics", h.TopicsHandler.GetTrashedTopics, r.auth)
+	r.registerGroupRoute(trash, http.MethodPost, "/topics/:id/restore", h.TopicsHandler.RestoreTopic, r.auth)
+	r.registerGroupRoute(trash, http.MethodDelete, "/topics/:id", h.TopicsHandler.PurgeTopic, r.auth)
 }
 
 func (r AppRoutes) registerGroupRoute(g *echo.Group, method string, path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"newsapi/internal/config/env"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
+	"newsapi/internal/utils"
+
+	"github.com/labstack/gommon/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authUsecase struct {
+	usersRepo repository.UsersRepository
+	config    env.AuthConfig
+}
+
+func NewAuthUsecase(
+	usersRepo repository.UsersRepository,
+	config env.AuthConfig,
+) AuthUsecase {
+	return authUsecase{
+		usersRepo: usersRepo,
+		config:    config,
+	}
+}
+
+func (u authUsecase) Login(ctx context.Context, body request.LoginRequest) (response.AuthToken, error) {
+	user, err := u.usersRepo.GetByEmail(ctx, body.Email)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.AuthToken{}, exception.ErrInvalidCredential
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return response.AuthToken{}, exception.ErrFailedGetUser
+	}
+
+	// users registered before password support cannot sign in
+	if user.Password == nil {
+		return response.AuthToken{}, exception.ErrInvalidCredential
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(*user.Password), []byte(body.Password))
+	if err != nil {
+		return response.AuthToken{}, exception.ErrInvalidCredential
+	}
+
+	return u.issueToken(user)
+}
+
+func (u authUsecase) RefreshToken(ctx context.Context, body request.RefreshTokenRequest) (response.AuthToken, error) {
+	claims, err := utils.ParseToken(u.config.JWTSecret, body.RefreshToken, utils.RefreshToken)
+	if err != nil {
+		return response.AuthToken{}, exception.ErrInvalidToken
+	}
+
+	user, err := u.usersRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.AuthToken{}, exception.ErrInvalidToken
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return response.AuthToken{}, exception.ErrFailedGetUser
+	}
+
+	return u.issueToken(user)
+}
+
+// Actor returns the current identity of an active user. Authenticate uses it to
+// refresh the role carried by a token, the command line tools to act as a user
+// without one.
+func (u authUsecase) Actor(ctx context.Context, userID int) (dto.AuthUser, error) {
+	user, err := u.usersRepo.GetByID(ctx, userID)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return dto.AuthUser{}, exception.ErrUserNotFound
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return dto.AuthUser{}, exception.ErrFailedGetUser
+	}
+
+	return dto.AuthUser{ID: user.ID, Role: user.Role}, nil
+}
+
+func (u authUsecase) issueToken(user entity.User) (response.AuthToken, error) {
+	accessToken, err := utils.GenerateToken(u.config.JWTSecret, user.ID, string(user.Role), utils.AccessToken, u.config.AccessTokenTTL)
+	if err != nil {
+		log.Errorf("failed generate access token: %s", err.Error())
+		return response.AuthToken{}, exception.ErrFailedGenerateToken
+	}
+
+	refreshToken, err := utils.GenerateToken(u.config.JWTSecret, user.ID, string(user.Role), utils.RefreshToken, u.config.RefreshTokenTTL)
+	if err != nil {
+		log.Errorf("failed generate refresh token: %s", err.Error())
+		return response.AuthToken{}, exception.ErrFailedGenerateToken
+	}
+
+	return response.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(u.config.AccessTokenTTL.Seconds()),
+	}, nil
+}
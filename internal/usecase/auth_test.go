@@ -0,0 +1,217 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/config/env"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/usecase"
+	"newsapi/internal/utils"
+	mock_repository "newsapi/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var mockAuthConfig = env.AuthConfig{
+	JWTSecret:       "test-secret",
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: time.Hour,
+}
+
+type AuthAccessor struct {
+	userRepo *mock_repository.MockUsersRepository
+	authUC   usecase.AuthUsecase
+}
+
+func newAuthAccessor(ctrl *gomock.Controller) AuthAccessor {
+	repo := mock_repository.NewMockUsersRepository(ctrl)
+	authUC := usecase.NewAuthUsecase(repo, mockAuthConfig)
+	return AuthAccessor{
+		userRepo: repo,
+		authUC:   authUC,
+	}
+}
+
+func Test_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newAuthAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.authUC
+	ctx := context.Background()
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	mockReq := request.LoginRequest{
+		Email:    "john@example.com",
+		Password: "secret-password",
+	}
+
+	tests := []struct {
+		testname  string
+		req       request.LoginRequest
+		initMock  func()
+		assertion func(token response.AuthToken, err error)
+	}{
+		{
+			testname: "user not found then return invalid credential",
+			req:      mockReq,
+			initMock: func() {
+				repo.EXPECT().GetByEmail(ctx, mockReq.Email).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.Equal(t, exception.ErrInvalidCredential, err)
+			},
+		},
+		{
+			testname: "repository error then return failed get user",
+			req:      mockReq,
+			initMock: func() {
+				repo.EXPECT().GetByEmail(ctx, mockReq.Email).Return(entity.User{}, errors.New("db error"))
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.Equal(t, exception.ErrFailedGetUser, err)
+			},
+		},
+		{
+			testname: "user without password then return invalid credential",
+			req:      mockReq,
+			initMock: func() {
+				repo.EXPECT().GetByEmail(ctx, mockReq.Email).Return(entity.User{ID: 1}, nil)
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.Equal(t, exception.ErrInvalidCredential, err)
+			},
+		},
+		{
+			testname: "wrong password then return invalid credential",
+			req: request.LoginRequest{
+				Email:    mockReq.Email,
+				Password: "wrong-password",
+			},
+			initMock: func() {
+				repo.EXPECT().GetByEmail(ctx, mockReq.Email).
+					Return(entity.User{ID: 1, Password: utils.StringPtr(string(hashed))}, nil)
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.Equal(t, exception.ErrInvalidCredential, err)
+			},
+		},
+		{
+			testname: "valid credential then return token pair",
+			req:      mockReq,
+			initMock: func() {
+				repo.EXPECT().GetByEmail(ctx, mockReq.Email).
+					Return(entity.User{ID: 1, Password: utils.StringPtr(string(hashed))}, nil)
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Bearer", token.TokenType)
+				assert.Equal(t, 900, token.ExpiresIn)
+
+				claims, err := utils.ParseToken(mockAuthConfig.JWTSecret, token.AccessToken, utils.AccessToken)
+				assert.NoError(t, err)
+				assert.Equal(t, 1, claims.UserID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			token, err := uc.Login(ctx, tt.req)
+			tt.assertion(token, err)
+		})
+	}
+}
+
+func Test_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newAuthAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.authUC
+	ctx := context.Background()
+
+	refreshToken, _ := utils.GenerateToken(mockAuthConfig.JWTSecret, 1, "author", utils.RefreshToken, time.Hour)
+	accessToken, _ := utils.GenerateToken(mockAuthConfig.JWTSecret, 1, "author", utils.AccessToken, time.Hour)
+
+	tests := []struct {
+		testname  string
+		token     string
+		initMock  func()
+		assertion func(token response.AuthToken, err error)
+	}{
+		{
+			testname: "access token used as refresh token then return invalid token",
+			token:    accessToken,
+			initMock: func() {},
+			assertion: func(token response.AuthToken, err error) {
+				assert.Equal(t, exception.ErrInvalidToken, err)
+			},
+		},
+		{
+			testname: "user no longer exists then return invalid token",
+			token:    refreshToken,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 1).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.Equal(t, exception.ErrInvalidToken, err)
+			},
+		},
+		{
+			testname: "valid refresh token then return new token pair",
+			token:    refreshToken,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 1).Return(entity.User{ID: 1}, nil)
+			},
+			assertion: func(token response.AuthToken, err error) {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token.AccessToken)
+				assert.NotEmpty(t, token.RefreshToken)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			token, err := uc.RefreshToken(ctx, request.RefreshTokenRequest{RefreshToken: tt.token})
+			tt.assertion(token, err)
+		})
+	}
+}
+
+func Test_Actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newAuthAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.authUC
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, 4).Return(entity.User{ID: 4, Role: entity.RoleEditor}, nil)
+	actor, err := uc.Actor(ctx, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.AuthUser{ID: 4, Role: entity.RoleEditor}, actor)
+
+	repo.EXPECT().GetByID(ctx, 5).Return(entity.User{}, sql.ErrNoRows)
+	_, err = uc.Actor(ctx, 5)
+	assert.Equal(t, exception.ErrUserNotFound, err)
+
+	repo.EXPECT().GetByID(ctx, 6).Return(entity.User{}, errors.New("db error"))
+	_, err = uc.Actor(ctx, 6)
+	assert.Equal(t, exception.ErrFailedGetUser, err)
+}
@@ -12,75 +12,207 @@ import (
 	"newsapi/internal/repository"
 	"newsapi/internal/utils"
 	"slices"
+	"strconv"
 	"time"
 
 	"github.com/labstack/gommon/log"
 )
 
+// newsSlugMaxLength is the size of the news_articles.slug column
+const newsSlugMaxLength = 255
+
 type newsArticlesUsecase struct {
-	newsArticlesrepo repository.NewsArticlesRepository
-	newsTopicsRepo   repository.NewsTopicsRepository
+	newsArticlesrepo     repository.NewsArticlesRepository
+	newsTopicsRepo       repository.NewsTopicsRepository
+	topicsRepo           repository.TopicsRepository
+	usersRepo            repository.UsersRepository
+	articleRevisionsRepo repository.ArticleRevisionsRepository
+	transactor           repository.Transactor
 }
 
 func NewNewsArticlesUsecase(
 	newsArticlesrepo repository.NewsArticlesRepository,
 	newsTopicsRepo repository.NewsTopicsRepository,
+	topicsRepo repository.TopicsRepository,
+	usersRepo repository.UsersRepository,
+	articleRevisionsRepo repository.ArticleRevisionsRepository,
+	transactor repository.Transactor,
 ) NewsUsecase {
 	return newsArticlesUsecase{
-		newsArticlesrepo: newsArticlesrepo,
-		newsTopicsRepo:   newsTopicsRepo,
+		newsArticlesrepo:     newsArticlesrepo,
+		newsTopicsRepo:       newsTopicsRepo,
+		topicsRepo:           topicsRepo,
+		usersRepo:            usersRepo,
+		articleRevisionsRepo: articleRevisionsRepo,
+		transactor:           transactor,
 	}
 }
 
 func (u newsArticlesUsecase) CreateNewsArticle(
 	ctx context.Context,
+	actor dto.AuthUser,
 	body request.CreateNewsArticleRequest,
 ) error {
+	if !canCreateArticle(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	authorID, err := u.resolveAuthorID(ctx, actor, body.AuthorID)
+	if err != nil {
+		return err
+	}
 
-	status := "draft"
-	if body.Status != nil {
-		status = *body.Status
+	status, err := initialStatus(actor, body.Status)
+	if err != nil {
+		return err
+	}
+
+	slug, err := u.newNewsSlug(ctx, body.Slug, body.Title)
+	if err != nil {
+		return err
 	}
 
 	article := &entity.NewsArticle{
 		Title:    body.Title,
 		Content:  body.Content,
 		Summary:  body.Summary,
-		AuthorID: body.AuthorID,
-		Slug:     body.Slug,
-		Status:   entity.ArticleStatus(status),
+		AuthorID: authorID,
+		Slug:     slug,
+		Status:   status,
 	}
-
-	if status == "published" {
-		article.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := schedule(article, body.PublishAt, time.Now()); err != nil {
+		return err
 	}
 
-	articleID, err := u.newsArticlesrepo.Create(ctx, article)
-	if err != nil {
-		if valid, hint := utils.IsDuplicateKey(err); valid {
-			return errors.New(hint)
+	// the article, its topic links and first revision are stored together, an
+	// invalid topic id must not leave an article without topics behind
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		articleID, err := u.newsArticlesrepo.Create(ctx, article)
+		if err != nil {
+			if valid, hint := utils.IsDuplicateKey(err); valid {
+				return errors.New(hint)
+			}
+			log.Errorf("failed create news: %v", err)
+			return exception.ErrFailedInsertNews
+		}
+		article.ID = articleID
+
+		if len(body.TopicIDs) > 0 {
+			err = u.newsTopicsRepo.Create(ctx, articleID, body.TopicIDs)
+			if errors.Is(err, repository.ErrUnknownTopic) {
+				return exception.ErrInvalidTopicIDs
+			}
+			if err != nil {
+				log.Errorf("failed create news topic: %v", err)
+				return exception.ErrFailedInsertNews
+			}
 		}
-		log.Errorf("failed create news: %w", err)
-		return exception.ErrFailedInsertNews
+
+		topicIDs := make([]int32, 0, len(body.TopicIDs))
+		for _, id := range body.TopicIDs {
+			topicIDs = append(topicIDs, int32(id))
+		}
+
+		return u.recordRevision(ctx, entity.ArticleRevision{
+			NewsArticleID: articleID,
+			Title:         article.Title,
+			Content:       article.Content,
+			Summary:       article.Summary,
+			Slug:          article.Slug,
+			TopicIDs:      topicIDs,
+			EditorID:      &actor.ID,
+		})
+	})
+}
+
+// initialStatus is the status a new article starts in, draft unless requested.
+// Going live on creation skips the review, which only publishers may do.
+func initialStatus(actor dto.AuthUser, requested *string) (entity.ArticleStatus, error) {
+	status := entity.StatusDraft
+	if requested != nil {
+		status = entity.ArticleStatus(*requested)
 	}
-	article.ID = articleID
 
-	if len(body.TopicIDs) > 0 {
-		err = u.newsTopicsRepo.Create(ctx, articleID, body.TopicIDs)
-		if err != nil {
-			log.Errorf("failed create news topic: %w", err)
-			return err
+	if (status == entity.StatusPublished || status == entity.StatusScheduled) && !canPublishArticle(actor) {
+		return "", exception.ErrPermissionDenied
+	}
+
+	return status, nil
+}
+
+// schedule sets the publishing times of a new article from its status, only
+// scheduled articles take a publishAt and it has to be after now
+func schedule(article *entity.NewsArticle, publishAt *time.Time, now time.Time) error {
+	switch {
+	case article.Status == entity.StatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return exception.ErrInvalidPublishAt
 		}
+		article.PublishAt = sql.NullTime{Time: *publishAt, Valid: true}
+	case publishAt != nil:
+		return exception.ErrPublishAtNotScheduled
+	case article.Status == entity.StatusPublished:
+		article.PublishedAt = sql.NullTime{Time: now, Valid: true}
 	}
 
 	return nil
 }
 
-func (u newsArticlesUsecase) GetNewsArticles(ctx context.Context, filter dto.NewsFilter) ([]response.NewsArticle, error) {
-	newsArticles, err := u.newsArticlesrepo.GetAll(ctx, filter)
+// resolveAuthorID returns the caller as the author unless an admin creates the article
+// on behalf of another existing user
+func (u newsArticlesUsecase) resolveAuthorID(ctx context.Context, actor dto.AuthUser, requested *int) (int, error) {
+	if requested == nil || *requested == actor.ID {
+		return actor.ID, nil
+	}
+
+	if actor.Role != entity.RoleAdmin {
+		return 0, exception.ErrPermissionDenied
+	}
+
+	author, err := u.usersRepo.GetByID(ctx, *requested)
 	if err != nil {
-		log.Errorf("failed get topic: %w", err)
-		return nil, exception.ErrFailedGetNews
+		if notFound := utils.IsNoRowError(err); notFound {
+			return 0, exception.ErrAuthorNotFound
+		}
+
+		log.Errorf("failed get author: %s", err.Error())
+		return 0, exception.ErrFailedGetUser
+	}
+
+	return author.ID, nil
+}
+
+// GetNewsArticles lists the articles matching filter that viewer may read, an
+// anonymous viewer is the zero AuthUser
+func (u newsArticlesUsecase) GetNewsArticles(
+	ctx context.Context,
+	viewer dto.AuthUser,
+	filter dto.NewsFilter,
+) ([]response.NewsArticle, response.Pagination, error) {
+	if !canSeeUnpublished(viewer) {
+		filter.PublishedOnly = true
+		filter.OwnerID = viewer.ID
+	}
+
+	// fetch one extra row to find out whether another page follows
+	probe := filter
+	probe.Pagination.Limit++
+
+	newsArticles, err := u.newsArticlesrepo.GetAll(ctx, probe)
+	if err != nil {
+		log.Errorf("failed get topic: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetNews
+	}
+
+	total, err := u.newsArticlesrepo.Count(ctx, filter)
+	if err != nil {
+		log.Errorf("failed count news: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetNews
+	}
+
+	hasMore := len(newsArticles) > filter.Pagination.Limit
+	if hasMore {
+		newsArticles = newsArticles[:filter.Pagination.Limit]
 	}
 
 	res := []response.NewsArticle{}
@@ -88,10 +220,42 @@ func (u newsArticlesUsecase) GetNewsArticles(ctx context.Context, filter dto.New
 		res = append(res, response.NewsArticleSeriliazer(na))
 	}
 
-	return res, nil
+	meta := response.PaginationSerializer(filter.Pagination, total)
+	if hasMore {
+		meta.NextCursor = newsCursor(newsArticles[len(newsArticles)-1], filter.Sort.OrDefault()).Encode()
+	}
+
+	return res, meta, nil
 }
 
-func (u newsArticlesUsecase) GetNewsArticleBySlug(ctx context.Context, slug string) (response.NewsArticleWithTopic, error) {
+// newsCursor captures the sort key of the article the way the repository orders by it
+func newsCursor(article entity.NewsArticleWithTopicID, sort dto.NewsSort) dto.Cursor {
+	var value string
+	switch sort.Field {
+	case dto.SortByCreatedAt:
+		value = article.CreatedAt.Format(time.RFC3339Nano)
+	case dto.SortByUpdatedAt:
+		value = article.UpdatedAt.Format(time.RFC3339Nano)
+	case dto.SortByTitle:
+		value = article.Title
+	case dto.SortByRelevance:
+		if article.Rank != nil {
+			value = strconv.FormatFloat(float64(*article.Rank), 'g', -1, 32)
+		}
+	default:
+		sortKey := article.CreatedAt
+		if article.PublishedAt.Valid {
+			sortKey = article.PublishedAt.Time
+		}
+		value = sortKey.Format(time.RFC3339Nano)
+	}
+
+	return dto.Cursor{Value: value, ID: article.ID, Sort: sort.String()}
+}
+
+// GetNewsArticleBySlug returns an article viewer may read, unpublished articles
+// of others are reported as not found
+func (u newsArticlesUsecase) GetNewsArticleBySlug(ctx context.Context, viewer dto.AuthUser, slug string) (response.NewsArticleWithTopic, error) {
 	newsArticles, err := u.newsArticlesrepo.GetActiveArticleBySlug(ctx, slug)
 	if err != nil {
 		if notFound := utils.IsNoRowError(err); notFound {
@@ -102,14 +266,128 @@ func (u newsArticlesUsecase) GetNewsArticleBySlug(ctx context.Context, slug stri
 		return response.NewsArticleWithTopic{}, exception.ErrFailedGetNews
 	}
 
+	if !canSeeArticle(viewer, newsArticles.Status, newsArticles.AuthorID) {
+		return response.NewsArticleWithTopic{}, exception.ErrNewsNotFound
+	}
+
 	res := response.NewsArticleWithTopicSerializer(newsArticles)
 	return res, nil
 }
 
+// ResolveNewsSlug returns the current slug of the article that used slug before
+// it was renamed, as long as viewer may read the article
+func (u newsArticlesUsecase) ResolveNewsSlug(ctx context.Context, viewer dto.AuthUser, slug string) (string, error) {
+	retired, err := u.newsArticlesrepo.GetByRetiredSlug(ctx, slug)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return "", exception.ErrNewsNotFound
+		}
+
+		log.Errorf("failed get retired slug: %s", err.Error())
+		return "", exception.ErrFailedGetNews
+	}
+
+	if retired.DeletedAt.Valid {
+		return "", exception.ErrNewsNotFound
+	}
+
+	// the current slug usually gives the title of an embargoed article away
+	if _, err := u.GetNewsArticleBySlug(ctx, viewer, retired.CurrentSlug); err != nil {
+		return "", err
+	}
+
+	return retired.CurrentSlug, nil
+}
+
+// newNewsSlug returns the slug requested by the client, or derives one from the
+// title that no article uses or used before
+func (u newsArticlesUsecase) newNewsSlug(ctx context.Context, requested, title string) (string, error) {
+	if requested != "" {
+		return requested, u.checkRetiredSlug(ctx, requested, 0)
+	}
+
+	base := newsSlugBase(title)
+	taken, err := u.newsArticlesrepo.GetTakenSlugs(ctx, base)
+	if err != nil {
+		log.Errorf("failed get taken slugs: %s", err.Error())
+		return "", exception.ErrFailedGetNews
+	}
+
+	return utils.UniqueSlug(base, taken), nil
+}
+
+// newsSlugBase is the slug generated from title before collisions are resolved
+func newsSlugBase(title string) string {
+	if base := utils.Slugify(title, newsSlugMaxLength); base != "" {
+		return base
+	}
+	return "news"
+}
+
+// checkRetiredSlug rejects slug when another article used it before, so old
+// links never lead to a different article. articleID may take back its own slugs.
+func (u newsArticlesUsecase) checkRetiredSlug(ctx context.Context, slug string, articleID int) error {
+	retired, err := u.newsArticlesrepo.GetByRetiredSlug(ctx, slug)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return nil
+		}
+
+		log.Errorf("failed get retired slug: %s", err.Error())
+		return exception.ErrFailedGetNews
+	}
+
+	if retired.OwnerID != articleID {
+		return exception.ErrSlugRetired
+	}
+
+	return nil
+}
+
+func (u newsArticlesUsecase) GetAuthorArticles(
+	ctx context.Context,
+	authorID int,
+	pagination dto.Pagination,
+) (response.AuthorProfile, response.Pagination, error) {
+	author, err := u.usersRepo.GetByID(ctx, authorID)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.AuthorProfile{}, response.Pagination{}, exception.ErrAuthorNotFound
+		}
+
+		log.Errorf("failed get author: %s", err.Error())
+		return response.AuthorProfile{}, response.Pagination{}, exception.ErrFailedGetUser
+	}
+
+	articles, err := u.newsArticlesrepo.GetPublishedByAuthor(ctx, author.ID, pagination)
+	if err != nil {
+		log.Errorf("failed get author news: %v", err)
+		return response.AuthorProfile{}, response.Pagination{}, exception.ErrFailedGetNews
+	}
+
+	total, err := u.newsArticlesrepo.CountPublishedByAuthor(ctx, author.ID)
+	if err != nil {
+		log.Errorf("failed count author news: %v", err)
+		return response.AuthorProfile{}, response.Pagination{}, exception.ErrFailedGetNews
+	}
+
+	res := response.AuthorProfile{
+		Author:   response.AuthorSerializer(author),
+		Articles: []response.ArticleSummary{},
+	}
+	for _, a := range articles {
+		res.Articles = append(res.Articles, response.ArticleSummarySerializer(a))
+	}
+
+	return res, response.PaginationSerializer(pagination, total), nil
+}
+
 func (u newsArticlesUsecase) UpdateNewsArticleBySlug(
 	ctx context.Context,
+	actor dto.AuthUser,
 	slug string,
 	body request.UpdateNewsArticleRequest,
+	expectedVersion *int,
 ) error {
 	currentNews, err := u.newsArticlesrepo.GetArticleBySlug(ctx, slug)
 	if err != nil {
@@ -121,6 +399,14 @@ func (u newsArticlesUsecase) UpdateNewsArticleBySlug(
 		return exception.ErrFailedGetNews
 	}
 
+	if !canModifyArticle(actor, currentNews.AuthorID) {
+		return exception.ErrPermissionDenied
+	}
+
+	if expectedVersion != nil && *expectedVersion != currentNews.Version {
+		return exception.ErrNewsVersionMismatch
+	}
+
 	// Prepare update fields
 	updateFields := make([]string, 0)
 	updatedNews := currentNews
@@ -135,29 +421,42 @@ func (u newsArticlesUsecase) UpdateNewsArticleBySlug(
 		updateFields = append(updateFields, "content")
 	}
 
-	if body.Summary != nil && *body.Summary != *currentNews.Summary {
-		updatedNews.Summary = body.Summary
-		updateFields = append(updateFields, "summary")
+	if body.Summary != nil {
+		// an empty summary clears it
+		summary := body.Summary
+		if *summary == "" {
+			summary = nil
+		}
+		if !equalStringPtr(summary, currentNews.Summary) {
+			updatedNews.Summary = summary
+			updateFields = append(updateFields, "summary")
+		}
 	}
 	if body.Slug != nil && *body.Slug != currentNews.Slug {
 		updatedNews.Slug = *body.Slug
 		updateFields = append(updateFields, "slug")
 	}
-	if body.Status != nil && *body.Status != string(currentNews.Status) {
-		updatedNews.Status = entity.ArticleStatus(*body.Status)
-		updateFields = append(updateFields, "status")
-	}
 
 	currentTopics := append([]int32(nil), currentNews.Topics...)
-	isTopicSame := slices.Equal(body.TopicIDs, currentTopics)
+	isTopicSame := body.TopicIDs == nil || slices.Equal(body.TopicIDs, currentTopics)
 
 	if len(updateFields) == 0 && isTopicSame {
 		return exception.ErrNoFieldUpdate
 	}
 
-	if len(updateFields) > 0 {
-		err = u.newsArticlesrepo.UpdateArticleFields(ctx, &currentNews, updateFields)
+	if slices.Contains(updateFields, "slug") {
+		if err := u.checkRetiredSlug(ctx, updatedNews.Slug, currentNews.ID); err != nil {
+			return err
+		}
+	}
+
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// runs even when only the topics change so the version moves on
+		err := u.newsArticlesrepo.UpdateArticleFields(ctx, &updatedNews, updateFields)
 		if err != nil {
+			if errors.Is(err, repository.ErrStaleVersion) {
+				return exception.ErrNewsVersionMismatch
+			}
 			// Handle unique constraint violation
 			if valid, hint := utils.IsDuplicateKey(err); valid {
 				return errors.New(hint)
@@ -165,20 +464,32 @@ func (u newsArticlesUsecase) UpdateNewsArticleBySlug(
 			log.Errorf("failed update news: %s", err.Error())
 			return exception.ErrFailedUpdateNews
 		}
-	}
 
-	if body.TopicIDs != nil {
-		err = u.newsTopicsRepo.ReplaceArticleTopics(ctx, currentNews.ID, body.TopicIDs)
-		if err != nil {
-			log.Errorf("failed get news: %s", err.Error())
-			return exception.ErrFailedUpdateTopicNews
+		if !isTopicSame {
+			err := u.newsTopicsRepo.ReplaceArticleTopics(ctx, currentNews.ID, body.TopicIDs)
+			if errors.Is(err, repository.ErrUnknownTopic) {
+				return exception.ErrInvalidTopicIDs
+			}
+			if err != nil {
+				log.Errorf("failed get news: %s", err.Error())
+				return exception.ErrFailedUpdateTopicNews
+			}
+			currentTopics = body.TopicIDs
 		}
-	}
 
-	return nil
+		return u.recordRevision(ctx, entity.ArticleRevision{
+			NewsArticleID: currentNews.ID,
+			Title:         updatedNews.Title,
+			Content:       updatedNews.Content,
+			Summary:       updatedNews.Summary,
+			Slug:          updatedNews.Slug,
+			TopicIDs:      currentTopics,
+			EditorID:      &actor.ID,
+		})
+	})
 }
 
-func (u newsArticlesUsecase) DeleteNewsArticleBySlug(ctx context.Context, slug string) error {
+func (u newsArticlesUsecase) DeleteNewsArticleBySlug(ctx context.Context, actor dto.AuthUser, slug string, expectedVersion *int) error {
 	currentNews, err := u.newsArticlesrepo.GetArticleBySlug(ctx, slug)
 	if err != nil {
 		if notFound := utils.IsNoRowError(err); notFound {
@@ -189,17 +500,48 @@ func (u newsArticlesUsecase) DeleteNewsArticleBySlug(ctx context.Context, slug s
 		return exception.ErrFailedGetNews
 	}
 
-	err = u.newsArticlesrepo.DeleteBySlug(ctx, slug)
-	if err != nil {
-		log.Errorf("failed delete news: %s", err.Error())
-		return exception.ErrFailedDeleteNews
+	if !canModifyArticle(actor, currentNews.AuthorID) {
+		return exception.ErrPermissionDenied
 	}
 
-	err = u.newsTopicsRepo.DeleteByArticleID(ctx, currentNews.ID)
-	if err != nil {
-		log.Errorf("failed delete topic news: %s", err.Error())
-		return exception.ErrFailedDeleteTopicNews
+	if expectedVersion != nil && *expectedVersion != currentNews.Version {
+		return exception.ErrNewsVersionMismatch
 	}
 
-	return nil
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.newsArticlesrepo.DeleteBySlug(ctx, slug, currentNews.Version)
+		if err != nil {
+			if errors.Is(err, repository.ErrStaleVersion) {
+				return exception.ErrNewsVersionMismatch
+			}
+			log.Errorf("failed delete news: %s", err.Error())
+			return exception.ErrFailedDeleteNews
+		}
+
+		err = u.newsTopicsRepo.DeleteByArticleID(ctx, currentNews.ID)
+		if err != nil {
+			log.Errorf("failed delete topic news: %s", err.Error())
+			return exception.ErrFailedDeleteTopicNews
+		}
+
+		return nil
+	})
+}
+
+// PublishScheduledNews publishes every scheduled article that is due, batchSize
+// rows at a time, and returns how many were published
+func (u newsArticlesUsecase) PublishScheduledNews(ctx context.Context, batchSize int) (int, error) {
+	published := 0
+	for {
+		ids, err := u.newsArticlesrepo.PublishDue(ctx, time.Now(), batchSize)
+		if err != nil {
+			log.Errorf("failed publish scheduled news: %v", err)
+			return published, exception.ErrFailedPublishNews
+		}
+
+		published += len(ids)
+		if len(ids) < batchSize {
+			return published, nil
+		}
+	}
 }
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
+	"slices"
+
+	"github.com/labstack/gommon/log"
+)
+
+// actions of request.BulkNewsRequest
+const (
+	bulkSetStatus    = "set_status"
+	bulkAddTopics    = "add_topics"
+	bulkRemoveTopics = "remove_topics"
+	bulkDelete       = "delete"
+)
+
+// BulkNews applies one action to many articles with a handful of set based
+// statements. Every article is checked the way its single endpoint would check
+// it, the ones that fail are reported and the rest are changed. An atomic
+// request changes nothing once one article fails and returns the result along
+// with ErrBulkNewsAborted.
+func (u newsArticlesUsecase) BulkNews(
+	ctx context.Context,
+	actor dto.AuthUser,
+	body request.BulkNewsRequest,
+) (response.BulkNewsResult, error) {
+	var reviewer *entity.User
+	if body.ReviewerID != nil {
+		if body.Action != bulkSetStatus || entity.ArticleStatus(body.Status) != entity.StatusInReview {
+			return response.BulkNewsResult{}, exception.ErrReviewerNotAllowed
+		}
+		found, err := u.getReviewer(ctx, *body.ReviewerID)
+		if err != nil {
+			return response.BulkNewsResult{}, err
+		}
+		reviewer = &found
+	}
+
+	slugs := make([]string, 0, len(body.Slugs))
+	for _, slug := range body.Slugs {
+		if !slices.Contains(slugs, slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+
+	articles, err := u.newsArticlesrepo.GetArticlesBySlugs(ctx, slugs)
+	if err != nil {
+		log.Errorf("failed get news: %s", err.Error())
+		return response.BulkNewsResult{}, exception.ErrFailedGetNews
+	}
+	bySlug := make(map[string]entity.NewsArticleWithTopic, len(articles))
+	for _, article := range articles {
+		bySlug[article.Slug] = article
+	}
+
+	result := response.BulkNewsResult{
+		Action: body.Action,
+		Atomic: body.Atomic,
+		Items:  make([]response.BulkNewsItem, len(slugs)),
+	}
+	// pending maps the id of every article left to write to its item
+	pending := map[int]int{}
+	ids := []int{}
+	targets := []entity.NewsArticleWithTopic{}
+	for i, slug := range slugs {
+		result.Items[i].Slug = slug
+
+		article, ok := bySlug[slug]
+		if !ok {
+			result.Items[i].Error = exception.ErrNewsNotFound.Error()
+			continue
+		}
+		if err := checkBulkNews(actor, article, body); err != nil {
+			result.Items[i].Error = err.Error()
+			continue
+		}
+		if reviewer != nil && reviewer.ID == article.AuthorID {
+			result.Items[i].Error = exception.ErrInvalidReviewer.Error()
+			continue
+		}
+		if !bulkChanges(article, body) {
+			result.Items[i].OK = true
+			continue
+		}
+
+		pending[article.ID] = i
+		ids = append(ids, article.ID)
+		targets = append(targets, article)
+	}
+
+	if body.Atomic && slices.ContainsFunc(result.Items, failedBulkItem) {
+		return abortBulkNews(result), exception.ErrBulkNewsAborted
+	}
+
+	if len(ids) > 0 {
+		err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			applied, err := u.applyBulkNews(ctx, actor, body, ids, targets)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range ids {
+				if !slices.Contains(applied, id) {
+					// another request changed the news since it was read
+					result.Items[pending[id]].Error = exception.ErrNewsVersionMismatch.Error()
+					delete(pending, id)
+				}
+			}
+			if body.Atomic && len(pending) < len(ids) {
+				return exception.ErrBulkNewsAborted
+			}
+
+			return nil
+		})
+		if err == exception.ErrBulkNewsAborted {
+			return abortBulkNews(result), err
+		}
+		if err != nil {
+			return response.BulkNewsResult{}, err
+		}
+	}
+
+	for _, i := range pending {
+		result.Items[i].OK = true
+	}
+	for _, item := range result.Items {
+		if item.OK {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+// applyBulkNews writes the action for ids, the ids of targets, and returns the
+// ids it changed
+func (u newsArticlesUsecase) applyBulkNews(
+	ctx context.Context,
+	actor dto.AuthUser,
+	body request.BulkNewsRequest,
+	ids []int,
+	targets []entity.NewsArticleWithTopic,
+) ([]int, error) {
+	switch body.Action {
+	case bulkSetStatus:
+		moved, err := u.newsArticlesrepo.UpdateStatuses(ctx, targets, entity.ArticleStatus(body.Status), body.ReviewerID, body.Comment)
+		if err != nil {
+			log.Errorf("failed change news status: %s", err.Error())
+			return nil, exception.ErrFailedTransitionNews
+		}
+		return moved, nil
+
+	case bulkDelete:
+		deleted, err := u.newsArticlesrepo.DeleteArticles(ctx, targets)
+		if err != nil {
+			log.Errorf("failed delete news: %s", err.Error())
+			return nil, exception.ErrFailedDeleteNews
+		}
+		if len(deleted) == 0 {
+			return deleted, nil
+		}
+		if err := u.newsTopicsRepo.DeleteByArticleIDs(ctx, deleted); err != nil {
+			log.Errorf("failed delete topic news: %s", err.Error())
+			return nil, exception.ErrFailedDeleteTopicNews
+		}
+		return deleted, nil
+	}
+
+	var err error
+	if body.Action == bulkAddTopics {
+		err = u.newsTopicsRepo.AddTopics(ctx, ids, body.TopicIDs)
+	} else {
+		err = u.newsTopicsRepo.RemoveTopics(ctx, ids, body.TopicIDs)
+	}
+	if errors.Is(err, repository.ErrUnknownTopic) {
+		return nil, exception.ErrInvalidTopicIDs
+	}
+	if err != nil {
+		log.Errorf("failed update topic news: %s", err.Error())
+		return nil, exception.ErrFailedBulkNews
+	}
+
+	if err := u.newsArticlesrepo.TouchArticles(ctx, ids); err != nil {
+		log.Errorf("failed update news: %s", err.Error())
+		return nil, exception.ErrFailedUpdateNews
+	}
+	if err := u.articleRevisionsRepo.CreateSnapshots(ctx, ids, actor.ID); err != nil {
+		log.Errorf("failed record news revision: %v", err)
+		return nil, exception.ErrFailedRecordRevision
+	}
+
+	return ids, nil
+}
+
+// checkBulkNews applies the rules of the single article endpoints: status
+// changes follow the editorial workflow, everything else needs edit rights
+func checkBulkNews(actor dto.AuthUser, article entity.NewsArticleWithTopic, body request.BulkNewsRequest) error {
+	if body.Action != bulkSetStatus {
+		if !canModifyArticle(actor, article.AuthorID) {
+			return exception.ErrPermissionDenied
+		}
+		return nil
+	}
+
+	from, to := article.Status, entity.ArticleStatus(body.Status)
+	if from == to && !bulkReassigns(article, body) {
+		return nil
+	}
+
+	guard, ok := newsTransitions[from][to]
+	if !ok {
+		return exception.NewInvalidTransitionError(string(from), string(to))
+	}
+	if !guard(actor, article) {
+		return exception.ErrPermissionDenied
+	}
+	if from == entity.StatusInReview && to == entity.StatusDraft && actor.ID != article.AuthorID && body.Comment == nil {
+		return exception.ErrReviewCommentRequired
+	}
+
+	return nil
+}
+
+// bulkChanges reports whether the action changes article at all, articles
+// already in the wanted state succeed without a write
+func bulkChanges(article entity.NewsArticleWithTopic, body request.BulkNewsRequest) bool {
+	has := func(id int) bool { return slices.Contains(article.Topics, int32(id)) }
+
+	switch body.Action {
+	case bulkSetStatus:
+		return article.Status != entity.ArticleStatus(body.Status) || bulkReassigns(article, body)
+	case bulkAddTopics:
+		return !allTopics(body.TopicIDs, has)
+	case bulkRemoveTopics:
+		return slices.ContainsFunc(body.TopicIDs, has)
+	}
+
+	return true
+}
+
+// bulkReassigns reports whether set_status hands article to another reviewer
+func bulkReassigns(article entity.NewsArticleWithTopic, body request.BulkNewsRequest) bool {
+	return body.ReviewerID != nil && (article.ReviewerID == nil || *article.ReviewerID != *body.ReviewerID)
+}
+
+func allTopics(ids []int, has func(int) bool) bool {
+	for _, id := range ids {
+		if !has(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func failedBulkItem(item response.BulkNewsItem) bool {
+	return item.Error != ""
+}
+
+// abortBulkNews reports every article that was not at fault as left unchanged
+func abortBulkNews(result response.BulkNewsResult) response.BulkNewsResult {
+	for i, item := range result.Items {
+		if !failedBulkItem(item) {
+			result.Items[i] = response.BulkNewsItem{Slug: item.Slug, Error: exception.ErrBulkNewsAborted.Error()}
+		}
+	}
+	result.Succeeded, result.Failed = 0, len(result.Items)
+
+	return result
+}
@@ -0,0 +1,248 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
+	"newsapi/internal/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BulkNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	newsTopicsRepo := accessor.newsTopicsRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	usersRepo := accessor.usersRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	author := dto.AuthUser{ID: 3, Role: entity.RoleAuthor}
+	articles := []entity.NewsArticleWithTopic{
+		{ID: 10, Slug: "slug-10", AuthorID: 3, Status: entity.StatusApproved, Topics: []int32{1}, Version: 2},
+		{ID: 11, Slug: "slug-11", AuthorID: 4, Status: entity.StatusDraft, Topics: []int32{1, 2}},
+		{ID: 12, Slug: "slug-12", AuthorID: 3, Status: entity.StatusPublished, Topics: []int32{}},
+	}
+	slugs := []string{"slug-10", "slug-11", "slug-12", "missing"}
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		body      request.BulkNewsRequest
+		initMock  func()
+		assertion func(res response.BulkNewsResult, err error)
+	}{
+		{
+			testname: "failed get news",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: slugs, Action: "delete"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs).Return(nil, errors.New("db error"))
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+		{
+			testname: "publishes what the workflow allows and reports the rest",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: append(slugs, "slug-10"), Action: "set_status", Status: "published"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs).Return(articles, nil)
+				newsArticleRepo.EXPECT().
+					UpdateStatuses(ctx, articles[:1], entity.StatusPublished, nil, nil).
+					Return([]int{10}, nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, response.BulkNewsResult{
+					Action:    "set_status",
+					Succeeded: 2,
+					Failed:    2,
+					Items: []response.BulkNewsItem{
+						{Slug: "slug-10", OK: true},
+						{Slug: "slug-11", Error: "cannot move news from draft to published"},
+						{Slug: "slug-12", OK: true},
+						{Slug: "missing", Error: exception.ErrNewsNotFound.Error()},
+					},
+				}, res)
+			},
+		},
+		{
+			testname: "atomic request changes nothing when one news fails",
+			actor:    author,
+			body:     request.BulkNewsRequest{Slugs: slugs[:2], Action: "delete", Atomic: true},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs[:2]).Return(articles[:2], nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrBulkNewsAborted, err)
+				assert.Equal(t, 2, res.Failed)
+				assert.Equal(t, exception.ErrBulkNewsAborted.Error(), res.Items[0].Error)
+				assert.Equal(t, exception.ErrPermissionDenied.Error(), res.Items[1].Error)
+			},
+		},
+		{
+			testname: "atomic request rolls back when a status changed meanwhile",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: []string{"slug-10", "slug-12"}, Action: "set_status", Status: "draft", Atomic: true},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, []string{"slug-10", "slug-12"}).Return([]entity.NewsArticleWithTopic{articles[0], articles[2]}, nil)
+				newsArticleRepo.EXPECT().
+					UpdateStatuses(ctx, []entity.NewsArticleWithTopic{articles[0], articles[2]}, entity.StatusDraft, nil, nil).
+					Return([]int{12}, nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrBulkNewsAborted, err)
+				assert.Equal(t, exception.ErrNewsVersionMismatch.Error(), res.Items[0].Error)
+				assert.Equal(t, exception.ErrBulkNewsAborted.Error(), res.Items[1].Error)
+			},
+		},
+		{
+			testname: "submits for review with the reviewer and comment of the single transition",
+			actor:    adminActor,
+			body: request.BulkNewsRequest{
+				Slugs: []string{"slug-11", "slug-12"}, Action: "set_status", Status: "in_review",
+				ReviewerID: utils.IntPtr(4), Comment: utils.StringPtr("check the sources"),
+			},
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 4).Return(entity.User{ID: 4, Role: entity.RoleEditor}, nil)
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, []string{"slug-11", "slug-12"}).Return(articles[1:], nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, exception.ErrInvalidReviewer.Error(), res.Items[0].Error)
+				assert.Equal(t, "cannot move news from published to in_review", res.Items[1].Error)
+			},
+		},
+		{
+			testname: "assigns the reviewer to the news written by someone else",
+			actor:    adminActor,
+			body: request.BulkNewsRequest{
+				Slugs: []string{"slug-11"}, Action: "set_status", Status: "in_review", ReviewerID: utils.IntPtr(3),
+			},
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 3).Return(entity.User{ID: 3, Role: entity.RoleEditor}, nil)
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, []string{"slug-11"}).Return(articles[1:2], nil)
+				newsArticleRepo.EXPECT().
+					UpdateStatuses(ctx, articles[1:2], entity.StatusInReview, utils.IntPtr(3), nil).
+					Return([]int{11}, nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, res.Succeeded)
+				assert.True(t, res.Items[0].OK)
+			},
+		},
+		{
+			testname: "reviewer only goes with in_review",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: slugs[:1], Action: "set_status", Status: "approved", ReviewerID: utils.IntPtr(4)},
+			initMock: func() {},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrReviewerNotAllowed, err)
+			},
+		},
+		{
+			testname: "reviewer must be an editor or admin",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: slugs[:1], Action: "set_status", Status: "in_review", ReviewerID: utils.IntPtr(5)},
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 5).Return(entity.User{ID: 5, Role: entity.RoleAuthor}, nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrInvalidReviewer, err)
+			},
+		},
+		{
+			testname: "adds topics to the news that miss them",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: slugs[:3], Action: "add_topics", TopicIDs: []int{1, 2}},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs[:3]).Return(articles, nil)
+				newsTopicsRepo.EXPECT().AddTopics(ctx, []int{10, 12}, []int{1, 2}).Return(nil)
+				newsArticleRepo.EXPECT().TouchArticles(ctx, []int{10, 12}).Return(nil)
+				articleRevisionsRepo.EXPECT().CreateSnapshots(ctx, []int{10, 12}, adminActor.ID).Return(nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, res.Succeeded)
+				assert.Equal(t, 0, res.Failed)
+			},
+		},
+		{
+			testname: "rejects unknown topics",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: slugs[:1], Action: "add_topics", TopicIDs: []int{99}},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs[:1]).Return(articles[:1], nil)
+				newsTopicsRepo.EXPECT().AddTopics(ctx, []int{10}, []int{99}).Return(repository.ErrUnknownTopic)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrInvalidTopicIDs, err)
+			},
+		},
+		{
+			testname: "author deletes only own news",
+			actor:    author,
+			body:     request.BulkNewsRequest{Slugs: slugs[:3], Action: "delete"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs[:3]).Return(articles, nil)
+				newsArticleRepo.EXPECT().DeleteArticles(ctx, []entity.NewsArticleWithTopic{articles[0], articles[2]}).Return([]int{10, 12}, nil)
+				newsTopicsRepo.EXPECT().DeleteByArticleIDs(ctx, []int{10, 12}).Return(nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, res.Succeeded)
+				assert.Equal(t, exception.ErrPermissionDenied.Error(), res.Items[1].Error)
+			},
+		},
+		{
+			testname: "news changed since the read is not deleted",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: []string{"slug-10", "slug-12"}, Action: "delete"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, []string{"slug-10", "slug-12"}).Return([]entity.NewsArticleWithTopic{articles[0], articles[2]}, nil)
+				newsArticleRepo.EXPECT().DeleteArticles(ctx, []entity.NewsArticleWithTopic{articles[0], articles[2]}).Return([]int{12}, nil)
+				newsTopicsRepo.EXPECT().DeleteByArticleIDs(ctx, []int{12}).Return(nil)
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, res.Succeeded)
+				assert.Equal(t, exception.ErrNewsVersionMismatch.Error(), res.Items[0].Error)
+				assert.True(t, res.Items[1].OK)
+			},
+		},
+		{
+			testname: "failed delete news",
+			actor:    adminActor,
+			body:     request.BulkNewsRequest{Slugs: slugs[:1], Action: "delete"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticlesBySlugs(ctx, slugs[:1]).Return(articles[:1], nil)
+				newsArticleRepo.EXPECT().DeleteArticles(ctx, articles[:1]).Return(nil, errors.New("db error"))
+			},
+			assertion: func(res response.BulkNewsResult, err error) {
+				assert.Equal(t, exception.ErrFailedDeleteNews, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, err := uc.BulkNews(ctx, tt.actor, tt.body)
+			tt.assertion(res, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/response"
+
+	"github.com/labstack/gommon/log"
+)
+
+// errExportWrite carries a failure of the caller's write out of the repository
+// untouched, it is not a database error worth logging
+type errExportWrite struct{ err error }
+
+func (e errExportWrite) Error() string { return e.err.Error() }
+
+// ExportNews streams every article matching filter to write in the order of
+// filter.Sort, pagination is ignored. Rows are handed over as they are read, so
+// an error may come after part of the export was written. An error returned by
+// write stops the export and is returned as is.
+func (u newsArticlesUsecase) ExportNews(
+	ctx context.Context,
+	actor dto.AuthUser,
+	filter dto.NewsFilter,
+	write func(response.NewsExport) error,
+) error {
+	if !canExportNews(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	err := u.newsArticlesrepo.Export(ctx, filter, func(row entity.NewsExport) error {
+		if err := write(response.NewsExportSerializer(row)); err != nil {
+			return errExportWrite{err: err}
+		}
+		return nil
+	})
+
+	var writeErr errExportWrite
+	if errors.As(err, &writeErr) {
+		return writeErr.err
+	}
+	if err != nil {
+		log.Errorf("failed export news: %v", err)
+		return exception.ErrFailedExportNews
+	}
+
+	return nil
+}
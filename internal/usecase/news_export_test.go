@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/response"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExportNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	publishedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := []entity.NewsExport{
+		{ID: 1, Slug: "first", Status: entity.StatusPublished, AuthorID: 3, AuthorName: "Jane",
+			PublishedAt: sql.NullTime{Time: publishedAt, Valid: true}, TopicIDs: []int32{2}, TopicSlugs: []string{"sports"}},
+		{ID: 2, Slug: "second", Status: entity.StatusDraft, AuthorID: 3, AuthorName: "Jane"},
+	}
+	filter := dto.NewsFilter{Status: entity.StatusPublished}
+	streamRows := func(_ context.Context, _ dto.NewsFilter, fn func(entity.NewsExport) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writeErr := errors.New("broken pipe")
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		write     func(written *[]response.NewsExport) func(response.NewsExport) error
+		initMock  func()
+		assertion func(written []response.NewsExport, err error)
+	}{
+		{
+			testname:  "author is not allowed",
+			actor:     dto.AuthUser{ID: 3, Role: entity.RoleAuthor},
+			initMock:  func() {},
+			assertion: func(written []response.NewsExport, err error) { assert.Equal(t, exception.ErrPermissionDenied, err) },
+		},
+		{
+			testname: "writes every row",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Export(ctx, filter, gomock.Any()).DoAndReturn(streamRows)
+			},
+			assertion: func(written []response.NewsExport, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, written, 2)
+				assert.Equal(t, &publishedAt, written[0].PublishedAt)
+				assert.Equal(t, []string{"sports"}, written[0].TopicSlugs)
+				assert.Nil(t, written[1].PublishedAt)
+			},
+		},
+		{
+			testname: "write error stops the export as is",
+			actor:    adminActor,
+			write: func(written *[]response.NewsExport) func(response.NewsExport) error {
+				return func(row response.NewsExport) error {
+					*written = append(*written, row)
+					return writeErr
+				}
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().Export(ctx, filter, gomock.Any()).DoAndReturn(streamRows)
+			},
+			assertion: func(written []response.NewsExport, err error) {
+				assert.Equal(t, writeErr, err)
+				assert.Len(t, written, 1)
+			},
+		},
+		{
+			testname: "failed export news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Export(ctx, filter, gomock.Any()).Return(errors.New("db error"))
+			},
+			assertion: func(written []response.NewsExport, err error) {
+				assert.Equal(t, exception.ErrFailedExportNews, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			written := []response.NewsExport{}
+			write := func(row response.NewsExport) error {
+				written = append(written, row)
+				return nil
+			}
+			if tt.write != nil {
+				write = tt.write(&written)
+			}
+			err := uc.ExportNews(ctx, tt.actor, filter, write)
+			tt.assertion(written, err)
+		})
+	}
+}
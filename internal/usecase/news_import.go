@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ImportBatchSize is the number of articles an import looks up and writes with
+// one statement per table
+const ImportBatchSize = 500
+
+// NewsImportSource yields the records of an import, io.EOF once it is read.
+// request.NewsImportReader decodes and validates them.
+type NewsImportSource interface {
+	Next() (request.NewsImportRecord, error)
+}
+
+// newsImport is the state an import keeps between batches
+type newsImport struct {
+	actor   dto.AuthUser
+	dryRun  bool
+	report  response.NewsImport
+	authors map[int]error
+	// claimed holds the slugs given to earlier lines, a dry run never writes
+	// them so the database cannot tell
+	claimed map[string]bool
+}
+
+// ImportNews creates the articles of source with the rules of
+// CreateNewsArticle, ImportBatchSize at a time. Lines that fail are reported
+// and skipped. Every batch is written in its own transaction, so when writing
+// or reading fails midway the batches before stay imported and the report
+// covers the lines read so far. A dry run checks everything without writing.
+func (u newsArticlesUsecase) ImportNews(
+	ctx context.Context,
+	actor dto.AuthUser,
+	source NewsImportSource,
+	dryRun bool,
+) (response.NewsImport, error) {
+	if !canCreateArticle(actor) {
+		return response.NewsImport{}, exception.ErrPermissionDenied
+	}
+
+	state := &newsImport{
+		actor:   actor,
+		dryRun:  dryRun,
+		report:  response.NewsImport{DryRun: dryRun, Errors: []response.NewsImportError{}},
+		authors: map[int]error{},
+		claimed: map[string]bool{},
+	}
+
+	batch := make([]request.NewsImportRecord, 0, ImportBatchSize)
+	for {
+		record, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Errorf("failed read import: %v", err)
+			return state.report, exception.ErrFailedReadImport
+		}
+
+		state.report.Total++
+		if record.Err != nil {
+			state.fail(record, record.Err)
+			continue
+		}
+
+		batch = append(batch, record)
+		if len(batch) == ImportBatchSize {
+			if err := u.importBatch(ctx, state, batch); err != nil {
+				return state.report, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err := u.importBatch(ctx, state, batch); err != nil {
+		return state.report, err
+	}
+
+	return state.report, nil
+}
+
+// importBatch resolves the topics and slugs of batch with one query each and
+// inserts the articles that pass
+func (u newsArticlesUsecase) importBatch(ctx context.Context, state *newsImport, batch []request.NewsImportRecord) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	topicIDs, topicSlugs, bases := []int{}, []string{}, []string{}
+	for _, record := range batch {
+		topicIDs = append(topicIDs, record.Article.TopicIDs...)
+		topicSlugs = append(topicSlugs, record.Article.TopicSlugs...)
+		bases = append(bases, importSlugBase(record.Article))
+	}
+
+	liveTopics := map[int]bool{}
+	if len(topicIDs) > 0 {
+		ids, err := u.topicsRepo.GetLiveIDs(ctx, topicIDs)
+		if err != nil {
+			log.Errorf("failed get topics: %v", err)
+			return exception.ErrFailedGetTopic
+		}
+		for _, id := range ids {
+			liveTopics[id] = true
+		}
+	}
+
+	topicBySlug := map[string]int{}
+	if len(topicSlugs) > 0 {
+		refs, err := u.topicsRepo.GetIDsBySlugs(ctx, topicSlugs)
+		if err != nil {
+			log.Errorf("failed get topics: %v", err)
+			return exception.ErrFailedGetTopic
+		}
+		for _, ref := range refs {
+			topicBySlug[ref.Slug] = ref.TopicID
+		}
+	}
+
+	taken, err := u.newsArticlesrepo.GetTakenSlugsOf(ctx, bases)
+	if err != nil {
+		log.Errorf("failed get taken slugs: %s", err.Error())
+		return exception.ErrFailedGetNews
+	}
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	isUsed := func(slug string) bool { return used[slug] || state.claimed[slug] }
+
+	articles := make([]entity.NewsArticle, 0, len(batch))
+	lines := make([]request.NewsImportRecord, 0, len(batch))
+	links := [][]int{}
+	now := time.Now()
+	for i, record := range batch {
+		body := record.Article
+
+		article, err := u.importArticle(ctx, state, body, now)
+		if err != nil {
+			state.fail(record, err)
+			continue
+		}
+
+		topics := make([]int, 0, len(body.TopicIDs)+len(body.TopicSlugs))
+		for _, id := range body.TopicIDs {
+			if !liveTopics[id] {
+				err = exception.ErrInvalidTopicIDs
+			}
+			topics = append(topics, id)
+		}
+		for _, slug := range body.TopicSlugs {
+			id, ok := topicBySlug[slug]
+			if !ok {
+				err = exception.ErrUnknownTopicSlug
+			}
+			topics = append(topics, id)
+		}
+		if err != nil {
+			state.fail(record, err)
+			continue
+		}
+
+		switch {
+		case body.Slug == "":
+			article.Slug = utils.FreeSlug(bases[i], isUsed)
+		case isUsed(body.Slug):
+			state.fail(record, exception.ErrSlugTaken)
+			continue
+		default:
+			article.Slug = body.Slug
+		}
+		state.claimed[article.Slug] = true
+
+		slices.Sort(topics)
+		articles = append(articles, article)
+		lines = append(lines, record)
+		links = append(links, slices.Compact(topics))
+	}
+
+	if state.dryRun || len(articles) == 0 {
+		state.report.Imported += len(articles)
+		return nil
+	}
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ids, err := u.newsArticlesrepo.CreateBatch(ctx, articles)
+		if err != nil {
+			return err
+		}
+
+		articleIDs, linkedTopics := []int{}, []int{}
+		for i, topics := range links {
+			for _, topicID := range topics {
+				articleIDs = append(articleIDs, ids[i])
+				linkedTopics = append(linkedTopics, topicID)
+			}
+		}
+		if err := u.newsTopicsRepo.CreateLinks(ctx, articleIDs, linkedTopics); err != nil {
+			return err
+		}
+
+		return u.articleRevisionsRepo.CreateSnapshots(ctx, ids, state.actor.ID)
+	})
+	if err != nil {
+		log.Errorf("failed import news: %v", err)
+		for _, record := range lines {
+			state.fail(record, exception.ErrFailedImportNews)
+		}
+		return exception.ErrFailedImportNews
+	}
+
+	state.report.Imported += len(articles)
+	return nil
+}
+
+// importArticle applies the author and status rules of CreateNewsArticle to
+// one line, authors are looked up once per import
+func (u newsArticlesUsecase) importArticle(
+	ctx context.Context,
+	state *newsImport,
+	body request.ImportNewsArticleRequest,
+	now time.Time,
+) (entity.NewsArticle, error) {
+	status, err := initialStatus(state.actor, body.Status)
+	if err != nil {
+		return entity.NewsArticle{}, err
+	}
+
+	authorID := state.actor.ID
+	if body.AuthorID != nil {
+		authorErr, seen := state.authors[*body.AuthorID]
+		if !seen {
+			_, authorErr = u.resolveAuthorID(ctx, state.actor, body.AuthorID)
+			state.authors[*body.AuthorID] = authorErr
+		}
+		if authorErr != nil {
+			return entity.NewsArticle{}, authorErr
+		}
+		authorID = *body.AuthorID
+	}
+
+	article := entity.NewsArticle{
+		Title:    body.Title,
+		Content:  body.Content,
+		Summary:  body.Summary,
+		AuthorID: authorID,
+		Status:   status,
+	}
+	if err := schedule(&article, body.PublishAt, now); err != nil {
+		return entity.NewsArticle{}, err
+	}
+
+	return article, nil
+}
+
+func (s *newsImport) fail(record request.NewsImportRecord, err error) {
+	s.report.Failed++
+	s.report.Errors = append(s.report.Errors, response.NewsImportError{
+		Line:  record.Line,
+		Slug:  record.Article.Slug,
+		Error: err.Error(),
+	})
+}
+
+// importSlugBase is the slug a line asks for, or the one its title generates
+func importSlugBase(body request.ImportNewsArticleRequest) string {
+	if body.Slug != "" {
+		return body.Slug
+	}
+	return newsSlugBase(body.Title)
+}
@@ -0,0 +1,193 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordSource hands out records like request.NewsImportReader, then err
+type recordSource struct {
+	records []request.NewsImportRecord
+	err     error
+}
+
+func (s *recordSource) Next() (request.NewsImportRecord, error) {
+	if len(s.records) == 0 {
+		if s.err != nil {
+			return request.NewsImportRecord{}, s.err
+		}
+		return request.NewsImportRecord{}, io.EOF
+	}
+
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+func importRecord(line int, title, slug string) request.NewsImportRecord {
+	record := request.NewsImportRecord{Line: line}
+	record.Article.Title = title
+	record.Article.Content = "Imported content"
+	record.Article.Slug = slug
+	return record
+}
+
+func Test_ImportNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	newsTopicsRepo := accessor.newsTopicsRepo
+	topicsRepo := accessor.topicsRepo
+	usersRepo := accessor.usersRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		source    func() *recordSource
+		dryRun    bool
+		initMock  func()
+		assertion func(res response.NewsImport, err error)
+	}{
+		{
+			testname:  "reader is not allowed",
+			actor:     dto.AuthUser{ID: 9, Role: entity.RoleReader},
+			source:    func() *recordSource { return &recordSource{} },
+			initMock:  func() {},
+			assertion: func(res response.NewsImport, err error) { assert.Equal(t, exception.ErrPermissionDenied, err) },
+		},
+		{
+			testname: "imports the valid lines and reports the rest",
+			actor:    adminActor,
+			source: func() *recordSource {
+				withTopic := importRecord(1, "Breaking news today", "")
+				withTopic.Article.TopicSlugs = []string{"sports"}
+				broken := request.NewsImportRecord{Line: 2, Err: errors.New("invalid title: required")}
+				unknownTopic := importRecord(4, "Fourth article", "")
+				unknownTopic.Article.TopicIDs = []int{99}
+				unknownAuthor := importRecord(5, "Fifth article", "")
+				unknownAuthor.Article.AuthorID = utils.IntPtr(7)
+				sameAuthor := importRecord(6, "Sixth article", "")
+				sameAuthor.Article.AuthorID = utils.IntPtr(7)
+				published := importRecord(7, "Breaking news today", "")
+				published.Article.Status = utils.StringPtr("published")
+				published.Article.TopicIDs = []int{2, 2}
+
+				return &recordSource{records: []request.NewsImportRecord{
+					withTopic, broken, importRecord(3, "Third article", "taken-slug"), unknownTopic, unknownAuthor, sameAuthor, published,
+				}}
+			},
+			initMock: func() {
+				topicsRepo.EXPECT().GetLiveIDs(ctx, []int{99, 2, 2}).Return([]int{2}, nil)
+				topicsRepo.EXPECT().GetIDsBySlugs(ctx, []string{"sports"}).Return([]entity.TopicSlugRef{{Slug: "sports", TopicID: 3}}, nil)
+				newsArticleRepo.EXPECT().
+					GetTakenSlugsOf(ctx, []string{"breaking-news-today", "taken-slug", "fourth-article", "fifth-article", "sixth-article", "breaking-news-today"}).
+					Return([]string{"breaking-news-today", "taken-slug"}, nil)
+				// looked up once for both lines naming it
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(entity.User{}, sql.ErrNoRows)
+				newsArticleRepo.EXPECT().CreateBatch(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, articles []entity.NewsArticle) ([]int, error) {
+						assert.Len(t, articles, 2)
+						assert.Equal(t, "breaking-news-today-2", articles[0].Slug)
+						assert.Equal(t, entity.StatusDraft, articles[0].Status)
+						assert.Equal(t, "breaking-news-today-3", articles[1].Slug)
+						assert.True(t, articles[1].PublishedAt.Valid)
+						return []int{10, 11}, nil
+					})
+				newsTopicsRepo.EXPECT().CreateLinks(ctx, []int{10, 11}, []int{3, 2}).Return(nil)
+				articleRevisionsRepo.EXPECT().CreateSnapshots(ctx, []int{10, 11}, adminActor.ID).Return(nil)
+			},
+			assertion: func(res response.NewsImport, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, response.NewsImport{
+					Total:    7,
+					Imported: 2,
+					Failed:   5,
+					Errors: []response.NewsImportError{
+						{Line: 2, Error: "invalid title: required"},
+						{Line: 3, Slug: "taken-slug", Error: exception.ErrSlugTaken.Error()},
+						{Line: 4, Error: exception.ErrInvalidTopicIDs.Error()},
+						{Line: 5, Error: exception.ErrAuthorNotFound.Error()},
+						{Line: 6, Error: exception.ErrAuthorNotFound.Error()},
+					},
+				}, res)
+			},
+		},
+		{
+			testname: "dry run checks slugs across lines without writing",
+			actor:    adminActor,
+			dryRun:   true,
+			source: func() *recordSource {
+				return &recordSource{records: []request.NewsImportRecord{
+					importRecord(1, "First article", "same-slug"),
+					importRecord(2, "Second article", "same-slug"),
+				}}
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTakenSlugsOf(ctx, []string{"same-slug", "same-slug"}).Return(nil, nil)
+			},
+			assertion: func(res response.NewsImport, err error) {
+				assert.NoError(t, err)
+				assert.True(t, res.DryRun)
+				assert.Equal(t, 1, res.Imported)
+				assert.Equal(t, []response.NewsImportError{{Line: 2, Slug: "same-slug", Error: exception.ErrSlugTaken.Error()}}, res.Errors)
+			},
+		},
+		{
+			testname: "failed write reports the lines of the batch",
+			actor:    adminActor,
+			source: func() *recordSource {
+				return &recordSource{records: []request.NewsImportRecord{importRecord(1, "First article", "")}}
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTakenSlugsOf(ctx, []string{"first-article"}).Return(nil, nil)
+				newsArticleRepo.EXPECT().CreateBatch(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			assertion: func(res response.NewsImport, err error) {
+				assert.Equal(t, exception.ErrFailedImportNews, err)
+				assert.Equal(t, 1, res.Failed)
+				assert.Equal(t, 0, res.Imported)
+			},
+		},
+		{
+			testname: "failed read keeps the report so far",
+			actor:    adminActor,
+			source: func() *recordSource {
+				return &recordSource{
+					records: []request.NewsImportRecord{{Line: 1, Err: errors.New("invalid content: required")}},
+					err:     errors.New("unexpected EOF"),
+				}
+			},
+			initMock: func() {},
+			assertion: func(res response.NewsImport, err error) {
+				assert.Equal(t, exception.ErrFailedReadImport, err)
+				assert.Equal(t, 1, res.Total)
+				assert.Equal(t, 1, res.Failed)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, err := uc.ImportNews(ctx, tt.actor, tt.source(), tt.dryRun)
+			tt.assertion(res, err)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"slices"
+
+	"github.com/labstack/gommon/log"
+)
+
+// recordRevision appends the state of an article after a change to its history
+func (u newsArticlesUsecase) recordRevision(ctx context.Context, revision entity.ArticleRevision) error {
+	revision.TopicIDs = slices.Clone(revision.TopicIDs)
+	slices.Sort(revision.TopicIDs)
+
+	if err := u.articleRevisionsRepo.Create(ctx, &revision); err != nil {
+		log.Errorf("failed record news revision: %v", err)
+		return exception.ErrFailedRecordRevision
+	}
+
+	return nil
+}
+
+// getEditableNews loads the article behind slug, only people allowed to edit it
+// may look into its history
+func (u newsArticlesUsecase) getEditableNews(
+	ctx context.Context,
+	actor dto.AuthUser,
+	slug string,
+) (entity.NewsArticleWithTopic, error) {
+	news, err := u.newsArticlesrepo.GetArticleBySlug(ctx, slug)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return entity.NewsArticleWithTopic{}, exception.ErrNewsNotFound
+		}
+
+		log.Errorf("failed get news: %s", err.Error())
+		return entity.NewsArticleWithTopic{}, exception.ErrFailedGetNews
+	}
+
+	if !canModifyArticle(actor, news.AuthorID) {
+		return entity.NewsArticleWithTopic{}, exception.ErrPermissionDenied
+	}
+
+	return news, nil
+}
+
+func (u newsArticlesUsecase) getRevision(ctx context.Context, articleID, revision int) (entity.ArticleRevision, error) {
+	result, err := u.articleRevisionsRepo.GetByRevision(ctx, articleID, revision)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return entity.ArticleRevision{}, exception.ErrRevisionNotFound
+		}
+
+		log.Errorf("failed get revision: %s", err.Error())
+		return entity.ArticleRevision{}, exception.ErrFailedGetRevision
+	}
+
+	return result, nil
+}
+
+func (u newsArticlesUsecase) GetNewsRevisions(
+	ctx context.Context,
+	actor dto.AuthUser,
+	slug string,
+	pagination dto.Pagination,
+) ([]response.ArticleRevision, response.Pagination, error) {
+	news, err := u.getEditableNews(ctx, actor, slug)
+	if err != nil {
+		return nil, response.Pagination{}, err
+	}
+
+	revisions, err := u.articleRevisionsRepo.GetByArticleID(ctx, news.ID, pagination)
+	if err != nil {
+		log.Errorf("failed get revisions: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetRevision
+	}
+
+	total, err := u.articleRevisionsRepo.CountByArticleID(ctx, news.ID)
+	if err != nil {
+		log.Errorf("failed count revisions: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetRevision
+	}
+
+	res := make([]response.ArticleRevision, 0, len(revisions))
+	for _, r := range revisions {
+		res = append(res, response.ArticleRevisionSerializer(r))
+	}
+
+	return res, response.PaginationSerializer(pagination, total), nil
+}
+
+func (u newsArticlesUsecase) DiffNewsRevisions(
+	ctx context.Context,
+	actor dto.AuthUser,
+	slug string,
+	from, to int,
+) (response.RevisionDiff, error) {
+	news, err := u.getEditableNews(ctx, actor, slug)
+	if err != nil {
+		return response.RevisionDiff{}, err
+	}
+
+	older, err := u.getRevision(ctx, news.ID, from)
+	if err != nil {
+		return response.RevisionDiff{}, err
+	}
+	newer, err := u.getRevision(ctx, news.ID, to)
+	if err != nil {
+		return response.RevisionDiff{}, err
+	}
+
+	return response.RevisionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffRevisions(older, newer),
+	}, nil
+}
+
+// diffRevisions lists every tracked field whose value differs between a and b
+func diffRevisions(a, b entity.ArticleRevision) []response.FieldChange {
+	changes := []response.FieldChange{}
+
+	if a.Title != b.Title {
+		changes = append(changes, response.FieldChange{Field: "title", From: a.Title, To: b.Title})
+	}
+	if a.Content != b.Content {
+		changes = append(changes, response.FieldChange{Field: "content", From: a.Content, To: b.Content})
+	}
+	if !equalStringPtr(a.Summary, b.Summary) {
+		changes = append(changes, response.FieldChange{Field: "summary", From: a.Summary, To: b.Summary})
+	}
+	if a.Slug != b.Slug {
+		changes = append(changes, response.FieldChange{Field: "slug", From: a.Slug, To: b.Slug})
+	}
+	if !slices.Equal(a.TopicIDs, b.TopicIDs) {
+		changes = append(changes, response.FieldChange{
+			Field: "topic_ids",
+			From:  append([]int32{}, a.TopicIDs...),
+			To:    append([]int32{}, b.TopicIDs...),
+		})
+	}
+
+	return changes
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// RestoreNewsRevision brings back the title, content, summary and topics of an
+// older revision through a regular update, so the restore is itself recorded as
+// a new revision. The current slug is kept to avoid breaking links.
+func (u newsArticlesUsecase) RestoreNewsRevision(
+	ctx context.Context,
+	actor dto.AuthUser,
+	slug string,
+	revision int,
+) error {
+	news, err := u.getEditableNews(ctx, actor, slug)
+	if err != nil {
+		return err
+	}
+
+	old, err := u.getRevision(ctx, news.ID, revision)
+	if err != nil {
+		return err
+	}
+
+	// the update leaves a nil summary alone, an empty one clears it
+	summary := old.Summary
+	if summary == nil {
+		summary = utils.StringPtr("")
+	}
+
+	return u.UpdateNewsArticleBySlug(ctx, actor, slug, request.UpdateNewsArticleRequest{
+		Title:    &old.Title,
+		Content:  &old.Content,
+		Summary:  summary,
+		TopicIDs: append([]int32{}, old.TopicIDs...),
+	}, nil)
+}
@@ -0,0 +1,308 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetNewsRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	article := entity.NewsArticleWithTopic{ID: 10, AuthorID: 4, Slug: "slug-10"}
+	pagination := dto.NewPagination(1, 10)
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(res []response.ArticleRevision, meta response.Pagination, err error)
+	}{
+		{
+			testname: "news not found",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(entity.NewsArticleWithTopic{}, sql.ErrNoRows)
+			},
+			assertion: func(res []response.ArticleRevision, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+			},
+		},
+		{
+			testname: "author of another article cannot see its history",
+			actor:    dto.AuthUser{ID: 3, Role: entity.RoleAuthor},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+			},
+			assertion: func(res []response.ArticleRevision, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "failed get revisions",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByArticleID(ctx, 10, pagination).Return(nil, errors.New("db error"))
+			},
+			assertion: func(res []response.ArticleRevision, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetRevision, err)
+			},
+		},
+		{
+			testname: "failed count revisions",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByArticleID(ctx, 10, pagination).Return([]entity.ArticleRevision{}, nil)
+				articleRevisionsRepo.EXPECT().CountByArticleID(ctx, 10).Return(0, errors.New("db error"))
+			},
+			assertion: func(res []response.ArticleRevision, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetRevision, err)
+			},
+		},
+		{
+			testname: "revisions newest first with editor",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByArticleID(ctx, 10, pagination).Return([]entity.ArticleRevision{
+					{Revision: 2, Title: "Title v2", Slug: "slug-10", TopicIDs: []int32{1}, EditorID: utils.IntPtr(1), EditorName: utils.StringPtr("Admin")},
+					{Revision: 1, Title: "Title v1", Slug: "slug-10", EditorID: utils.IntPtr(4), EditorName: utils.StringPtr("Author")},
+				}, nil)
+				articleRevisionsRepo.EXPECT().CountByArticleID(ctx, 10).Return(2, nil)
+			},
+			assertion: func(res []response.ArticleRevision, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, res, 2)
+				assert.Equal(t, 2, res[0].Revision)
+				assert.Equal(t, "Admin", *res[0].EditorName)
+				assert.Equal(t, []int32{}, res[1].TopicIDs)
+				assert.Equal(t, 2, meta.Total)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, meta, err := uc.GetNewsRevisions(ctx, tt.actor, "slug-10", pagination)
+			tt.assertion(res, meta, err)
+		})
+	}
+}
+
+func Test_DiffNewsRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	article := entity.NewsArticleWithTopic{ID: 10, AuthorID: 4, Slug: "slug-10"}
+	first := entity.ArticleRevision{
+		Revision: 1,
+		Title:    "Title v1",
+		Content:  "Content v1",
+		Slug:     "slug-10",
+		TopicIDs: []int32{1, 2},
+	}
+	second := entity.ArticleRevision{
+		Revision: 2,
+		Title:    "Title v1",
+		Content:  "Content v2",
+		Summary:  utils.StringPtr("Summary v2"),
+		Slug:     "slug-10",
+		TopicIDs: []int32{2},
+	}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(res response.RevisionDiff, err error)
+	}{
+		{
+			testname: "revision not found",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(first, nil)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 2).Return(entity.ArticleRevision{}, sql.ErrNoRows)
+			},
+			assertion: func(res response.RevisionDiff, err error) {
+				assert.Equal(t, exception.ErrRevisionNotFound, err)
+			},
+		},
+		{
+			testname: "failed get revision",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(entity.ArticleRevision{}, errors.New("db error"))
+			},
+			assertion: func(res response.RevisionDiff, err error) {
+				assert.Equal(t, exception.ErrFailedGetRevision, err)
+			},
+		},
+		{
+			testname: "changed fields only",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(first, nil)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 2).Return(second, nil)
+			},
+			assertion: func(res response.RevisionDiff, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, response.RevisionDiff{
+					From: 1,
+					To:   2,
+					Changes: []response.FieldChange{
+						{Field: "content", From: "Content v1", To: "Content v2"},
+						{Field: "summary", From: (*string)(nil), To: utils.StringPtr("Summary v2")},
+						{Field: "topic_ids", From: []int32{1, 2}, To: []int32{2}},
+					},
+				}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, err := uc.DiffNewsRevisions(ctx, adminActor, "slug-10", 1, 2)
+			tt.assertion(res, err)
+		})
+	}
+}
+
+func Test_RestoreNewsRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	newsTopicsRepo := accessor.newsTopicsRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	article := entity.NewsArticleWithTopic{
+		ID:       10,
+		Title:    "Title v2",
+		Content:  "Content v2",
+		Summary:  utils.StringPtr("Summary"),
+		AuthorID: 4,
+		Slug:     "slug-10",
+		Topics:   []int32{2},
+	}
+	first := entity.ArticleRevision{
+		Revision: 1,
+		Title:    "Title v1",
+		Content:  "Content v2",
+		Summary:  utils.StringPtr("Summary"),
+		Slug:     "old-slug-10",
+		TopicIDs: []int32{1, 2},
+	}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "revision not found",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(entity.ArticleRevision{}, sql.ErrNoRows)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrRevisionNotFound, err)
+			},
+		},
+		{
+			testname: "restore applies the old content as a new revision and keeps the slug",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil).Times(2)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(first, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"title"}).Return(nil)
+				newsTopicsRepo.EXPECT().ReplaceArticleTopics(ctx, 10, []int32{1, 2}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, revision *entity.ArticleRevision) error {
+						assert.Equal(t, "Title v1", revision.Title)
+						assert.Equal(t, "slug-10", revision.Slug)
+						assert.Equal(t, []int32{1, 2}, []int32(revision.TopicIDs))
+						return nil
+					})
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "restoring a revision without a summary clears the current one",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil).Times(2)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(entity.ArticleRevision{
+					Revision: 1,
+					Title:    article.Title,
+					Content:  article.Content,
+					TopicIDs: article.Topics,
+				}, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"summary"}).
+					DoAndReturn(func(_ context.Context, news *entity.NewsArticleWithTopic, _ []string) error {
+						assert.Nil(t, news.Summary)
+						return nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, revision *entity.ArticleRevision) error {
+						assert.Nil(t, revision.Summary)
+						return nil
+					})
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "restoring the current state changes nothing",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil).Times(2)
+				articleRevisionsRepo.EXPECT().GetByRevision(ctx, 10, 1).Return(entity.ArticleRevision{
+					Revision: 1,
+					Title:    article.Title,
+					Content:  article.Content,
+					Summary:  article.Summary,
+					TopicIDs: article.Topics,
+				}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNoFieldUpdate, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := uc.RestoreNewsRevision(ctx, adminActor, "slug-10", 1)
+			tt.assertion(err)
+		})
+	}
+}
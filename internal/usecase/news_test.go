@@ -9,6 +9,7 @@ import (
 	"newsapi/internal/model/entity"
 	"newsapi/internal/model/request"
 	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
 	"newsapi/internal/usecase"
 	"newsapi/internal/utils"
 	mock_repository "newsapi/mocks/repository"
@@ -19,20 +20,40 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var adminActor = dto.AuthUser{ID: 1, Role: entity.RoleAdmin}
+
 type NewsAccessor struct {
-	newsArticleRepo *mock_repository.MockNewsArticlesRepository
-	newsTopicsRepo  *mock_repository.MockNewsTopicsRepository
-	uc              usecase.NewsUsecase
+	newsArticleRepo      *mock_repository.MockNewsArticlesRepository
+	newsTopicsRepo       *mock_repository.MockNewsTopicsRepository
+	topicsRepo           *mock_repository.MockTopicsRepository
+	usersRepo            *mock_repository.MockUsersRepository
+	articleRevisionsRepo *mock_repository.MockArticleRevisionsRepository
+	transactor           *mock_repository.MockTransactor
+	uc                   usecase.NewsUsecase
+}
+
+// runInTransaction stands in for a real transaction by running fn right away
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
 }
 
 func newNewsAccessor(ctrl *gomock.Controller) NewsAccessor {
 	newsArticleRepo := mock_repository.NewMockNewsArticlesRepository(ctrl)
 	newsTopicsRepo := mock_repository.NewMockNewsTopicsRepository(ctrl)
-	uc := usecase.NewNewsArticlesUsecase(newsArticleRepo, newsTopicsRepo)
+	topicsRepo := mock_repository.NewMockTopicsRepository(ctrl)
+	usersRepo := mock_repository.NewMockUsersRepository(ctrl)
+	articleRevisionsRepo := mock_repository.NewMockArticleRevisionsRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction).AnyTimes()
+	uc := usecase.NewNewsArticlesUsecase(newsArticleRepo, newsTopicsRepo, topicsRepo, usersRepo, articleRevisionsRepo, transactor)
 	return NewsAccessor{
-		newsArticleRepo: newsArticleRepo,
-		newsTopicsRepo:  newsTopicsRepo,
-		uc:              uc,
+		newsArticleRepo:      newsArticleRepo,
+		newsTopicsRepo:       newsTopicsRepo,
+		topicsRepo:           topicsRepo,
+		usersRepo:            usersRepo,
+		articleRevisionsRepo: articleRevisionsRepo,
+		transactor:           transactor,
+		uc:                   uc,
 	}
 }
 
@@ -43,8 +64,13 @@ func Test_CreateNewsArticle(t *testing.T) {
 	accessor := newNewsAccessor(ctrl)
 	newsArticleRepo := accessor.newsArticleRepo
 	newsTopicsRepo := accessor.newsTopicsRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
 	uc := accessor.uc
 	ctx := context.Background()
+	// no slug used by another article before, see Test_NewsSlugHistory
+	newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, gomock.Any()).Return(entity.RetiredSlug{}, sql.ErrNoRows).AnyTimes()
+	publishAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	pastPublishAt := time.Now().Add(-time.Hour)
 
 	tests := []struct {
 		testname  string
@@ -58,13 +84,13 @@ func Test_CreateNewsArticle(t *testing.T) {
 				Title:    "Test Article 1",
 				Content:  "Content of test article 1",
 				Summary:  utils.StringPtr("Summary 1"),
-				AuthorID: 1,
 				Slug:     "test-article-1",
 				Status:   utils.StringPtr("draft"),
 				TopicIDs: []int{},
 			},
 			initMock: func() {
 				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).Return(1, nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -76,7 +102,6 @@ func Test_CreateNewsArticle(t *testing.T) {
 				Title:    "Published Article",
 				Content:  "Published content",
 				Summary:  nil,
-				AuthorID: 2,
 				Slug:     "published-article",
 				Status:   utils.StringPtr("published"),
 				TopicIDs: []int{10, 20, 30},
@@ -84,6 +109,14 @@ func Test_CreateNewsArticle(t *testing.T) {
 			initMock: func() {
 				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).Return(2, nil)
 				newsTopicsRepo.EXPECT().Create(ctx, 2, []int{10, 20, 30}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, revision *entity.ArticleRevision) error {
+						assert.Equal(t, 2, revision.NewsArticleID)
+						assert.Equal(t, "published-article", revision.Slug)
+						assert.Equal(t, []int32{10, 20, 30}, []int32(revision.TopicIDs))
+						assert.Equal(t, &adminActor.ID, revision.EditorID)
+						return nil
+					})
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -94,7 +127,6 @@ func Test_CreateNewsArticle(t *testing.T) {
 			mockReq: request.CreateNewsArticleRequest{
 				Title:    "Default Status Article",
 				Content:  "Content of default status",
-				AuthorID: 3,
 				Slug:     "default-status-article",
 				Status:   nil, // Default to draft
 				TopicIDs: []int{40, 50},
@@ -102,19 +134,82 @@ func Test_CreateNewsArticle(t *testing.T) {
 			initMock: func() {
 				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).Return(3, nil)
 				newsTopicsRepo.EXPECT().Create(ctx, 3, []int{40, 50}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "successful creation - scheduled status keeps publish_at",
+			mockReq: request.CreateNewsArticleRequest{
+				Title:     "Scheduled Article",
+				Content:   "Content of scheduled article",
+				Slug:      "scheduled-article",
+				Status:    utils.StringPtr("scheduled"),
+				PublishAt: &publishAt,
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, article *entity.NewsArticle) (int, error) {
+						assert.Equal(t, entity.StatusScheduled, article.Status)
+						assert.Equal(t, sql.NullTime{Time: publishAt, Valid: true}, article.PublishAt)
+						assert.False(t, article.PublishedAt.Valid)
+						return 4, nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testname: "scheduled status without publish_at",
+			mockReq: request.CreateNewsArticleRequest{
+				Title:   "Scheduled Article",
+				Content: "Content of scheduled article",
+				Slug:    "scheduled-article",
+				Status:  utils.StringPtr("scheduled"),
+			},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrInvalidPublishAt, err)
+			},
+		},
+		{
+			testname: "scheduled status with past publish_at",
+			mockReq: request.CreateNewsArticleRequest{
+				Title:     "Scheduled Article",
+				Content:   "Content of scheduled article",
+				Slug:      "scheduled-article",
+				Status:    utils.StringPtr("scheduled"),
+				PublishAt: &pastPublishAt,
+			},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrInvalidPublishAt, err)
+			},
+		},
+		{
+			testname: "publish_at without scheduled status",
+			mockReq: request.CreateNewsArticleRequest{
+				Title:     "Draft Article",
+				Content:   "Content of draft article",
+				Slug:      "draft-article",
+				PublishAt: &publishAt,
+			},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPublishAtNotScheduled, err)
+			},
+		},
 		{
 			testname: "failed to create news article - duplicate slug",
 			mockReq: request.CreateNewsArticleRequest{
-				Title:    "Duplicate Slug Article",
-				Content:  "Content",
-				AuthorID: 4,
-				Slug:     "duplicate-slug-value",
-				Status:   utils.StringPtr("draft"),
+				Title:   "Duplicate Slug Article",
+				Content: "Content",
+				Slug:    "duplicate-slug-value",
+				Status:  utils.StringPtr("draft"),
 			},
 			initMock: func() {
 				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
@@ -128,11 +223,10 @@ func Test_CreateNewsArticle(t *testing.T) {
 		{
 			testname: "failed to create news article - generic repository error",
 			mockReq: request.CreateNewsArticleRequest{
-				Title:    "Error Article",
-				Content:  "Content",
-				AuthorID: 5,
-				Slug:     "error-article",
-				Status:   utils.StringPtr("draft"),
+				Title:   "Error Article",
+				Content: "Content",
+				Slug:    "error-article",
+				Status:  utils.StringPtr("draft"),
 			},
 			initMock: func() {
 				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
@@ -148,7 +242,6 @@ func Test_CreateNewsArticle(t *testing.T) {
 			mockReq: request.CreateNewsArticleRequest{
 				Title:    "Article with Topic Error",
 				Content:  "Content",
-				AuthorID: 6,
 				Slug:     "article-topic-error",
 				Status:   utils.StringPtr("draft"),
 				TopicIDs: []int{1, 2},
@@ -162,8 +255,22 @@ func Test_CreateNewsArticle(t *testing.T) {
 				).Return(errors.New("failed to insert topic relations"))
 			},
 			assertion: func(err error) {
-				assert.Error(t, err)
-				assert.EqualError(t, err, "failed to insert topic relations")
+				assert.Equal(t, exception.ErrFailedInsertNews, err)
+			},
+		},
+		{
+			testname: "failed to record the first revision",
+			mockReq: request.CreateNewsArticleRequest{
+				Title:   "Article with Revision Error",
+				Content: "Content",
+				Slug:    "article-revision-error",
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).Return(7, nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedRecordRevision, err)
 			},
 		},
 	}
@@ -171,7 +278,7 @@ func Test_CreateNewsArticle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			err := uc.CreateNewsArticle(ctx, tt.mockReq)
+			err := uc.CreateNewsArticle(ctx, adminActor, tt.mockReq)
 			tt.assertion(err)
 		})
 	}
@@ -186,26 +293,74 @@ func Test_GetNewsArticles(t *testing.T) {
 	uc := accessor.uc
 	ctx := context.Background()
 
-	filter := dto.NewsFilter{}
+	filter := dto.NewsFilter{Pagination: dto.NewPagination(1, 1)}
+	probe := dto.NewsFilter{Pagination: dto.NewPagination(1, 2)}
+	titleFilter := dto.NewsFilter{Sort: dto.NewsSort{Field: dto.SortByTitle}, Pagination: dto.NewPagination(1, 1)}
+	titleProbe := dto.NewsFilter{Sort: dto.NewsSort{Field: dto.SortByTitle}, Pagination: dto.NewPagination(1, 2)}
+	searchFilter := dto.NewsFilter{Query: "election", Sort: dto.RelevanceNewsSort, Pagination: dto.NewPagination(1, 1)}
+	searchProbe := dto.NewsFilter{Query: "election", Sort: dto.RelevanceNewsSort, Pagination: dto.NewPagination(1, 2)}
+	publishedAt := time.Date(2025, 6, 5, 14, 25, 24, 0, time.UTC)
+	rank := float32(0.25)
+	snippet := "<mark>election</mark> night"
+
 	tests := []struct {
 		testname  string
+		viewer    *dto.AuthUser
+		filter    *dto.NewsFilter
 		initMock  func()
-		assertion func(res []response.NewsArticle, err error)
+		assertion func(res []response.NewsArticle, meta response.Pagination, err error)
 	}{
+		{
+			testname: "anonymous viewer only gets published news",
+			viewer:   &dto.AuthUser{},
+			initMock: func() {
+				published, publishedProbe := filter, probe
+				published.PublishedOnly, publishedProbe.PublishedOnly = true, true
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), publishedProbe).Return([]entity.NewsArticleWithTopicID{}, nil)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), published).Return(0, nil)
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "author also gets own unpublished news",
+			viewer:   &dto.AuthUser{ID: 3, Role: entity.RoleAuthor},
+			initMock: func() {
+				own, ownProbe := filter, probe
+				own.PublishedOnly, own.OwnerID = true, 3
+				ownProbe.PublishedOnly, ownProbe.OwnerID = true, 3
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), ownProbe).Return([]entity.NewsArticleWithTopicID{}, nil)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), own).Return(0, nil)
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+			},
+		},
 		{
 			testname: "get articles repo return error then usecase return error",
 			initMock: func() {
-				newsArticleRepo.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed retrieve"))
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), probe).Return(nil, errors.New("failed retrieve"))
 			},
-			assertion: func(res []response.NewsArticle, err error) {
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
 				assert.Error(t, err)
 				assert.Equal(t, 0, len(res))
 			},
 		},
+		{
+			testname: "count articles return error then usecase return error",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), probe).Return([]entity.NewsArticleWithTopicID{}, nil)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), filter).Return(0, errors.New("failed count"))
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
 		{
 			testname: "get articles valid data",
 			initMock: func() {
-				newsArticleRepo.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return(
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), probe).Return(
 					[]entity.NewsArticleWithTopicID{
 						{
 							ID:        1,
@@ -220,10 +375,69 @@ func Test_GetNewsArticles(t *testing.T) {
 					},
 					nil,
 				)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), filter).Return(1, nil)
 			},
-			assertion: func(res []response.NewsArticle, err error) {
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, 1, len(res))
+				assert.Equal(t, response.Pagination{Page: 1, Limit: 1, Total: 1}, meta)
+			},
+		},
+		{
+			testname: "extra row then return next cursor of last article",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), probe).Return(
+					[]entity.NewsArticleWithTopicID{
+						{ID: 9, PublishedAt: sql.NullTime{Time: publishedAt, Valid: true}},
+						{ID: 8, CreatedAt: publishedAt.Add(-time.Hour)},
+					},
+					nil,
+				)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), filter).Return(2, nil)
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, 9, res[0].ID)
+				cursor, err := dto.DecodeCursor(meta.NextCursor)
+				assert.NoError(t, err)
+				assert.Equal(t, dto.Cursor{Value: "2025-06-05T14:25:24Z", ID: 9, Sort: "published_at:desc"}, cursor)
+			},
+		},
+		{
+			testname: "next cursor follows the requested sort",
+			filter:   &titleFilter,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), titleProbe).Return(
+					[]entity.NewsArticleWithTopicID{{ID: 3, Title: "Alpha"}, {ID: 1, Title: "Beta"}},
+					nil,
+				)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), titleFilter).Return(2, nil)
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				cursor, err := dto.DecodeCursor(meta.NextCursor)
+				assert.NoError(t, err)
+				assert.Equal(t, dto.Cursor{Value: "Alpha", ID: 3, Sort: "title:asc"}, cursor)
+			},
+		},
+		{
+			testname: "search results carry rank, snippet and relevance cursor",
+			filter:   &searchFilter,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetAll(gomock.Any(), searchProbe).Return(
+					[]entity.NewsArticleWithTopicID{{ID: 5, Rank: &rank, Snippet: &snippet}, {ID: 2}},
+					nil,
+				)
+				newsArticleRepo.EXPECT().Count(gomock.Any(), searchFilter).Return(2, nil)
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &snippet, res[0].Snippet)
+				assert.Equal(t, &rank, res[0].Rank)
+				cursor, err := dto.DecodeCursor(meta.NextCursor)
+				assert.NoError(t, err)
+				assert.Equal(t, dto.Cursor{Value: "0.25", ID: 5, Sort: "relevance:desc"}, cursor)
 			},
 		},
 	}
@@ -231,8 +445,16 @@ func Test_GetNewsArticles(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			res, err := uc.GetNewsArticles(ctx, filter)
-			tt.assertion(res, err)
+			f := filter
+			if tt.filter != nil {
+				f = *tt.filter
+			}
+			viewer := adminActor
+			if tt.viewer != nil {
+				viewer = *tt.viewer
+			}
+			res, meta, err := uc.GetNewsArticles(ctx, viewer, f)
+			tt.assertion(res, meta, err)
 		})
 	}
 }
@@ -246,12 +468,59 @@ func Test_GetNewsArticleBySlug(t *testing.T) {
 	uc := accessor.uc
 	ctx := context.Background()
 
+	draft := entity.ActiveNewsWithTopic{ID: 2, Slug: "draft-slug", Status: entity.StatusDraft, AuthorID: 3}
+
 	tests := []struct {
 		testname  string
 		slug      string
+		viewer    dto.AuthUser
 		initMock  func()
 		assertion func(res response.NewsArticleWithTopic, err error)
 	}{
+		{
+			testname: "unpublished article is hidden from anonymous viewers",
+			slug:     "draft-slug",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetActiveArticleBySlug(ctx, "draft-slug").Return(draft, nil)
+			},
+			assertion: func(res response.NewsArticleWithTopic, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+			},
+		},
+		{
+			testname: "unpublished article is hidden from other authors",
+			slug:     "draft-slug",
+			viewer:   dto.AuthUser{ID: 4, Role: entity.RoleAuthor},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetActiveArticleBySlug(ctx, "draft-slug").Return(draft, nil)
+			},
+			assertion: func(res response.NewsArticleWithTopic, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+			},
+		},
+		{
+			testname: "unpublished article is shown to its author",
+			slug:     "draft-slug",
+			viewer:   dto.AuthUser{ID: 3, Role: entity.RoleAuthor},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetActiveArticleBySlug(ctx, "draft-slug").Return(draft, nil)
+			},
+			assertion: func(res response.NewsArticleWithTopic, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, res.ID)
+			},
+		},
+		{
+			testname: "unpublished article is shown to editors",
+			slug:     "draft-slug",
+			viewer:   dto.AuthUser{ID: 8, Role: entity.RoleEditor},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetActiveArticleBySlug(ctx, "draft-slug").Return(draft, nil)
+			},
+			assertion: func(res response.NewsArticleWithTopic, err error) {
+				assert.NoError(t, err)
+			},
+		},
 		{
 			testname: "successful retrieval of an active news article by slug",
 			slug:     "active-article-slug",
@@ -261,6 +530,7 @@ func Test_GetNewsArticleBySlug(t *testing.T) {
 					Title:       "Active News Title",
 					Content:     "Some active content.",
 					Slug:        "active-article-slug",
+					Status:      entity.StatusPublished,
 					PublishedAt: sql.NullTime{Time: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), Valid: true},
 					Topics:      []string{"technology"},
 				}
@@ -303,12 +573,106 @@ func Test_GetNewsArticleBySlug(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			res, err := uc.GetNewsArticleBySlug(ctx, tt.slug)
+			res, err := uc.GetNewsArticleBySlug(ctx, tt.viewer, tt.slug)
 			tt.assertion(res, err)
 		})
 	}
 }
 
+func Test_GetAuthorArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	usersRepo := accessor.usersRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	pagination := dto.NewPagination(1, 10)
+	author := entity.User{ID: 7, Name: "Jane", Email: "jane@example.com", Role: entity.RoleAuthor}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(profile response.AuthorProfile, meta response.Pagination, err error)
+	}{
+		{
+			testname: "author not found",
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(profile response.AuthorProfile, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrAuthorNotFound, err)
+			},
+		},
+		{
+			testname: "failed get author",
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(entity.User{}, errors.New("db error"))
+			},
+			assertion: func(profile response.AuthorProfile, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetUser, err)
+			},
+		},
+		{
+			testname: "failed get author articles",
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(author, nil)
+				newsArticleRepo.EXPECT().GetPublishedByAuthor(ctx, 7, pagination).Return(nil, errors.New("db error"))
+			},
+			assertion: func(profile response.AuthorProfile, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+		{
+			testname: "failed count author articles",
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(author, nil)
+				newsArticleRepo.EXPECT().GetPublishedByAuthor(ctx, 7, pagination).
+					Return([]entity.PublishedNewsWithTopic{}, nil)
+				newsArticleRepo.EXPECT().CountPublishedByAuthor(ctx, 7).Return(0, errors.New("db error"))
+			},
+			assertion: func(profile response.AuthorProfile, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+		{
+			testname: "author profile with articles",
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(author, nil)
+				newsArticleRepo.EXPECT().GetPublishedByAuthor(ctx, 7, pagination).
+					Return([]entity.PublishedNewsWithTopic{
+						{
+							ID:          1,
+							Title:       "Title 1",
+							Slug:        "title-1",
+							PublishedAt: sql.NullTime{Time: time.Now(), Valid: true},
+							Topics:      []string{"Go", "Tech"},
+						},
+					}, nil)
+				newsArticleRepo.EXPECT().CountPublishedByAuthor(ctx, 7).Return(11, nil)
+			},
+			assertion: func(profile response.AuthorProfile, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, response.Author{ID: 7, Name: "Jane", Role: entity.RoleAuthor}, profile.Author)
+				assert.Len(t, profile.Articles, 1)
+				assert.Equal(t, []string{"Go", "Tech"}, profile.Articles[0].Topics)
+				assert.NotNil(t, profile.Articles[0].PublishedAt)
+				assert.Equal(t, response.Pagination{Page: 1, Limit: 10, Total: 11}, meta)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			profile, meta, err := uc.GetAuthorArticles(ctx, 7, pagination)
+			tt.assertion(profile, meta, err)
+		})
+	}
+}
+
 func Test_UpdateNewsArticleBySlug(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -316,9 +680,11 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 	accessor := newNewsAccessor(ctrl)
 	newsArticleRepo := accessor.newsArticleRepo
 	newsTopicsRepo := accessor.newsTopicsRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
 	uc := accessor.uc
 	ctx := context.Background()
-
+	// no slug used by another article before, see Test_NewsSlugHistory
+	newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, gomock.Any()).Return(entity.RetiredSlug{}, sql.ErrNoRows).AnyTimes()
 	tests := []struct {
 		testname  string
 		slug      string
@@ -345,6 +711,7 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}, nil)
 				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"title"}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -370,17 +737,17 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}, nil)
 				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), gomock.InAnyOrder([]string{"content", "summary"})).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
 			},
 		},
 		{
-			testname: "successful update - slug and status",
+			testname: "successful update - slug",
 			slug:     "old-slug-3",
 			mockReq: request.UpdateNewsArticleRequest{
-				Slug:   utils.StringPtr("new-slug-3"),
-				Status: utils.StringPtr(string(entity.StatusPublished)),
+				Slug: utils.StringPtr("new-slug-3"),
 			},
 			initMock: func() {
 				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "old-slug-3").Return(entity.NewsArticleWithTopic{
@@ -394,7 +761,8 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				}, nil)
-				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), gomock.InAnyOrder([]string{"slug", "status"})).Return(nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"slug"}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -418,8 +786,10 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				}, nil)
-
+				// only the version moves when nothing but the topics change
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{}).Return(nil)
 				newsTopicsRepo.EXPECT().ReplaceArticleTopics(ctx, 4, []int32{30, 40, 50}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -433,7 +803,6 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 				Content:  utils.StringPtr("New Content 5"),
 				Summary:  utils.StringPtr("New Summary 5"),
 				Slug:     utils.StringPtr("new-slug-5"),
-				Status:   utils.StringPtr(string(entity.StatusPublished)),
 				TopicIDs: []int32{100, 200},
 			},
 			initMock: func() {
@@ -448,8 +817,21 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				}, nil)
-				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), gomock.InAnyOrder([]string{"title", "content", "summary", "slug", "status"})).Return(nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), gomock.InAnyOrder([]string{"title", "content", "summary", "slug"})).Return(nil)
 				newsTopicsRepo.EXPECT().ReplaceArticleTopics(ctx, 5, []int32{100, 200}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, revision *entity.ArticleRevision) error {
+						assert.Equal(t, entity.ArticleRevision{
+							NewsArticleID: 5,
+							Title:         "New Title 5",
+							Content:       "New Content 5",
+							Summary:       utils.StringPtr("New Summary 5"),
+							Slug:          "new-slug-5",
+							TopicIDs:      []int32{100, 200},
+							EditorID:      &adminActor.ID,
+						}, *revision)
+						return nil
+					})
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
@@ -491,7 +873,6 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 				Content:  utils.StringPtr("Existing Content"),
 				Summary:  utils.StringPtr("Existing Summary"),
 				Slug:     utils.StringPtr("slug-6"),
-				Status:   utils.StringPtr(string(entity.StatusDraft)),
 				TopicIDs: []int32{1, 2},
 			},
 			initMock: func() {
@@ -580,7 +961,7 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				}, nil)
-				// No UpdateArticleFields if only topics change and no other fields are changed
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{}).Return(nil)
 				newsTopicsRepo.EXPECT().ReplaceArticleTopics(ctx, 9, []int32{3, 4}).Return(errors.New("failed to update topic relations"))
 			},
 			assertion: func(err error) {
@@ -588,12 +969,59 @@ func Test_UpdateNewsArticleBySlug(t *testing.T) {
 				assert.Equal(t, exception.ErrFailedUpdateTopicNews, err)
 			},
 		},
+		{
+			testname: "omitted topics keep the current ones in the revision",
+			slug:     "slug-10",
+			mockReq: request.UpdateNewsArticleRequest{
+				Title: utils.StringPtr("New Title 10"),
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(entity.NewsArticleWithTopic{
+					ID:      10,
+					Title:   "Title 10",
+					Content: "Content 10",
+					Slug:    "slug-10",
+					Status:  entity.StatusDraft,
+					Topics:  []int32{2, 1},
+				}, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"title"}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, revision *entity.ArticleRevision) error {
+						assert.Equal(t, []int32{1, 2}, []int32(revision.TopicIDs))
+						return nil
+					})
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "failed to record revision",
+			slug:     "slug-11",
+			mockReq: request.UpdateNewsArticleRequest{
+				Title: utils.StringPtr("New Title 11"),
+			},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-11").Return(entity.NewsArticleWithTopic{
+					ID:      11,
+					Title:   "Title 11",
+					Content: "Content 11",
+					Slug:    "slug-11",
+					Status:  entity.StatusDraft,
+				}, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"title"}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedRecordRevision, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			err := uc.UpdateNewsArticleBySlug(ctx, tt.slug, tt.mockReq)
+			err := uc.UpdateNewsArticleBySlug(ctx, adminActor, tt.slug, tt.mockReq, nil)
 			tt.assertion(err)
 		})
 	}
@@ -632,7 +1060,7 @@ func Test_DeleteNewsArticleBySlug(t *testing.T) {
 					Slug:  "article-to-delete",
 					Title: "Article to Delete",
 				}, nil)
-				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "article-to-delete").Return(nil)
+				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "article-to-delete", 0).Return(nil)
 				newsTopicsRepo.EXPECT().DeleteByArticleID(ctx, 1).Return(nil)
 			},
 			assertion: func(err error) {
@@ -682,7 +1110,7 @@ func Test_DeleteNewsArticleBySlug(t *testing.T) {
 					Slug:  "delete-error-article",
 					Title: "Article with Delete Error",
 				}, nil)
-				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "delete-error-article").Return(errors.New("failed to delete from article table"))
+				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "delete-error-article", 0).Return(errors.New("failed to delete from article table"))
 			},
 			assertion: func(err error) {
 				assert.Error(t, err)
@@ -707,7 +1135,7 @@ func Test_DeleteNewsArticleBySlug(t *testing.T) {
 					Title: "Article with Topic Delete Error",
 				}, nil)
 				// Expect DeleteBySlug to succeed
-				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "topic-delete-error-article").Return(nil)
+				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "topic-delete-error-article", 0).Return(nil)
 				// Expect DeleteByArticleID to return an error
 				newsTopicsRepo.EXPECT().DeleteByArticleID(ctx, 3).Return(errors.New("failed to delete topic relations"))
 			},
@@ -721,7 +1149,675 @@ func Test_DeleteNewsArticleBySlug(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			err := uc.DeleteNewsArticleBySlug(ctx, tt.slug)
+			err := uc.DeleteNewsArticleBySlug(ctx, adminActor, tt.slug, nil)
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_NewsArticlePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	reader := dto.AuthUser{ID: 2, Role: entity.RoleReader}
+	author := dto.AuthUser{ID: 3, Role: entity.RoleAuthor}
+	article := entity.NewsArticleWithTopic{
+		ID:       10,
+		Title:    "Title 10",
+		Content:  "Content 10",
+		Summary:  utils.StringPtr("Summary 10"),
+		AuthorID: 4,
+		Slug:     "slug-10",
+		Status:   entity.StatusDraft,
+	}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		action    func() error
+		assertion func(err error)
+	}{
+		{
+			testname: "reader cannot create article",
+			initMock: func() {},
+			action: func() error {
+				return uc.CreateNewsArticle(ctx, reader, request.CreateNewsArticleRequest{Title: "Title"})
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "author cannot create article straight as published",
+			initMock: func() {},
+			action: func() error {
+				return uc.CreateNewsArticle(ctx, author, request.CreateNewsArticleRequest{
+					Title:  "Title",
+					Status: utils.StringPtr(string(entity.StatusPublished)),
+				})
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "author cannot update article of another author",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+			},
+			action: func() error {
+				return uc.UpdateNewsArticleBySlug(ctx, author, "slug-10", request.UpdateNewsArticleRequest{Title: utils.StringPtr("New Title")}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "author can update own article",
+			initMock: func() {
+				own := article
+				own.AuthorID = author.ID
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(own, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"title"}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			action: func() error {
+				return uc.UpdateNewsArticleBySlug(ctx, author, "slug-10", request.UpdateNewsArticleRequest{Title: utils.StringPtr("New Title")}, nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "author cannot delete article of another author",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+			},
+			action: func() error {
+				return uc.DeleteNewsArticleBySlug(ctx, author, "slug-10", nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := tt.action()
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_CreateNewsArticleAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	usersRepo := accessor.usersRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+	// no slug used by another article before, see Test_NewsSlugHistory
+	newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, gomock.Any()).Return(entity.RetiredSlug{}, sql.ErrNoRows).AnyTimes()
+
+	author := dto.AuthUser{ID: 3, Role: entity.RoleAuthor}
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		authorID  *int
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "author defaults to the caller",
+			actor:    author,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, article *entity.NewsArticle) (int, error) {
+						assert.Equal(t, author.ID, article.AuthorID)
+						return 1, nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "caller passing own id is accepted",
+			actor:    author,
+			authorID: &author.ID,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).Return(1, nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "non admin cannot post on behalf of another author",
+			actor:    author,
+			authorID: utils.IntPtr(7),
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "admin posts on behalf of another author",
+			actor:    adminActor,
+			authorID: utils.IntPtr(7),
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 7).Return(entity.User{ID: 7, Role: entity.RoleAuthor}, nil)
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, article *entity.NewsArticle) (int, error) {
+						assert.Equal(t, 7, article.AuthorID)
+						return 1, nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "admin posts on behalf of unknown author",
+			actor:    adminActor,
+			authorID: utils.IntPtr(8),
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 8).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrAuthorNotFound, err)
+			},
+		},
+		{
+			testname: "failed get author",
+			actor:    adminActor,
+			authorID: utils.IntPtr(9),
+			initMock: func() {
+				usersRepo.EXPECT().GetByID(ctx, 9).Return(entity.User{}, errors.New("db down"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedGetUser, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := uc.CreateNewsArticle(ctx, tt.actor, request.CreateNewsArticleRequest{
+				Title:    "Title",
+				Content:  "Content",
+				AuthorID: tt.authorID,
+				Slug:     "title-slug",
+			})
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_PublishScheduledNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(published int, err error)
+	}{
+		{
+			testname: "nothing due then publish nothing",
+			initMock: func() {
+				newsArticleRepo.EXPECT().PublishDue(ctx, gomock.Any(), 2).Return([]int{}, nil)
+			},
+			assertion: func(published int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, published)
+			},
+		},
+		{
+			testname: "full batch then fetch the next one",
+			initMock: func() {
+				gomock.InOrder(
+					newsArticleRepo.EXPECT().PublishDue(ctx, gomock.Any(), 2).Return([]int{1, 2}, nil),
+					newsArticleRepo.EXPECT().PublishDue(ctx, gomock.Any(), 2).Return([]int{3}, nil),
+				)
+			},
+			assertion: func(published int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, published)
+			},
+		},
+		{
+			testname: "repo return error then report what was published",
+			initMock: func() {
+				gomock.InOrder(
+					newsArticleRepo.EXPECT().PublishDue(ctx, gomock.Any(), 2).Return([]int{1, 2}, nil),
+					newsArticleRepo.EXPECT().PublishDue(ctx, gomock.Any(), 2).Return(nil, errors.New("db error")),
+				)
+			},
+			assertion: func(published int, err error) {
+				assert.Equal(t, exception.ErrFailedPublishNews, err)
+				assert.Equal(t, 2, published)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			published, err := uc.PublishScheduledNews(ctx, 2)
+			tt.assertion(published, err)
+		})
+	}
+}
+
+func Test_NewsSlugHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	article := entity.NewsArticleWithTopic{ID: 10, Title: "Title 10", Content: "Content 10", Slug: "slug-10"}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		action    func() error
+		assertion func(err error)
+	}{
+		{
+			testname: "create with a slug retired by another article",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{Slug: "old-slug", OwnerID: 4, CurrentSlug: "new-slug"}, nil)
+			},
+			action: func() error {
+				return uc.CreateNewsArticle(ctx, adminActor, request.CreateNewsArticleRequest{Title: "Title", Content: "Content", Slug: "old-slug"})
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrSlugRetired, err)
+			},
+		},
+		{
+			testname: "rename to a slug retired by another article",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{Slug: "old-slug", OwnerID: 4}, nil)
+			},
+			action: func() error {
+				return uc.UpdateNewsArticleBySlug(ctx, adminActor, "slug-10", request.UpdateNewsArticleRequest{Slug: utils.StringPtr("old-slug")}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrSlugRetired, err)
+			},
+		},
+		{
+			testname: "rename back to an own retired slug",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "first-slug-10").Return(entity.RetiredSlug{Slug: "first-slug-10", OwnerID: 10}, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"slug"}).Return(nil)
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			action: func() error {
+				return uc.UpdateNewsArticleBySlug(ctx, adminActor, "slug-10", request.UpdateNewsArticleRequest{Slug: utils.StringPtr("first-slug-10")}, nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "failed get retired slug",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "any-slug").Return(entity.RetiredSlug{}, errors.New("db error"))
+			},
+			action: func() error {
+				return uc.CreateNewsArticle(ctx, adminActor, request.CreateNewsArticleRequest{Title: "Title", Content: "Content", Slug: "any-slug"})
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := tt.action()
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_ResolveNewsSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(slug string, err error)
+	}{
+		{
+			testname: "slug never used",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{}, sql.ErrNoRows)
+			},
+			assertion: func(slug string, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+			},
+		},
+		{
+			testname: "renamed article was deleted since",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{
+					Slug:        "old-slug",
+					OwnerID:     4,
+					CurrentSlug: "new-slug",
+					DeletedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			assertion: func(slug string, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+			},
+		},
+		{
+			testname: "failed get retired slug",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{}, errors.New("db error"))
+			},
+			assertion: func(slug string, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+		{
+			testname: "renamed article resolves to its current slug",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{Slug: "old-slug", OwnerID: 4, CurrentSlug: "new-slug"}, nil)
+				newsArticleRepo.EXPECT().GetActiveArticleBySlug(ctx, "new-slug").
+					Return(entity.ActiveNewsWithTopic{ID: 4, Slug: "new-slug", Status: entity.StatusPublished}, nil)
+			},
+			assertion: func(slug string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "new-slug", slug)
+			},
+		},
+		{
+			testname: "renamed unpublished article does not give its slug away",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, "old-slug").Return(entity.RetiredSlug{Slug: "old-slug", OwnerID: 4, CurrentSlug: "embargoed-headline"}, nil)
+				newsArticleRepo.EXPECT().GetActiveArticleBySlug(ctx, "embargoed-headline").
+					Return(entity.ActiveNewsWithTopic{ID: 4, Slug: "embargoed-headline", Status: entity.StatusScheduled, AuthorID: 9}, nil)
+			},
+			assertion: func(slug string, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+				assert.Empty(t, slug)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			slug, err := uc.ResolveNewsSlug(ctx, dto.AuthUser{}, "old-slug")
+			tt.assertion(slug, err)
+		})
+	}
+}
+
+func Test_GenerateNewsSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	articleRevisionsRepo := accessor.articleRevisionsRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		title     string
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "slug derived from the transliterated title",
+			title:    "Ünïcödé Straße & Co",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTakenSlugs(ctx, "unicode-strasse-and-co").Return(nil, nil)
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, article *entity.NewsArticle) (int, error) {
+						assert.Equal(t, "unicode-strasse-and-co", article.Slug)
+						return 1, nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "collisions get the next free numeric suffix",
+			title:    "Breaking News",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTakenSlugs(ctx, "breaking-news").
+					Return([]string{"breaking-news", "breaking-news-2", "breaking-news-today"}, nil)
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, article *entity.NewsArticle) (int, error) {
+						assert.Equal(t, "breaking-news-3", article.Slug)
+						return 2, nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "title without letters or digits falls back to news",
+			title:    "!!! ???",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTakenSlugs(ctx, "news").Return([]string{"news"}, nil)
+				newsArticleRepo.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, article *entity.NewsArticle) (int, error) {
+						assert.Equal(t, "news-2", article.Slug)
+						return 3, nil
+					})
+				articleRevisionsRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "failed get taken slugs",
+			title:    "Breaking News",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTakenSlugs(ctx, "breaking-news").Return(nil, errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := uc.CreateNewsArticle(ctx, adminActor, request.CreateNewsArticleRequest{Title: tt.title, Content: "Some content"})
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_NewsTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newsArticleRepo := mock_repository.NewMockNewsArticlesRepository(ctrl)
+	newsTopicsRepo := mock_repository.NewMockNewsTopicsRepository(ctrl)
+	articleRevisionsRepo := mock_repository.NewMockArticleRevisionsRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	uc := usecase.NewNewsArticlesUsecase(newsArticleRepo, newsTopicsRepo, mock_repository.NewMockTopicsRepository(ctrl), mock_repository.NewMockUsersRepository(ctrl), articleRevisionsRepo, transactor)
+	ctx := context.Background()
+
+	type txKey struct{}
+	txCtx := context.WithValue(ctx, txKey{}, "tx")
+	commitErr := errors.New("commit failed")
+
+	newsArticleRepo.EXPECT().GetByRetiredSlug(ctx, gomock.Any()).Return(entity.RetiredSlug{}, sql.ErrNoRows).AnyTimes()
+
+	// every write must use the context of the transaction, and a failure to
+	// commit must reach the caller
+	inTransaction := func(_ context.Context, fn func(ctx context.Context) error) error {
+		if err := fn(txCtx); err != nil {
+			return err
+		}
+		return commitErr
+	}
+
+	t.Run("create stores the article, topics and revision in one transaction", func(t *testing.T) {
+		transactor.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(inTransaction)
+		newsArticleRepo.EXPECT().Create(txCtx, gomock.Any()).Return(7, nil)
+		newsTopicsRepo.EXPECT().Create(txCtx, 7, []int{1, 2}).Return(nil)
+		articleRevisionsRepo.EXPECT().Create(txCtx, gomock.Any()).Return(nil)
+
+		err := uc.CreateNewsArticle(ctx, adminActor, request.CreateNewsArticleRequest{
+			Title: "Title", Content: "Content", Slug: "some-slug", TopicIDs: []int{1, 2},
+		})
+		assert.Equal(t, commitErr, err)
+	})
+
+	t.Run("invalid topics abort the transaction", func(t *testing.T) {
+		transactor.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(inTransaction)
+		newsArticleRepo.EXPECT().Create(txCtx, gomock.Any()).Return(8, nil)
+		newsTopicsRepo.EXPECT().Create(txCtx, 8, []int{99}).Return(repository.ErrUnknownTopic)
+
+		err := uc.CreateNewsArticle(ctx, adminActor, request.CreateNewsArticleRequest{
+			Title: "Title", Content: "Content", Slug: "other-slug", TopicIDs: []int{99},
+		})
+		assert.Equal(t, exception.ErrInvalidTopicIDs, err)
+	})
+
+	t.Run("delete removes the article and its topic links in one transaction", func(t *testing.T) {
+		newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-3").Return(entity.NewsArticleWithTopic{ID: 3, AuthorID: 1}, nil)
+		transactor.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(inTransaction)
+		newsArticleRepo.EXPECT().DeleteBySlug(txCtx, "slug-3", 0).Return(nil)
+		newsTopicsRepo.EXPECT().DeleteByArticleID(txCtx, 3).Return(nil)
+
+		err := uc.DeleteNewsArticleBySlug(ctx, adminActor, "slug-3", nil)
+		assert.Equal(t, commitErr, err)
+	})
+}
+
+func Test_NewsVersionCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+	article := entity.NewsArticleWithTopic{
+		ID:      10,
+		Title:   "Title 10",
+		Slug:    "slug-10",
+		Status:  entity.StatusDraft,
+		Topics:  []int32{1},
+		Version: 3,
+	}
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		action    func() error
+		assertion func(err error)
+	}{
+		{
+			testname: "update with a stale version",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+			},
+			action: func() error {
+				return uc.UpdateNewsArticleBySlug(ctx, adminActor, "slug-10", request.UpdateNewsArticleRequest{Title: utils.StringPtr("New Title")}, utils.IntPtr(2))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNewsVersionMismatch, err)
+			},
+		},
+		{
+			testname: "article changed between read and write",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				newsArticleRepo.EXPECT().UpdateArticleFields(ctx, gomock.Any(), []string{"title"}).Return(repository.ErrStaleVersion)
+			},
+			action: func() error {
+				return uc.UpdateNewsArticleBySlug(ctx, adminActor, "slug-10", request.UpdateNewsArticleRequest{Title: utils.StringPtr("New Title")}, utils.IntPtr(3))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNewsVersionMismatch, err)
+			},
+		},
+		{
+			testname: "delete with a stale version",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+			},
+			action: func() error {
+				return uc.DeleteNewsArticleBySlug(ctx, adminActor, "slug-10", utils.IntPtr(1))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNewsVersionMismatch, err)
+			},
+		},
+		{
+			testname: "article changed between read and delete",
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(article, nil)
+				newsArticleRepo.EXPECT().DeleteBySlug(ctx, "slug-10", 3).Return(repository.ErrStaleVersion)
+			},
+			action: func() error {
+				return uc.DeleteNewsArticleBySlug(ctx, adminActor, "slug-10", utils.IntPtr(3))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNewsVersionMismatch, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := tt.action()
 			tt.assertion(err)
 		})
 	}
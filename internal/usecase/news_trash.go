@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/response"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+func (u newsArticlesUsecase) GetTrashedNews(
+	ctx context.Context,
+	actor dto.AuthUser,
+	pagination dto.Pagination,
+) ([]response.NewsArticle, response.Pagination, error) {
+	if !canManageTrash(actor) {
+		return nil, response.Pagination{}, exception.ErrPermissionDenied
+	}
+
+	articles, err := u.newsArticlesrepo.GetTrashed(ctx, pagination)
+	if err != nil {
+		log.Errorf("failed get trashed news: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetNews
+	}
+
+	total, err := u.newsArticlesrepo.CountTrashed(ctx)
+	if err != nil {
+		log.Errorf("failed count trashed news: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetNews
+	}
+
+	res := make([]response.NewsArticle, 0, len(articles))
+	for _, a := range articles {
+		res = append(res, response.NewsArticleSeriliazer(a))
+	}
+
+	return res, response.PaginationSerializer(pagination, total), nil
+}
+
+// RestoreNewsArticle undeletes a trashed article with the topic links it had
+// when it was deleted
+func (u newsArticlesUsecase) RestoreNewsArticle(ctx context.Context, actor dto.AuthUser, slug string) error {
+	if !canManageTrash(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	restored, err := u.newsArticlesrepo.Restore(ctx, slug)
+	if err != nil {
+		log.Errorf("failed restore news: %v", err)
+		return exception.ErrFailedRestoreNews
+	}
+	if !restored {
+		return exception.ErrNewsNotInTrash
+	}
+
+	return nil
+}
+
+// PurgeNewsArticle deletes a trashed article for good, live articles have to be
+// deleted first
+func (u newsArticlesUsecase) PurgeNewsArticle(ctx context.Context, actor dto.AuthUser, slug string) error {
+	if !canManageTrash(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	purged, err := u.newsArticlesrepo.Purge(ctx, slug)
+	if err != nil {
+		log.Errorf("failed purge news: %v", err)
+		return exception.ErrFailedPurgeNews
+	}
+	if !purged {
+		return exception.ErrNewsNotInTrash
+	}
+
+	return nil
+}
+
+// PurgeTrashedNews deletes for good every article trashed before the given
+// time, batchSize rows at a time, and returns how many were purged
+func (u newsArticlesUsecase) PurgeTrashedNews(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	purged := 0
+	for {
+		ids, err := u.newsArticlesrepo.PurgeTrashed(ctx, before, batchSize)
+		if err != nil {
+			log.Errorf("failed purge trashed news: %v", err)
+			return purged, exception.ErrFailedPurgeNews
+		}
+
+		purged += len(ids)
+		if len(ids) < batchSize {
+			return purged, nil
+		}
+	}
+}
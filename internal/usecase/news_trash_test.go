@@ -0,0 +1,270 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/response"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTrashedNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	pagination := dto.NewPagination(1, 10)
+	deletedAt := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(res []response.NewsArticle, meta response.Pagination, err error)
+	}{
+		{
+			testname: "editor is not allowed",
+			actor:    dto.AuthUser{ID: 5, Role: entity.RoleEditor},
+			initMock: func() {},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "failed get trashed news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTrashed(ctx, pagination).Return(nil, errors.New("db error"))
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+		{
+			testname: "failed count trashed news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTrashed(ctx, pagination).Return([]entity.NewsArticleWithTopicID{}, nil)
+				newsArticleRepo.EXPECT().CountTrashed(ctx).Return(0, errors.New("db error"))
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetNews, err)
+			},
+		},
+		{
+			testname: "success get trashed news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetTrashed(ctx, pagination).Return([]entity.NewsArticleWithTopicID{
+					{ID: 3, Slug: "slug-3", TopicIDs: []int32{1, 2}, DeletedAt: sql.NullTime{Time: deletedAt, Valid: true}},
+				}, nil)
+				newsArticleRepo.EXPECT().CountTrashed(ctx).Return(1, nil)
+			},
+			assertion: func(res []response.NewsArticle, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, &deletedAt, res[0].DeletedAt)
+				assert.Equal(t, []int32{1, 2}, res[0].TopicIDs)
+				assert.Equal(t, response.Pagination{Page: 1, Limit: 10, Total: 1}, meta)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, meta, err := uc.GetTrashedNews(ctx, tt.actor, pagination)
+			tt.assertion(res, meta, err)
+		})
+	}
+}
+
+func Test_RestoreNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "author is not allowed",
+			actor:    dto.AuthUser{ID: 3, Role: entity.RoleAuthor},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "news is not in the trash",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Restore(ctx, "slug-3").Return(false, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNewsNotInTrash, err)
+			},
+		},
+		{
+			testname: "failed restore news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Restore(ctx, "slug-3").Return(false, errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedRestoreNews, err)
+			},
+		},
+		{
+			testname: "success restore news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Restore(ctx, "slug-3").Return(true, nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			tt.assertion(uc.RestoreNewsArticle(ctx, tt.actor, "slug-3"))
+		})
+	}
+}
+
+func Test_PurgeNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "editor is not allowed",
+			actor:    dto.AuthUser{ID: 5, Role: entity.RoleEditor},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "live news cannot be purged",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Purge(ctx, "slug-3").Return(false, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNewsNotInTrash, err)
+			},
+		},
+		{
+			testname: "failed purge news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Purge(ctx, "slug-3").Return(false, errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedPurgeNews, err)
+			},
+		},
+		{
+			testname: "success purge news",
+			actor:    adminActor,
+			initMock: func() {
+				newsArticleRepo.EXPECT().Purge(ctx, "slug-3").Return(true, nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			tt.assertion(uc.PurgeNewsArticle(ctx, tt.actor, "slug-3"))
+		})
+	}
+}
+
+func Test_PurgeTrashedNews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	before := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		testname  string
+		initMock  func()
+		assertion func(purged int, err error)
+	}{
+		{
+			testname: "full batch then fetch the next one",
+			initMock: func() {
+				gomock.InOrder(
+					newsArticleRepo.EXPECT().PurgeTrashed(ctx, before, 2).Return([]int{1, 2}, nil),
+					newsArticleRepo.EXPECT().PurgeTrashed(ctx, before, 2).Return([]int{3}, nil),
+				)
+			},
+			assertion: func(purged int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, purged)
+			},
+		},
+		{
+			testname: "repo return error then report what was purged",
+			initMock: func() {
+				gomock.InOrder(
+					newsArticleRepo.EXPECT().PurgeTrashed(ctx, before, 2).Return([]int{1, 2}, nil),
+					newsArticleRepo.EXPECT().PurgeTrashed(ctx, before, 2).Return(nil, errors.New("db error")),
+				)
+			},
+			assertion: func(purged int, err error) {
+				assert.Equal(t, exception.ErrFailedPurgeNews, err)
+				assert.Equal(t, 2, purged)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			purged, err := uc.PurgeTrashedNews(ctx, before, 2)
+			tt.assertion(purged, err)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// transitionGuard decides whether the actor may perform a transition on article
+type transitionGuard func(actor dto.AuthUser, article entity.NewsArticleWithTopic) bool
+
+func authorOrEditor(actor dto.AuthUser, article entity.NewsArticleWithTopic) bool {
+	return canModifyArticle(actor, article.AuthorID)
+}
+
+func assignedReviewer(actor dto.AuthUser, article entity.NewsArticleWithTopic) bool {
+	return canReviewArticle(actor, article.ReviewerID)
+}
+
+// authorOrReviewer lets the author withdraw an article from review and the
+// assigned reviewer reject it, other editors have to be assigned first
+func authorOrReviewer(actor dto.AuthUser, article entity.NewsArticleWithTopic) bool {
+	return (actor.ID == article.AuthorID && canModifyArticle(actor, article.AuthorID)) || canReviewArticle(actor, article.ReviewerID)
+}
+
+func publisher(actor dto.AuthUser, _ entity.NewsArticleWithTopic) bool {
+	return canPublishArticle(actor)
+}
+
+// newsTransitions is the editorial workflow, every legal status change and who
+// may perform it. Anything missing is rejected, deleted articles never move.
+var newsTransitions = map[entity.ArticleStatus]map[entity.ArticleStatus]transitionGuard{
+	entity.StatusDraft: {
+		entity.StatusInReview: authorOrEditor,
+	},
+	entity.StatusInReview: {
+		entity.StatusInReview: publisher, // reassign the reviewer
+		entity.StatusApproved: assignedReviewer,
+		entity.StatusDraft:    authorOrReviewer, // withdrawn by the author or rejected by the reviewer
+	},
+	entity.StatusApproved: {
+		entity.StatusScheduled: publisher,
+		entity.StatusPublished: publisher,
+		entity.StatusDraft:     authorOrEditor,
+	},
+	entity.StatusScheduled: {
+		entity.StatusScheduled: publisher, // reschedule
+		entity.StatusPublished: publisher,
+		entity.StatusApproved:  publisher,
+	},
+	entity.StatusPublished: {
+		entity.StatusDraft: publisher,
+	},
+}
+
+func (u newsArticlesUsecase) TransitionNewsArticle(
+	ctx context.Context,
+	actor dto.AuthUser,
+	slug string,
+	body request.TransitionNewsArticleRequest,
+) (response.NewsStatus, error) {
+	currentNews, err := u.newsArticlesrepo.GetArticleBySlug(ctx, slug)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.NewsStatus{}, exception.ErrNewsNotFound
+		}
+
+		log.Errorf("failed get news: %s", err.Error())
+		return response.NewsStatus{}, exception.ErrFailedGetNews
+	}
+
+	from, to := currentNews.Status, entity.ArticleStatus(body.Status)
+	guard, ok := newsTransitions[from][to]
+	if !ok {
+		return response.NewsStatus{}, exception.NewInvalidTransitionError(string(from), string(to))
+	}
+	if !guard(actor, currentNews) {
+		return response.NewsStatus{}, exception.ErrPermissionDenied
+	}
+
+	updatedNews := currentNews
+	updatedNews.Status = to
+	if body.Comment != nil {
+		updatedNews.ReviewComment = body.Comment
+	}
+
+	if from == entity.StatusInReview && to == entity.StatusDraft && actor.ID != currentNews.AuthorID && body.Comment == nil {
+		return response.NewsStatus{}, exception.ErrReviewCommentRequired
+	}
+
+	if body.ReviewerID != nil {
+		if to != entity.StatusInReview {
+			return response.NewsStatus{}, exception.ErrReviewerNotAllowed
+		}
+		if err := u.checkReviewer(ctx, *body.ReviewerID, currentNews.AuthorID); err != nil {
+			return response.NewsStatus{}, err
+		}
+		updatedNews.ReviewerID = body.ReviewerID
+	}
+
+	now := time.Now()
+	switch {
+	case to == entity.StatusScheduled:
+		if body.PublishAt == nil || !body.PublishAt.After(now) {
+			return response.NewsStatus{}, exception.ErrInvalidPublishAt
+		}
+		updatedNews.PublishAt = sql.NullTime{Time: *body.PublishAt, Valid: true}
+	case body.PublishAt != nil:
+		return response.NewsStatus{}, exception.ErrPublishAtNotScheduled
+	default:
+		updatedNews.PublishAt = sql.NullTime{}
+	}
+	if to == entity.StatusPublished {
+		updatedNews.PublishedAt = sql.NullTime{Time: now, Valid: true}
+	}
+
+	moved, err := u.newsArticlesrepo.UpdateStatus(ctx, &updatedNews, from)
+	if err != nil {
+		log.Errorf("failed change news status: %s", err.Error())
+		return response.NewsStatus{}, exception.ErrFailedTransitionNews
+	}
+	if !moved {
+		// another request changed the status since it was read
+		return response.NewsStatus{}, exception.NewInvalidTransitionError(string(from), string(to))
+	}
+
+	return response.NewsStatusSerializer(updatedNews), nil
+}
+
+// checkReviewer makes sure reviewerID belongs to an active editor or admin who
+// did not write the article
+func (u newsArticlesUsecase) checkReviewer(ctx context.Context, reviewerID, authorID int) error {
+	reviewer, err := u.getReviewer(ctx, reviewerID)
+	if err != nil {
+		return err
+	}
+
+	if reviewer.ID == authorID {
+		return exception.ErrInvalidReviewer
+	}
+
+	return nil
+}
+
+// getReviewer loads reviewerID and makes sure it is an active editor or admin
+func (u newsArticlesUsecase) getReviewer(ctx context.Context, reviewerID int) (entity.User, error) {
+	reviewer, err := u.usersRepo.GetByID(ctx, reviewerID)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return entity.User{}, exception.ErrReviewerNotFound
+		}
+
+		log.Errorf("failed get reviewer: %s", err.Error())
+		return entity.User{}, exception.ErrFailedGetUser
+	}
+
+	if reviewer.Role != entity.RoleEditor && reviewer.Role != entity.RoleAdmin {
+		return entity.User{}, exception.ErrInvalidReviewer
+	}
+
+	return reviewer, nil
+}
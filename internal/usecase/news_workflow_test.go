@@ -0,0 +1,350 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
+	"newsapi/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TransitionNewsArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newNewsAccessor(ctrl)
+	newsArticleRepo := accessor.newsArticleRepo
+	usersRepo := accessor.usersRepo
+	uc := accessor.uc
+	ctx := context.Background()
+
+	author := dto.AuthUser{ID: 3, Role: entity.RoleAuthor}
+	editor := dto.AuthUser{ID: 5, Role: entity.RoleEditor}
+	otherEditor := dto.AuthUser{ID: 6, Role: entity.RoleEditor}
+	reader := dto.AuthUser{ID: 7, Role: entity.RoleReader}
+	publishAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	articleIn := func(status entity.ArticleStatus) entity.NewsArticleWithTopic {
+		return entity.NewsArticleWithTopic{ID: 10, AuthorID: author.ID, Slug: "slug-10", Status: status}
+	}
+	reviewed := articleIn(entity.StatusInReview)
+	reviewed.ReviewerID = utils.IntPtr(editor.ID)
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		req       request.TransitionNewsArticleRequest
+		initMock  func()
+		assertion func(res response.NewsStatus, err error)
+	}{
+		{
+			testname: "news not found",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "in_review"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(entity.NewsArticleWithTopic{}, sql.ErrNoRows)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrNewsNotFound, err)
+			},
+		},
+		{
+			testname: "deleted news cannot be published",
+			actor:    adminActor,
+			req:      request.TransitionNewsArticleRequest{Status: "published"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusDeleted), nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.NewInvalidTransitionError("deleted", "published"), err)
+				assert.Equal(t, exception.ErrInvalidTransition.Code, err.(exception.CustomError).Code)
+			},
+		},
+		{
+			testname: "draft cannot skip the review",
+			actor:    adminActor,
+			req:      request.TransitionNewsArticleRequest{Status: "published"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusDraft), nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.EqualError(t, err, "cannot move news from draft to published")
+			},
+		},
+		{
+			testname: "reader cannot submit for review",
+			actor:    reader,
+			req:      request.TransitionNewsArticleRequest{Status: "in_review"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusDraft), nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "author submits for review with a reviewer",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "in_review", ReviewerID: utils.IntPtr(editor.ID)},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusDraft), nil)
+				usersRepo.EXPECT().GetByID(ctx, editor.ID).Return(entity.User{ID: editor.ID, Role: entity.RoleEditor}, nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusDraft).Return(true, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StatusInReview, res.Status)
+				assert.Equal(t, utils.IntPtr(editor.ID), res.ReviewerID)
+			},
+		},
+		{
+			testname: "reviewer must be an editor or admin",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "in_review", ReviewerID: utils.IntPtr(8)},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusDraft), nil)
+				usersRepo.EXPECT().GetByID(ctx, 8).Return(entity.User{ID: 8, Role: entity.RoleAuthor}, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrInvalidReviewer, err)
+			},
+		},
+		{
+			testname: "reviewer not found",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "in_review", ReviewerID: utils.IntPtr(99)},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusDraft), nil)
+				usersRepo.EXPECT().GetByID(ctx, 99).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrReviewerNotFound, err)
+			},
+		},
+		{
+			testname: "reviewer can only be assigned when submitting",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "approved", ReviewerID: utils.IntPtr(otherEditor.ID)},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrReviewerNotAllowed, err)
+			},
+		},
+		{
+			testname: "only the assigned reviewer may approve",
+			actor:    otherEditor,
+			req:      request.TransitionNewsArticleRequest{Status: "approved"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "assigned reviewer approves",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "approved"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusInReview).Return(true, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StatusApproved, res.Status)
+			},
+		},
+		{
+			testname: "transition without a comment keeps the review comment",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "in_review"},
+			initMock: func() {
+				rejected := articleIn(entity.StatusDraft)
+				rejected.ReviewComment = utils.StringPtr("needs sources")
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(rejected, nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusDraft).
+					DoAndReturn(func(_ context.Context, news *entity.NewsArticleWithTopic, _ entity.ArticleStatus) (bool, error) {
+						assert.Equal(t, utils.StringPtr("needs sources"), news.ReviewComment)
+						return true, nil
+					})
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StatusInReview, res.Status)
+			},
+		},
+		{
+			testname: "reviewer rejection requires a comment",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "draft"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrReviewCommentRequired, err)
+			},
+		},
+		{
+			testname: "only the assigned reviewer may reject",
+			actor:    otherEditor,
+			req:      request.TransitionNewsArticleRequest{Status: "draft", Comment: utils.StringPtr("needs sources")},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "reviewer rejects with a comment",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "draft", Comment: utils.StringPtr("needs sources")},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusInReview).
+					DoAndReturn(func(_ context.Context, news *entity.NewsArticleWithTopic, _ entity.ArticleStatus) (bool, error) {
+						assert.Equal(t, entity.StatusDraft, news.Status)
+						assert.Equal(t, utils.StringPtr("needs sources"), news.ReviewComment)
+						assert.Equal(t, utils.IntPtr(editor.ID), news.ReviewerID)
+						return true, nil
+					})
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, utils.StringPtr("needs sources"), res.ReviewComment)
+			},
+		},
+		{
+			testname: "author withdraws without a comment",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "draft"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(reviewed, nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusInReview).Return(true, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StatusDraft, res.Status)
+			},
+		},
+		{
+			testname: "author cannot publish approved news",
+			actor:    author,
+			req:      request.TransitionNewsArticleRequest{Status: "published"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusApproved), nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "publishing stamps published_at",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "published"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusApproved), nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusApproved).Return(true, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StatusPublished, res.Status)
+				assert.NotNil(t, res.PublishedAt)
+			},
+		},
+		{
+			testname: "scheduling requires a future publish_at",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "scheduled"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusApproved), nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrInvalidPublishAt, err)
+			},
+		},
+		{
+			testname: "publish_at is only allowed when scheduling",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "published", PublishAt: &publishAt},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusApproved), nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrPublishAtNotScheduled, err)
+			},
+		},
+		{
+			testname: "schedule approved news",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "scheduled", PublishAt: &publishAt},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusApproved), nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusApproved).Return(true, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &publishAt, res.PublishAt)
+				assert.Nil(t, res.PublishedAt)
+			},
+		},
+		{
+			testname: "unscheduling clears publish_at",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "approved"},
+			initMock: func() {
+				scheduled := articleIn(entity.StatusScheduled)
+				scheduled.PublishAt = sql.NullTime{Time: publishAt, Valid: true}
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(scheduled, nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusScheduled).Return(true, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.NoError(t, err)
+				assert.Nil(t, res.PublishAt)
+			},
+		},
+		{
+			testname: "status changed concurrently",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "draft"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusPublished), nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusPublished).Return(false, nil)
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrInvalidTransition.Code, err.(exception.CustomError).Code)
+			},
+		},
+		{
+			testname: "repo return error then usecase return error",
+			actor:    editor,
+			req:      request.TransitionNewsArticleRequest{Status: "draft"},
+			initMock: func() {
+				newsArticleRepo.EXPECT().GetArticleBySlug(ctx, "slug-10").Return(articleIn(entity.StatusPublished), nil)
+				newsArticleRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), entity.StatusPublished).Return(false, errors.New("db error"))
+			},
+			assertion: func(res response.NewsStatus, err error) {
+				assert.Equal(t, exception.ErrFailedTransitionNews, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, err := uc.TransitionNewsArticle(ctx, tt.actor, "slug-10", tt.req)
+			tt.assertion(res, err)
+		})
+	}
+}
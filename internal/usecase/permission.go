@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+)
+
+func canManageTopics(actor dto.AuthUser) bool {
+	return actor.Role == entity.RoleAdmin || actor.Role == entity.RoleEditor
+}
+
+func canManageUsers(actor dto.AuthUser) bool {
+	return actor.Role == entity.RoleAdmin
+}
+
+// canManageTrash reports whether the actor may list, restore or purge soft-deleted
+// articles and topics
+func canManageTrash(actor dto.AuthUser) bool {
+	return actor.Role == entity.RoleAdmin
+}
+
+// canAccessUser reports whether the actor may read or edit the profile of
+// user id, everyone but admins is limited to their own account
+func canAccessUser(actor dto.AuthUser, id int) bool {
+	return canManageUsers(actor) || actor.ID == id
+}
+
+func canCreateArticle(actor dto.AuthUser) bool {
+	switch actor.Role {
+	case entity.RoleAdmin, entity.RoleEditor, entity.RoleAuthor:
+		return true
+	default:
+		return false
+	}
+}
+
+// canModifyArticle reports whether the actor may update or delete an article
+// written by authorID, authors are limited to their own articles
+func canModifyArticle(actor dto.AuthUser, authorID int) bool {
+	switch actor.Role {
+	case entity.RoleAdmin, entity.RoleEditor:
+		return true
+	case entity.RoleAuthor:
+		return actor.ID == authorID
+	default:
+		return false
+	}
+}
+
+// canPublishArticle reports whether the actor may approve, schedule, publish
+// or unpublish articles
+func canPublishArticle(actor dto.AuthUser) bool {
+	return actor.Role == entity.RoleAdmin || actor.Role == entity.RoleEditor
+}
+
+// canSeeUnpublished reports whether the actor may read articles of any author
+// before they are published
+func canSeeUnpublished(actor dto.AuthUser) bool {
+	return actor.Role == entity.RoleAdmin || actor.Role == entity.RoleEditor
+}
+
+// canSeeArticle reports whether the actor may read an article, unpublished
+// articles are only shown to their author, editors and admins. An anonymous
+// caller is the zero AuthUser.
+func canSeeArticle(actor dto.AuthUser, status entity.ArticleStatus, authorID int) bool {
+	return status == entity.StatusPublished || canSeeUnpublished(actor) || (actor.ID != 0 && actor.ID == authorID)
+}
+
+// canExportNews reports whether the actor may dump articles of every author
+// and status in bulk
+func canExportNews(actor dto.AuthUser) bool {
+	return actor.Role == entity.RoleAdmin || actor.Role == entity.RoleEditor
+}
+
+// canReviewArticle reports whether the actor may decide on an article in review,
+// once a reviewer is assigned only that reviewer or an admin may
+func canReviewArticle(actor dto.AuthUser, reviewerID *int) bool {
+	if !canPublishArticle(actor) {
+		return false
+	}
+	return reviewerID == nil || *reviewerID == actor.ID || actor.Role == entity.RoleAdmin
+}
@@ -4,35 +4,74 @@ import (
 	"context"
 	"errors"
 	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
 	"newsapi/internal/model/request"
 	"newsapi/internal/model/response"
 	"newsapi/internal/repository"
 	"newsapi/internal/utils"
+	"slices"
+	"strconv"
 
 	"github.com/labstack/gommon/log"
 )
 
+// topicSlugMaxLength follows the limit CreateTopicRequest puts on slugs
+const topicSlugMaxLength = 100
+
+const (
+	// DefaultTopicLatest is how many recent articles a topic detail lists when
+	// the client does not ask for a number
+	DefaultTopicLatest = 5
+	MaxTopicLatest     = 20
+)
+
 type topicsUsecase struct {
-	repo repository.TopicsRepository
+	repo           repository.TopicsRepository
+	newsTopicsRepo repository.NewsTopicsRepository
+	transactor     repository.Transactor
 }
 
 func NewTopicsUsecase(
 	repo repository.TopicsRepository,
+	newsTopicsRepo repository.NewsTopicsRepository,
+	transactor repository.Transactor,
 ) TopicsUsecase {
-	return topicsUsecase{repo: repo}
+	return topicsUsecase{
+		repo:           repo,
+		newsTopicsRepo: newsTopicsRepo,
+		transactor:     transactor,
+	}
 }
 
 func (u topicsUsecase) CreateTopic(
 	ctx context.Context,
+	actor dto.AuthUser,
 	body request.CreateTopicRequest,
 ) error {
+	if !canManageTopics(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	if body.ParentID != nil {
+		if err := u.checkParent(ctx, 0, *body.ParentID); err != nil {
+			return err
+		}
+	}
+
+	slug, err := u.newTopicSlug(ctx, body.Slug, body.Name)
+	if err != nil {
+		return err
+	}
+
 	entity := &entity.Topic{
 		Name:        body.Name,
 		Description: body.Description,
-		Slug:        body.Slug,
+		Slug:        slug,
+		ParentID:    body.ParentID,
 	}
-	err := u.repo.Create(ctx, entity)
+
+	err = u.repo.Create(ctx, entity)
 	if err != nil {
 		if valid, hint := utils.IsDuplicateKey(err); valid {
 			return errors.New(hint)
@@ -43,11 +82,30 @@ func (u topicsUsecase) CreateTopic(
 	return nil
 }
 
-func (u topicsUsecase) GetTopics(ctx context.Context) ([]response.Topic, error) {
-	topics, err := u.repo.GetAll(ctx)
+func (u topicsUsecase) GetTopics(
+	ctx context.Context,
+	pagination dto.Pagination,
+	withCounts bool,
+) ([]response.Topic, response.Pagination, error) {
+	// fetch one extra row to find out whether another page follows
+	probe := pagination
+	probe.Limit++
+
+	topics, err := u.repo.GetAll(ctx, probe)
 	if err != nil {
-		log.Errorf("failed get topic: %w", err)
-		return nil, exception.ErrFailedGetTopic
+		log.Errorf("failed get topic: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetTopic
+	}
+
+	total, err := u.repo.Count(ctx)
+	if err != nil {
+		log.Errorf("failed count topic: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetTopic
+	}
+
+	hasMore := len(topics) > pagination.Limit
+	if hasMore {
+		topics = topics[:pagination.Limit]
 	}
 
 	res := []response.Topic{}
@@ -55,10 +113,138 @@ func (u topicsUsecase) GetTopics(ctx context.Context) ([]response.Topic, error)
 		res = append(res, response.TopicSeriliazer(t))
 	}
 
+	if withCounts && len(topics) > 0 {
+		ids := make([]int, 0, len(topics))
+		for _, t := range topics {
+			ids = append(ids, t.ID)
+		}
+
+		counts, err := u.articleCounts(ctx, ids)
+		if err != nil {
+			return nil, response.Pagination{}, err
+		}
+		for i := range res {
+			count := counts[res[i].ID]
+			res[i].ArticleCount = &count
+		}
+	}
+
+	meta := response.PaginationSerializer(pagination, total)
+	if hasMore {
+		meta.NextCursor = dto.Cursor{ID: topics[len(topics)-1].ID}.Encode()
+	}
+
+	return res, meta, nil
+}
+
+// GetTopic returns a topic by id, or by slug when key is not a number, with
+// its published article count and the latest most recent published articles
+func (u topicsUsecase) GetTopic(ctx context.Context, key string, latest int) (response.TopicDetail, error) {
+	var topic entity.Topic
+	var err error
+	if id, convErr := strconv.Atoi(key); convErr == nil {
+		topic, err = u.repo.GetByID(ctx, id)
+	} else {
+		topic, err = u.repo.GetBySlug(ctx, key)
+	}
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.TopicDetail{}, exception.ErrTopicNotFound
+		}
+
+		log.Errorf("failed get topic: %s", err.Error())
+		return response.TopicDetail{}, exception.ErrFailedGetTopic
+	}
+
+	if latest < 1 {
+		latest = DefaultTopicLatest
+	}
+	if latest > MaxTopicLatest {
+		latest = MaxTopicLatest
+	}
+
+	counts, err := u.articleCounts(ctx, []int{topic.ID})
+	if err != nil {
+		return response.TopicDetail{}, err
+	}
+
+	articles, err := u.repo.GetLatestPublished(ctx, topic.ID, latest)
+	if err != nil {
+		log.Errorf("failed get topic articles: %s", err.Error())
+		return response.TopicDetail{}, exception.ErrFailedGetTopic
+	}
+
+	res := response.TopicDetail{
+		Topic:          response.TopicSeriliazer(topic),
+		LatestArticles: []response.ArticleSummary{},
+	}
+	count := counts[topic.ID]
+	res.ArticleCount = &count
+	for _, a := range articles {
+		res.LatestArticles = append(res.LatestArticles, response.ArticleSummarySerializer(a))
+	}
+
+	return res, nil
+}
+
+// ResolveTopicSlug returns the current slug of the topic that used slug before
+// it was renamed
+func (u topicsUsecase) ResolveTopicSlug(ctx context.Context, slug string) (string, error) {
+	retired, err := u.repo.GetByRetiredSlug(ctx, slug)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return "", exception.ErrTopicNotFound
+		}
+
+		log.Errorf("failed get retired slug: %s", err.Error())
+		return "", exception.ErrFailedGetTopic
+	}
+
+	if retired.DeletedAt.Valid {
+		return "", exception.ErrTopicNotFound
+	}
+
+	return retired.CurrentSlug, nil
+}
+
+// articleCounts maps each of topicIDs to its number of published articles
+func (u topicsUsecase) articleCounts(ctx context.Context, topicIDs []int) (map[int]int, error) {
+	counts, err := u.repo.CountPublishedArticles(ctx, topicIDs)
+	if err != nil {
+		log.Errorf("failed count topic articles: %s", err.Error())
+		return nil, exception.ErrFailedGetTopic
+	}
+
+	res := make(map[int]int, len(counts))
+	for _, c := range counts {
+		res[c.TopicID] = c.Count
+	}
+
 	return res, nil
 }
 
-func (u topicsUsecase) UpdateTopic(ctx context.Context, id int, body request.UpdateTopicRequest) error {
+// GetTopicTree returns all topics nested below their parents
+func (u topicsUsecase) GetTopicTree(ctx context.Context) ([]response.TopicNode, error) {
+	topics, err := u.repo.GetHierarchy(ctx)
+	if err != nil {
+		log.Errorf("failed get topic hierarchy: %v", err)
+		return nil, exception.ErrFailedGetTopic
+	}
+
+	return response.TopicTreeSerializer(topics), nil
+}
+
+func (u topicsUsecase) UpdateTopic(
+	ctx context.Context,
+	actor dto.AuthUser,
+	id int,
+	body request.UpdateTopicRequest,
+	expectedVersion *int,
+) error {
+	if !canManageTopics(actor) {
+		return exception.ErrPermissionDenied
+	}
+
 	currentTopic, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		if notFound := utils.IsNoRowError(err); notFound {
@@ -69,6 +255,10 @@ func (u topicsUsecase) UpdateTopic(ctx context.Context, id int, body request.Upd
 		return exception.ErrFailedUpdateTopic
 	}
 
+	if expectedVersion != nil && *expectedVersion != currentTopic.Version {
+		return exception.ErrTopicVersionMismatch
+	}
+
 	updateFields := make([]string, 0)
 	updatedTopic := currentTopic
 
@@ -89,12 +279,39 @@ func (u topicsUsecase) UpdateTopic(ctx context.Context, id int, body request.Upd
 		updateFields = append(updateFields, "slug")
 	}
 
+	if body.ParentID != nil {
+		// 0 moves the topic to the top level
+		var parentID *int
+		if *body.ParentID != 0 {
+			parentID = body.ParentID
+		}
+
+		if !sameParent(parentID, currentTopic.ParentID) {
+			if parentID != nil {
+				if err := u.checkParent(ctx, currentTopic.ID, *parentID); err != nil {
+					return err
+				}
+			}
+			updatedTopic.ParentID = parentID
+			updateFields = append(updateFields, "parent_id")
+		}
+	}
+
 	if len(updateFields) == 0 {
 		return exception.ErrNoFieldUpdate
 	}
 
+	if slices.Contains(updateFields, "slug") {
+		if err := u.checkRetiredSlug(ctx, updatedTopic.Slug, currentTopic.ID); err != nil {
+			return err
+		}
+	}
+
 	err = u.repo.UpdateTopicFileds(ctx, &updatedTopic, updateFields)
 	if err != nil {
+		if errors.Is(err, repository.ErrStaleVersion) {
+			return exception.ErrTopicVersionMismatch
+		}
 		if valid, hint := utils.IsDuplicateKey(err); valid {
 			return errors.New(hint)
 		}
@@ -104,7 +321,101 @@ func (u topicsUsecase) UpdateTopic(ctx context.Context, id int, body request.Upd
 	return nil
 }
 
-func (u topicsUsecase) DeleteTopic(ctx context.Context, id int) error {
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// checkParent makes sure parentID is a live topic that topicID may be moved
+// below, which rules out topicID itself and its descendants. topicID is 0 for
+// a topic that does not exist yet.
+func (u topicsUsecase) checkParent(ctx context.Context, topicID, parentID int) error {
+	if parentID == topicID {
+		return exception.ErrTopicCycle
+	}
+
+	if _, err := u.repo.GetByID(ctx, parentID); err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return exception.ErrParentTopicNotFound
+		}
+
+		log.Errorf("failed get parent topic: %s", err.Error())
+		return exception.ErrFailedGetTopic
+	}
+
+	if topicID == 0 {
+		return nil
+	}
+
+	ancestors, err := u.repo.GetAncestorIDs(ctx, parentID)
+	if err != nil {
+		log.Errorf("failed get topic ancestors: %s", err.Error())
+		return exception.ErrFailedGetTopic
+	}
+	if slices.Contains(ancestors, topicID) {
+		return exception.ErrTopicCycle
+	}
+
+	return nil
+}
+
+// newTopicSlug returns the slug requested by the client, or derives one from the
+// name that no topic uses or used before
+func (u topicsUsecase) newTopicSlug(ctx context.Context, requested, name string) (string, error) {
+	if requested != "" {
+		return requested, u.checkRetiredSlug(ctx, requested, 0)
+	}
+
+	base := utils.Slugify(name, topicSlugMaxLength)
+	if base == "" {
+		base = "topic"
+	}
+
+	taken, err := u.repo.GetTakenSlugs(ctx, base)
+	if err != nil {
+		log.Errorf("failed get taken slugs: %s", err.Error())
+		return "", exception.ErrFailedGetTopic
+	}
+
+	return utils.UniqueSlug(base, taken), nil
+}
+
+// checkRetiredSlug rejects slug when another topic used it before, topicID may
+// take back its own slugs
+func (u topicsUsecase) checkRetiredSlug(ctx context.Context, slug string, topicID int) error {
+	retired, err := u.repo.GetByRetiredSlug(ctx, slug)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return nil
+		}
+
+		log.Errorf("failed get retired slug: %s", err.Error())
+		return exception.ErrFailedGetTopic
+	}
+
+	if retired.OwnerID != topicID {
+		return exception.ErrTopicSlugRetired
+	}
+
+	return nil
+}
+
+// DeleteTopic refuses to delete a topic published articles are filed under,
+// unless force is set, which detaches the topic from every article as part of
+// the delete
+func (u topicsUsecase) DeleteTopic(
+	ctx context.Context,
+	actor dto.AuthUser,
+	id int,
+	expectedVersion *int,
+	force bool,
+) error {
+	if !canManageTopics(actor) {
+		return exception.ErrPermissionDenied
+	}
+
 	currentTopic, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		if notFound := utils.IsNoRowError(err); notFound {
@@ -115,11 +426,110 @@ func (u topicsUsecase) DeleteTopic(ctx context.Context, id int) error {
 		return exception.ErrFailedUpdateTopic
 	}
 
-	err = u.repo.Delete(ctx, currentTopic.ID)
+	if expectedVersion != nil && *expectedVersion != currentTopic.Version {
+		return exception.ErrTopicVersionMismatch
+	}
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		counts, err := u.repo.CountPublishedArticles(ctx, []int{currentTopic.ID})
+		if err != nil {
+			return err
+		}
+		if len(counts) > 0 && counts[0].Count > 0 && !force {
+			return exception.NewTopicInUseError(counts[0].Count)
+		}
+
+		if force {
+			if _, err := u.newsTopicsRepo.DetachTopic(ctx, currentTopic.ID); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.Delete(ctx, currentTopic.ID, currentTopic.Version)
+	})
 	if err != nil {
+		if customErr, ok := err.(exception.CustomError); ok && customErr.Code == exception.ErrTopicInUse.Code {
+			return err
+		}
+		if errors.Is(err, repository.ErrStaleVersion) {
+			return exception.ErrTopicVersionMismatch
+		}
+
 		log.Errorf("failed delete topic: %s", err.Error())
 		return exception.ErrFailedDeleteTopic
 	}
 
 	return nil
 }
+
+// MergeTopic moves the articles, subtopics and slugs of topic id to the target
+// topic and deletes topic id, all in one transaction
+func (u topicsUsecase) MergeTopic(
+	ctx context.Context,
+	actor dto.AuthUser,
+	id int,
+	body request.MergeTopicRequest,
+	expectedVersion *int,
+) (response.TopicMerge, error) {
+	if !canManageTopics(actor) {
+		return response.TopicMerge{}, exception.ErrPermissionDenied
+	}
+
+	if body.TargetID == id {
+		return response.TopicMerge{}, exception.ErrTopicMergeSelf
+	}
+
+	source, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.TopicMerge{}, exception.ErrTopicNotFound
+		}
+
+		log.Errorf("failed get topic: %s", err.Error())
+		return response.TopicMerge{}, exception.ErrFailedMergeTopic
+	}
+
+	if expectedVersion != nil && *expectedVersion != source.Version {
+		return response.TopicMerge{}, exception.ErrTopicVersionMismatch
+	}
+
+	if _, err := u.repo.GetByID(ctx, body.TargetID); err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.TopicMerge{}, exception.ErrMergeTargetNotFound
+		}
+
+		log.Errorf("failed get merge target: %s", err.Error())
+		return response.TopicMerge{}, exception.ErrFailedMergeTopic
+	}
+
+	// the subtopics of source move below the target, which must not be one of them
+	ancestors, err := u.repo.GetAncestorIDs(ctx, body.TargetID)
+	if err != nil {
+		log.Errorf("failed get topic ancestors: %s", err.Error())
+		return response.TopicMerge{}, exception.ErrFailedMergeTopic
+	}
+	if slices.Contains(ancestors, source.ID) {
+		return response.TopicMerge{}, exception.ErrTopicMergeSubtopic
+	}
+
+	res := response.TopicMerge{SourceID: source.ID, TargetID: body.TargetID}
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		moved, err := u.newsTopicsRepo.MoveTopic(ctx, source.ID, body.TargetID)
+		if err != nil {
+			return err
+		}
+		res.ArticlesMoved = moved
+
+		return u.repo.Merge(ctx, source, body.TargetID)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrStaleVersion) {
+			return response.TopicMerge{}, exception.ErrTopicVersionMismatch
+		}
+
+		log.Errorf("failed merge topic: %s", err.Error())
+		return response.TopicMerge{}, exception.ErrFailedMergeTopic
+	}
+
+	return res, nil
+}
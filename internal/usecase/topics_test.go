@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
 	"newsapi/internal/model/request"
 	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
 	"newsapi/internal/usecase"
 	"newsapi/internal/utils"
 	mock_repository "newsapi/mocks/repository"
@@ -19,16 +21,21 @@ import (
 )
 
 type TopicsAccessor struct {
-	topicRepo *mock_repository.MockTopicsRepository
-	topicUC   usecase.TopicsUsecase
+	topicRepo      *mock_repository.MockTopicsRepository
+	newsTopicsRepo *mock_repository.MockNewsTopicsRepository
+	topicUC        usecase.TopicsUsecase
 }
 
 func newTopicAccessor(ctrl *gomock.Controller) TopicsAccessor {
 	repo := mock_repository.NewMockTopicsRepository(ctrl)
-	topicUC := usecase.NewTopicsUsecase(repo)
+	newsTopicsRepo := mock_repository.NewMockNewsTopicsRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction).AnyTimes()
+	topicUC := usecase.NewTopicsUsecase(repo, newsTopicsRepo, transactor)
 	return TopicsAccessor{
-		topicRepo: repo,
-		topicUC:   topicUC,
+		topicRepo:      repo,
+		newsTopicsRepo: newsTopicsRepo,
+		topicUC:        topicUC,
 	}
 }
 
@@ -47,12 +54,14 @@ func Test_CreateTopic(t *testing.T) {
 	}
 	tests := []struct {
 		testname  string
+		req       request.CreateTopicRequest
 		initMock  func()
 		assertion func(err error)
 	}{
 		{
 			testname: "create topic and repository return err then uc return error",
 			initMock: func() {
+				topicRepo.EXPECT().GetByRetiredSlug(gomock.Any(), "test-1").Return(entity.RetiredSlug{}, sql.ErrNoRows)
 				topicRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("error"))
 			},
 			assertion: func(err error) {
@@ -62,18 +71,56 @@ func Test_CreateTopic(t *testing.T) {
 		{
 			testname: "success create topic then return err nil",
 			initMock: func() {
+				topicRepo.EXPECT().GetByRetiredSlug(gomock.Any(), "test-1").Return(entity.RetiredSlug{}, sql.ErrNoRows)
 				topicRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testname: "slug retired by another topic then return error",
+			initMock: func() {
+				topicRepo.EXPECT().GetByRetiredSlug(gomock.Any(), "test-1").Return(entity.RetiredSlug{Slug: "test-1", OwnerID: 9, CurrentSlug: "test-9"}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrTopicSlugRetired, err)
+			},
+		},
+		{
+			testname: "slug derived from the name with a suffix on collision",
+			req:      request.CreateTopicRequest{Name: "Science & Tech"},
+			initMock: func() {
+				topicRepo.EXPECT().GetTakenSlugs(gomock.Any(), "science-and-tech").Return([]string{"science-and-tech"}, nil)
+				topicRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, topic *entity.Topic) error {
+						assert.Equal(t, "science-and-tech-2", topic.Slug)
+						return nil
+					})
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "failed get retired slug then return error",
+			initMock: func() {
+				topicRepo.EXPECT().GetByRetiredSlug(gomock.Any(), "test-1").Return(entity.RetiredSlug{}, errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedGetTopic, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			err := topicUC.CreateTopic(ctx, mockReq)
+			req := mockReq
+			if tt.req.Name != "" {
+				req = tt.req
+			}
+			err := topicUC.CreateTopic(ctx, adminActor, req)
 			tt.assertion(err)
 		})
 	}
@@ -88,10 +135,14 @@ func Test_GetTopics(t *testing.T) {
 	topicRepo := accessor.topicRepo
 	ctx := context.Background()
 
+	pagination := dto.NewPagination(1, 2)
+	probe := dto.NewPagination(1, 3)
+
 	tests := []struct {
-		testname  string
-		initMock  func()
-		assertion func(topics []response.Topic, err error)
+		testname   string
+		withCounts bool
+		initMock   func()
+		assertion  func(topics []response.Topic, meta response.Pagination, err error)
 	}{
 		{
 			testname: "successful retrieval of multiple topics",
@@ -114,21 +165,79 @@ func Test_GetTopics(t *testing.T) {
 						UpdatedAt:   time.Now(),
 					},
 				}
-				topicRepo.EXPECT().GetAll(ctx).Return(mockTopics, nil)
+				topicRepo.EXPECT().GetAll(ctx, probe).Return(mockTopics, nil)
+				topicRepo.EXPECT().Count(ctx).Return(2, nil)
 			},
-			assertion: func(topics []response.Topic, err error) {
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
 				assert.NoError(t, err)
 				assert.Len(t, topics, 2)
 				assert.Equal(t, "Topic A", topics[0].Name)
 				assert.Equal(t, "topic-b", topics[1].Slug)
+				assert.Nil(t, topics[0].ArticleCount)
+				assert.Equal(t, response.Pagination{Page: 1, Limit: 2, Total: 2}, meta)
+			},
+		},
+		{
+			testname:   "with counts then empty topics count zero",
+			withCounts: true,
+			initMock: func() {
+				topicRepo.EXPECT().GetAll(ctx, probe).Return([]entity.Topic{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+				topicRepo.EXPECT().Count(ctx).Return(3, nil)
+				topicRepo.EXPECT().CountPublishedArticles(ctx, []int{1, 2}).
+					Return([]entity.TopicArticleCount{{TopicID: 2, Count: 4}}, nil)
+			},
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, topics, 2)
+				assert.Equal(t, 0, *topics[0].ArticleCount)
+				assert.Equal(t, 4, *topics[1].ArticleCount)
+			},
+		},
+		{
+			testname:   "with counts on an empty page skips counting",
+			withCounts: true,
+			initMock: func() {
+				topicRepo.EXPECT().GetAll(ctx, probe).Return([]entity.Topic{}, nil)
+				topicRepo.EXPECT().Count(ctx).Return(0, nil)
+			},
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, topics)
+			},
+		},
+		{
+			testname:   "counting articles fails",
+			withCounts: true,
+			initMock: func() {
+				topicRepo.EXPECT().GetAll(ctx, probe).Return([]entity.Topic{{ID: 1}}, nil)
+				topicRepo.EXPECT().Count(ctx).Return(1, nil)
+				topicRepo.EXPECT().CountPublishedArticles(ctx, []int{1}).Return(nil, errors.New("database error"))
+			},
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetTopic, err)
+				assert.Nil(t, topics)
+			},
+		},
+		{
+			testname: "extra row then return next cursor",
+			initMock: func() {
+				topicRepo.EXPECT().GetAll(ctx, probe).Return([]entity.Topic{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+				topicRepo.EXPECT().Count(ctx).Return(5, nil)
+			},
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, topics, 2)
+				assert.Equal(t, dto.Cursor{ID: 2}.Encode(), meta.NextCursor)
+				assert.Equal(t, 5, meta.Total)
 			},
 		},
 		{
 			testname: "successful retrieval of no topics (empty slice)",
 			initMock: func() {
-				topicRepo.EXPECT().GetAll(ctx).Return([]entity.Topic{}, nil)
+				topicRepo.EXPECT().GetAll(ctx, probe).Return([]entity.Topic{}, nil)
+				topicRepo.EXPECT().Count(ctx).Return(0, nil)
 			},
-			assertion: func(topics []response.Topic, err error) {
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
 				assert.NoError(t, err)
 				assert.Len(t, topics, 0)
 				assert.Empty(t, topics)
@@ -137,21 +246,31 @@ func Test_GetTopics(t *testing.T) {
 		{
 			testname: "repository returns an error",
 			initMock: func() {
-				topicRepo.EXPECT().GetAll(ctx).Return(nil, errors.New("database connection lost"))
+				topicRepo.EXPECT().GetAll(ctx, probe).Return(nil, errors.New("database connection lost"))
 			},
-			assertion: func(topics []response.Topic, err error) {
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, topics)
 				assert.Equal(t, exception.ErrFailedGetTopic, err)
 			},
 		},
+		{
+			testname: "count returns an error",
+			initMock: func() {
+				topicRepo.EXPECT().GetAll(ctx, probe).Return([]entity.Topic{}, nil)
+				topicRepo.EXPECT().Count(ctx).Return(0, errors.New("database connection lost"))
+			},
+			assertion: func(topics []response.Topic, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetTopic, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			topics, err := topicUC.GetTopics(ctx)
-			tt.assertion(topics, err)
+			topics, meta, err := topicUC.GetTopics(ctx, pagination, tt.withCounts)
+			tt.assertion(topics, meta, err)
 		})
 	}
 }
@@ -267,6 +386,7 @@ func Test_UpdateTopic(t *testing.T) {
 					Description: utils.StringPtr("Desc 4"),
 					Slug:        "topic-4",
 				}, nil)
+				topicRepo.EXPECT().GetByRetiredSlug(ctx, "new-topic-4-slug").Return(entity.RetiredSlug{}, sql.ErrNoRows)
 				topicRepo.EXPECT().UpdateTopicFileds(ctx, gomock.Any(), []string{"slug"}).Return(nil)
 			},
 			assertion: func(err error) {
@@ -294,6 +414,7 @@ func Test_UpdateTopic(t *testing.T) {
 					Description: utils.StringPtr("Old Description 5"),
 					Slug:        "old-slug-5",
 				}, nil)
+				topicRepo.EXPECT().GetByRetiredSlug(ctx, "updated-slug-5").Return(entity.RetiredSlug{Slug: "updated-slug-5", OwnerID: 5}, nil)
 				topicRepo.EXPECT().UpdateTopicFileds(ctx, gomock.Any(), gomock.InAnyOrder([]string{"name", "description", "slug"})).Return(nil)
 			},
 			assertion: func(err error) {
@@ -380,6 +501,7 @@ func Test_UpdateTopic(t *testing.T) {
 					Description: utils.StringPtr("Desc 7"),
 					Slug:        "topic-7",
 				}, nil)
+				topicRepo.EXPECT().GetByRetiredSlug(ctx, "duplicate-slug").Return(entity.RetiredSlug{}, sql.ErrNoRows)
 				topicRepo.EXPECT().UpdateTopicFileds(ctx, gomock.Any(), []string{"slug"}).
 					Return(errors.New("pq: duplicate key value violates unique constraint \"topics_slug_key\""))
 			},
@@ -388,6 +510,20 @@ func Test_UpdateTopic(t *testing.T) {
 				assert.Equal(t, "failed update topic", err.Error())
 			},
 		},
+		{
+			testname: "slug retired by another topic",
+			mockID:   9,
+			mockReq: request.UpdateTopicRequest{
+				Slug: utils.StringPtr("retired-slug"),
+			},
+			initMock: func() {
+				topicRepo.EXPECT().GetByID(ctx, 9).Return(entity.Topic{ID: 9, Name: "Topic 9", Slug: "topic-9"}, nil)
+				topicRepo.EXPECT().GetByRetiredSlug(ctx, "retired-slug").Return(entity.RetiredSlug{Slug: "retired-slug", OwnerID: 3}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrTopicSlugRetired, err)
+			},
+		},
 		{
 			testname: "failed to update topic - generic error",
 			mockID:   8,
@@ -419,7 +555,7 @@ func Test_UpdateTopic(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock()
-			err := topicUC.UpdateTopic(ctx, tt.mockID, tt.mockReq)
+			err := topicUC.UpdateTopic(ctx, adminActor, tt.mockID, tt.mockReq, nil)
 			tt.assertion(err)
 		})
 	}
@@ -434,9 +570,12 @@ func Test_DeleteTopic(t *testing.T) {
 	topicRepo := accessor.topicRepo
 	ctx := context.Background()
 
+	newsTopicsRepo := accessor.newsTopicsRepo
+
 	tests := []struct {
 		testname  string
 		id        int
+		force     bool
 		initMock  func(id int)
 		assertion func(err error)
 	}{
@@ -470,7 +609,8 @@ func Test_DeleteTopic(t *testing.T) {
 					ID:   1,
 					Name: "test topic",
 				}, nil)
-				topicRepo.EXPECT().Delete(gomock.Any(), id).Return(errors.New("failed update"))
+				topicRepo.EXPECT().CountPublishedArticles(gomock.Any(), []int{id}).Return(nil, nil)
+				topicRepo.EXPECT().Delete(gomock.Any(), id, 0).Return(errors.New("failed update"))
 			},
 			assertion: func(err error) {
 				assert.Error(t, err)
@@ -485,19 +625,560 @@ func Test_DeleteTopic(t *testing.T) {
 					ID:   1,
 					Name: "test topic",
 				}, nil)
-				topicRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
+				topicRepo.EXPECT().CountPublishedArticles(gomock.Any(), []int{id}).Return([]entity.TopicArticleCount{}, nil)
+				topicRepo.EXPECT().Delete(gomock.Any(), id, 0).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "published news use the topic then return conflict with the count",
+			id:       2,
+			initMock: func(id int) {
+				topicRepo.EXPECT().GetByID(gomock.Any(), id).Return(entity.Topic{ID: 2}, nil)
+				topicRepo.EXPECT().CountPublishedArticles(gomock.Any(), []int{id}).
+					Return([]entity.TopicArticleCount{{TopicID: 2, Count: 3}}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.NewTopicInUseError(3), err)
+				assert.Contains(t, err.Error(), "used by 3 published news")
+			},
+		},
+		{
+			testname: "force detaches the news before deleting",
+			id:       3,
+			force:    true,
+			initMock: func(id int) {
+				topicRepo.EXPECT().GetByID(gomock.Any(), id).Return(entity.Topic{ID: 3}, nil)
+				gomock.InOrder(
+					topicRepo.EXPECT().CountPublishedArticles(gomock.Any(), []int{id}).
+						Return([]entity.TopicArticleCount{{TopicID: 3, Count: 3}}, nil),
+					newsTopicsRepo.EXPECT().DetachTopic(gomock.Any(), id).Return(5, nil),
+					topicRepo.EXPECT().Delete(gomock.Any(), id, 0).Return(nil),
+				)
 			},
 			assertion: func(err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testname: "detaching fails then nothing is deleted",
+			id:       4,
+			force:    true,
+			initMock: func(id int) {
+				topicRepo.EXPECT().GetByID(gomock.Any(), id).Return(entity.Topic{ID: 4}, nil)
+				topicRepo.EXPECT().CountPublishedArticles(gomock.Any(), []int{id}).Return(nil, nil)
+				newsTopicsRepo.EXPECT().DetachTopic(gomock.Any(), id).Return(0, errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedDeleteTopic, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
 			tt.initMock(tt.id)
-			err := topicUC.DeleteTopic(ctx, tt.id)
+			err := topicUC.DeleteTopic(ctx, adminActor, tt.id, nil, tt.force)
 			tt.assertion(err)
 		})
 	}
 }
+
+func Test_TopicPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicAccessor(ctrl)
+	topicUC := accessor.topicUC
+	ctx := context.Background()
+
+	for _, role := range []entity.UserRole{entity.RoleAuthor, entity.RoleReader} {
+		actor := dto.AuthUser{ID: 2, Role: role}
+
+		t.Run(string(role)+" cannot manage topics", func(t *testing.T) {
+			err := topicUC.CreateTopic(ctx, actor, request.CreateTopicRequest{Name: "test", Slug: "test-1"})
+			assert.Equal(t, exception.ErrPermissionDenied, err)
+
+			err = topicUC.UpdateTopic(ctx, actor, 1, request.UpdateTopicRequest{Name: utils.StringPtr("New Name")}, nil)
+			assert.Equal(t, exception.ErrPermissionDenied, err)
+
+			err = topicUC.DeleteTopic(ctx, actor, 1, nil, false)
+			assert.Equal(t, exception.ErrPermissionDenied, err)
+		})
+	}
+}
+
+func Test_TopicVersionCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicAccessor(ctrl)
+	topicRepo := accessor.topicRepo
+	topicUC := accessor.topicUC
+	ctx := context.Background()
+	topic := entity.Topic{ID: 1, Name: "Topic", Slug: "topic", Version: 5}
+
+	t.Run("update with a stale version", func(t *testing.T) {
+		topicRepo.EXPECT().GetByID(ctx, 1).Return(topic, nil)
+
+		err := topicUC.UpdateTopic(ctx, adminActor, 1, request.UpdateTopicRequest{Name: utils.StringPtr("New Name")}, utils.IntPtr(4))
+		assert.Equal(t, exception.ErrTopicVersionMismatch, err)
+	})
+
+	t.Run("topic changed between read and write", func(t *testing.T) {
+		topicRepo.EXPECT().GetByID(ctx, 1).Return(topic, nil)
+		topicRepo.EXPECT().UpdateTopicFileds(ctx, gomock.Any(), []string{"name"}).Return(repository.ErrStaleVersion)
+
+		err := topicUC.UpdateTopic(ctx, adminActor, 1, request.UpdateTopicRequest{Name: utils.StringPtr("New Name")}, utils.IntPtr(5))
+		assert.Equal(t, exception.ErrTopicVersionMismatch, err)
+	})
+
+	t.Run("delete with a stale version", func(t *testing.T) {
+		topicRepo.EXPECT().GetByID(ctx, 1).Return(topic, nil)
+
+		err := topicUC.DeleteTopic(ctx, adminActor, 1, utils.IntPtr(4), false)
+		assert.Equal(t, exception.ErrTopicVersionMismatch, err)
+	})
+
+	t.Run("topic changed between read and delete", func(t *testing.T) {
+		topicRepo.EXPECT().GetByID(ctx, 1).Return(topic, nil)
+		topicRepo.EXPECT().CountPublishedArticles(ctx, []int{1}).Return(nil, nil)
+		topicRepo.EXPECT().Delete(ctx, 1, 5).Return(repository.ErrStaleVersion)
+
+		err := topicUC.DeleteTopic(ctx, adminActor, 1, utils.IntPtr(5), false)
+		assert.Equal(t, exception.ErrTopicVersionMismatch, err)
+	})
+
+	t.Run("get topic exposes its version", func(t *testing.T) {
+		topicRepo.EXPECT().GetByID(ctx, 1).Return(topic, nil)
+		topicRepo.EXPECT().CountPublishedArticles(ctx, []int{1}).Return(nil, nil)
+		topicRepo.EXPECT().GetLatestPublished(ctx, 1, usecase.DefaultTopicLatest).Return(nil, nil)
+
+		res, err := topicUC.GetTopic(ctx, "1", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, res.Version)
+	})
+}
+
+func Test_GetTopicDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	sports := entity.Topic{ID: 3, Name: "Sports", Slug: "sports", Version: 2}
+	published := sql.NullTime{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	tests := []struct {
+		testname  string
+		key       string
+		latest    int
+		initMock  func(repo *mock_repository.MockTopicsRepository)
+		assertion func(res response.TopicDetail, err error)
+	}{
+		{
+			testname: "by slug with count and latest articles",
+			key:      "sports",
+			latest:   2,
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetBySlug(ctx, "sports").Return(sports, nil)
+				repo.EXPECT().CountPublishedArticles(ctx, []int{3}).
+					Return([]entity.TopicArticleCount{{TopicID: 3, Count: 7}}, nil)
+				repo.EXPECT().GetLatestPublished(ctx, 3, 2).Return([]entity.PublishedNewsWithTopic{
+					{ID: 9, Title: "Final", Slug: "final", PublishedAt: published, Topics: []string{"Sports"}},
+					{ID: 8, Title: "Semi", Slug: "semi", PublishedAt: published},
+				}, nil)
+			},
+			assertion: func(res response.TopicDetail, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "sports", res.Slug)
+				assert.Equal(t, 7, *res.ArticleCount)
+				assert.Len(t, res.LatestArticles, 2)
+				assert.Equal(t, "final", res.LatestArticles[0].Slug)
+				assert.Equal(t, []string{}, res.LatestArticles[1].Topics)
+			},
+		},
+		{
+			testname: "by id without articles",
+			key:      "3",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 3).Return(sports, nil)
+				repo.EXPECT().CountPublishedArticles(ctx, []int{3}).Return([]entity.TopicArticleCount{}, nil)
+				repo.EXPECT().GetLatestPublished(ctx, 3, usecase.DefaultTopicLatest).Return([]entity.PublishedNewsWithTopic{}, nil)
+			},
+			assertion: func(res response.TopicDetail, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, *res.ArticleCount)
+				assert.Equal(t, []response.ArticleSummary{}, res.LatestArticles)
+			},
+		},
+		{
+			testname: "latest above the maximum is capped",
+			key:      "sports",
+			latest:   500,
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetBySlug(ctx, "sports").Return(sports, nil)
+				repo.EXPECT().CountPublishedArticles(ctx, []int{3}).Return(nil, nil)
+				repo.EXPECT().GetLatestPublished(ctx, 3, usecase.MaxTopicLatest).Return(nil, nil)
+			},
+			assertion: func(res response.TopicDetail, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "unknown slug",
+			key:      "nope",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetBySlug(ctx, "nope").Return(entity.Topic{}, sql.ErrNoRows)
+			},
+			assertion: func(res response.TopicDetail, err error) {
+				assert.Equal(t, exception.ErrTopicNotFound, err)
+			},
+		},
+		{
+			testname: "latest articles fail",
+			key:      "3",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 3).Return(sports, nil)
+				repo.EXPECT().CountPublishedArticles(ctx, []int{3}).Return(nil, nil)
+				repo.EXPECT().GetLatestPublished(ctx, 3, usecase.DefaultTopicLatest).Return(nil, errors.New("database error"))
+			},
+			assertion: func(res response.TopicDetail, err error) {
+				assert.Equal(t, exception.ErrFailedGetTopic, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			accessor := newTopicAccessor(ctrl)
+			tt.initMock(accessor.topicRepo)
+
+			res, err := accessor.topicUC.GetTopic(ctx, tt.key, tt.latest)
+			tt.assertion(res, err)
+		})
+	}
+}
+
+func Test_ResolveTopicSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		testname string
+		retired  entity.RetiredSlug
+		err      error
+		expected string
+		wantErr  error
+	}{
+		{
+			testname: "renamed topic",
+			retired:  entity.RetiredSlug{Slug: "soccer", OwnerID: 2, CurrentSlug: "football"},
+			expected: "football",
+		},
+		{
+			testname: "renamed topic was deleted",
+			retired:  entity.RetiredSlug{Slug: "soccer", OwnerID: 2, CurrentSlug: "football", DeletedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+			wantErr:  exception.ErrTopicNotFound,
+		},
+		{
+			testname: "slug never used",
+			err:      sql.ErrNoRows,
+			wantErr:  exception.ErrTopicNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			accessor := newTopicAccessor(ctrl)
+			accessor.topicRepo.EXPECT().GetByRetiredSlug(ctx, "soccer").Return(tt.retired, tt.err)
+
+			current, err := accessor.topicUC.ResolveTopicSlug(ctx, "soccer")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.expected, current)
+		})
+	}
+}
+
+func Test_TopicHierarchy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	football := entity.Topic{ID: 2, Name: "Football", Slug: "football", ParentID: utils.IntPtr(1), Version: 1}
+
+	tests := []struct {
+		testname  string
+		initMock  func(repo *mock_repository.MockTopicsRepository)
+		action    func(uc usecase.TopicsUsecase) error
+		assertion func(err error)
+	}{
+		{
+			testname: "create below a missing parent",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 9).Return(entity.Topic{}, sql.ErrNoRows)
+			},
+			action: func(uc usecase.TopicsUsecase) error {
+				return uc.CreateTopic(ctx, adminActor, request.CreateTopicRequest{Name: "Tennis", Slug: "tennis", ParentID: utils.IntPtr(9)})
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrParentTopicNotFound, err)
+			},
+		},
+		{
+			testname: "topic cannot become its own parent",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 2).Return(football, nil)
+			},
+			action: func(uc usecase.TopicsUsecase) error {
+				return uc.UpdateTopic(ctx, adminActor, 2, request.UpdateTopicRequest{ParentID: utils.IntPtr(2)}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrTopicCycle, err)
+			},
+		},
+		{
+			testname: "topic cannot move below its own descendant",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 2).Return(football, nil)
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.Topic{ID: 5, ParentID: utils.IntPtr(2)}, nil)
+				repo.EXPECT().GetAncestorIDs(ctx, 5).Return([]int{5, 2, 1}, nil)
+			},
+			action: func(uc usecase.TopicsUsecase) error {
+				return uc.UpdateTopic(ctx, adminActor, 2, request.UpdateTopicRequest{ParentID: utils.IntPtr(5)}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrTopicCycle, err)
+			},
+		},
+		{
+			testname: "move below another branch",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 2).Return(football, nil)
+				repo.EXPECT().GetByID(ctx, 7).Return(entity.Topic{ID: 7}, nil)
+				repo.EXPECT().GetAncestorIDs(ctx, 7).Return([]int{7}, nil)
+				repo.EXPECT().UpdateTopicFileds(ctx, gomock.Any(), []string{"parent_id"}).
+					DoAndReturn(func(_ context.Context, topic *entity.Topic, _ []string) error {
+						assert.Equal(t, utils.IntPtr(7), topic.ParentID)
+						return nil
+					})
+			},
+			action: func(uc usecase.TopicsUsecase) error {
+				return uc.UpdateTopic(ctx, adminActor, 2, request.UpdateTopicRequest{ParentID: utils.IntPtr(7)}, nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "parent_id 0 moves the topic to the top level",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 2).Return(football, nil)
+				repo.EXPECT().UpdateTopicFileds(ctx, gomock.Any(), []string{"parent_id"}).
+					DoAndReturn(func(_ context.Context, topic *entity.Topic, _ []string) error {
+						assert.Nil(t, topic.ParentID)
+						return nil
+					})
+			},
+			action: func(uc usecase.TopicsUsecase) error {
+				return uc.UpdateTopic(ctx, adminActor, 2, request.UpdateTopicRequest{ParentID: utils.IntPtr(0)}, nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testname: "same parent updates nothing",
+			initMock: func(repo *mock_repository.MockTopicsRepository) {
+				repo.EXPECT().GetByID(ctx, 2).Return(football, nil)
+			},
+			action: func(uc usecase.TopicsUsecase) error {
+				return uc.UpdateTopic(ctx, adminActor, 2, request.UpdateTopicRequest{ParentID: utils.IntPtr(1)}, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNoFieldUpdate, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			accessor := newTopicAccessor(ctrl)
+			tt.initMock(accessor.topicRepo)
+			err := tt.action(accessor.topicUC)
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_GetTopicTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicAccessor(ctrl)
+	ctx := context.Background()
+
+	t.Run("nests topics below their parents", func(t *testing.T) {
+		accessor.topicRepo.EXPECT().GetHierarchy(ctx).Return([]entity.Topic{
+			{ID: 3, Name: "Champions League", Slug: "champions-league", ParentID: utils.IntPtr(2)},
+			{ID: 2, Name: "Football", Slug: "football", ParentID: utils.IntPtr(1)},
+			{ID: 4, Name: "Politics", Slug: "politics"},
+			{ID: 1, Name: "Sports", Slug: "sports"},
+			// the parent is in the trash
+			{ID: 6, Name: "Tennis", Slug: "tennis", ParentID: utils.IntPtr(5)},
+		}, nil)
+
+		tree, err := accessor.topicUC.GetTopicTree(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []response.TopicNode{
+			{ID: 4, Name: "Politics", Slug: "politics", Children: []response.TopicNode{}},
+			{ID: 1, Name: "Sports", Slug: "sports", Children: []response.TopicNode{
+				{ID: 2, Name: "Football", Slug: "football", Children: []response.TopicNode{
+					{ID: 3, Name: "Champions League", Slug: "champions-league", Children: []response.TopicNode{}},
+				}},
+			}},
+			{ID: 6, Name: "Tennis", Slug: "tennis", Children: []response.TopicNode{}},
+		}, tree)
+	})
+
+	t.Run("failed get hierarchy", func(t *testing.T) {
+		accessor.topicRepo.EXPECT().GetHierarchy(ctx).Return(nil, errors.New("db error"))
+
+		_, err := accessor.topicUC.GetTopicTree(ctx)
+		assert.Equal(t, exception.ErrFailedGetTopic, err)
+	})
+}
+
+func Test_MergeTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tech := entity.Topic{ID: 2, Name: "Tech", Slug: "tech", Version: 3}
+	technology := entity.Topic{ID: 5, Name: "Technology", Slug: "technology", Version: 1}
+	body := request.MergeTopicRequest{TargetID: 5}
+
+	tests := []struct {
+		testname        string
+		actor           dto.AuthUser
+		body            request.MergeTopicRequest
+		expectedVersion *int
+		initMock        func(accessor TopicsAccessor)
+		assertion       func(res response.TopicMerge, err error)
+	}{
+		{
+			testname: "moves links and retires the source",
+			actor:    adminActor,
+			body:     body,
+			initMock: func(accessor TopicsAccessor) {
+				accessor.topicRepo.EXPECT().GetByID(ctx, 2).Return(tech, nil)
+				accessor.topicRepo.EXPECT().GetByID(ctx, 5).Return(technology, nil)
+				accessor.topicRepo.EXPECT().GetAncestorIDs(ctx, 5).Return([]int{5}, nil)
+				accessor.newsTopicsRepo.EXPECT().MoveTopic(ctx, 2, 5).Return(4, nil)
+				accessor.topicRepo.EXPECT().Merge(ctx, tech, 5).Return(nil)
+			},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, response.TopicMerge{SourceID: 2, TargetID: 5, ArticlesMoved: 4}, res)
+			},
+		},
+		{
+			testname: "authors cannot merge topics",
+			actor:    dto.AuthUser{ID: 3, Role: entity.RoleAuthor},
+			body:     body,
+			initMock: func(accessor TopicsAccessor) {},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "merge into itself",
+			actor:    adminActor,
+			body:     request.MergeTopicRequest{TargetID: 2},
+			initMock: func(accessor TopicsAccessor) {},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrTopicMergeSelf, err)
+			},
+		},
+		{
+			testname:        "stale source version",
+			actor:           adminActor,
+			body:            body,
+			expectedVersion: utils.IntPtr(2),
+			initMock: func(accessor TopicsAccessor) {
+				accessor.topicRepo.EXPECT().GetByID(ctx, 2).Return(tech, nil)
+			},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrTopicVersionMismatch, err)
+			},
+		},
+		{
+			testname: "unknown target",
+			actor:    adminActor,
+			body:     body,
+			initMock: func(accessor TopicsAccessor) {
+				accessor.topicRepo.EXPECT().GetByID(ctx, 2).Return(tech, nil)
+				accessor.topicRepo.EXPECT().GetByID(ctx, 5).Return(entity.Topic{}, sql.ErrNoRows)
+			},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrMergeTargetNotFound, err)
+			},
+		},
+		{
+			testname: "target is a subtopic of the source",
+			actor:    adminActor,
+			body:     body,
+			initMock: func(accessor TopicsAccessor) {
+				accessor.topicRepo.EXPECT().GetByID(ctx, 2).Return(tech, nil)
+				accessor.topicRepo.EXPECT().GetByID(ctx, 5).Return(technology, nil)
+				accessor.topicRepo.EXPECT().GetAncestorIDs(ctx, 5).Return([]int{5, 2}, nil)
+			},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrTopicMergeSubtopic, err)
+			},
+		},
+		{
+			testname: "source changed inside the transaction",
+			actor:    adminActor,
+			body:     body,
+			initMock: func(accessor TopicsAccessor) {
+				accessor.topicRepo.EXPECT().GetByID(ctx, 2).Return(tech, nil)
+				accessor.topicRepo.EXPECT().GetByID(ctx, 5).Return(technology, nil)
+				accessor.topicRepo.EXPECT().GetAncestorIDs(ctx, 5).Return([]int{5}, nil)
+				accessor.newsTopicsRepo.EXPECT().MoveTopic(ctx, 2, 5).Return(4, nil)
+				accessor.topicRepo.EXPECT().Merge(ctx, tech, 5).Return(repository.ErrStaleVersion)
+			},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrTopicVersionMismatch, err)
+				assert.Equal(t, response.TopicMerge{}, res)
+			},
+		},
+		{
+			testname: "moving links fails",
+			actor:    adminActor,
+			body:     body,
+			initMock: func(accessor TopicsAccessor) {
+				accessor.topicRepo.EXPECT().GetByID(ctx, 2).Return(tech, nil)
+				accessor.topicRepo.EXPECT().GetByID(ctx, 5).Return(technology, nil)
+				accessor.topicRepo.EXPECT().GetAncestorIDs(ctx, 5).Return([]int{5}, nil)
+				accessor.newsTopicsRepo.EXPECT().MoveTopic(ctx, 2, 5).Return(0, errors.New("db error"))
+			},
+			assertion: func(res response.TopicMerge, err error) {
+				assert.Equal(t, exception.ErrFailedMergeTopic, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			accessor := newTopicAccessor(ctrl)
+			tt.initMock(accessor)
+
+			res, err := accessor.topicUC.MergeTopic(ctx, tt.actor, 2, tt.body, tt.expectedVersion)
+			tt.assertion(res, err)
+		})
+	}
+}
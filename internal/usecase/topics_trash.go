@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+func (u topicsUsecase) GetTrashedTopics(
+	ctx context.Context,
+	actor dto.AuthUser,
+	pagination dto.Pagination,
+) ([]response.Topic, response.Pagination, error) {
+	if !canManageTrash(actor) {
+		return nil, response.Pagination{}, exception.ErrPermissionDenied
+	}
+
+	topics, err := u.repo.GetTrashed(ctx, pagination)
+	if err != nil {
+		log.Errorf("failed get trashed topic: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetTopic
+	}
+
+	total, err := u.repo.CountTrashed(ctx)
+	if err != nil {
+		log.Errorf("failed count trashed topic: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetTopic
+	}
+
+	res := make([]response.Topic, 0, len(topics))
+	for _, t := range topics {
+		res = append(res, response.TopicSeriliazer(t))
+	}
+
+	return res, response.PaginationSerializer(pagination, total), nil
+}
+
+func (u topicsUsecase) RestoreTopic(ctx context.Context, actor dto.AuthUser, id int) error {
+	if !canManageTrash(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	restored, err := u.repo.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTopicMerged) {
+			return exception.ErrTopicMerged
+		}
+		log.Errorf("failed restore topic: %v", err)
+		return exception.ErrFailedRestoreTopic
+	}
+	if !restored {
+		return exception.ErrTopicNotInTrash
+	}
+
+	return nil
+}
+
+// PurgeTopic deletes a trashed topic for good, live topics have to be deleted
+// first
+func (u topicsUsecase) PurgeTopic(ctx context.Context, actor dto.AuthUser, id int) error {
+	if !canManageTrash(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	purged, err := u.repo.Purge(ctx, id)
+	if err != nil {
+		log.Errorf("failed purge topic: %v", err)
+		return exception.ErrFailedPurgeTopic
+	}
+	if !purged {
+		return exception.ErrTopicNotInTrash
+	}
+
+	return nil
+}
+
+// PurgeTrashedTopics deletes for good every topic trashed before the given
+// time, batchSize rows at a time, and returns how many were purged
+func (u topicsUsecase) PurgeTrashedTopics(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	purged := 0
+	for {
+		ids, err := u.repo.PurgeTrashed(ctx, before, batchSize)
+		if err != nil {
+			log.Errorf("failed purge trashed topic: %v", err)
+			return purged, exception.ErrFailedPurgeTopic
+		}
+
+		purged += len(ids)
+		if len(ids) < batchSize {
+			return purged, nil
+		}
+	}
+}
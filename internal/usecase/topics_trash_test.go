@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
+	"newsapi/internal/model/response"
+	"newsapi/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTrashedTopics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicAccessor(ctrl)
+	topicUC := accessor.topicUC
+	topicRepo := accessor.topicRepo
+	ctx := context.Background()
+
+	pagination := dto.NewPagination(1, 10)
+	deletedAt := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(res []response.Topic, meta response.Pagination, err error)
+	}{
+		{
+			testname: "editor is not allowed",
+			actor:    dto.AuthUser{ID: 5, Role: entity.RoleEditor},
+			initMock: func() {},
+			assertion: func(res []response.Topic, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "failed get trashed topics",
+			actor:    adminActor,
+			initMock: func() {
+				topicRepo.EXPECT().GetTrashed(ctx, pagination).Return(nil, errors.New("db error"))
+			},
+			assertion: func(res []response.Topic, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetTopic, err)
+			},
+		},
+		{
+			testname: "success get trashed topics",
+			actor:    adminActor,
+			initMock: func() {
+				topicRepo.EXPECT().GetTrashed(ctx, pagination).Return([]entity.Topic{
+					{ID: 2, Name: "Sport", Slug: "sport", DeletedAt: sql.NullTime{Time: deletedAt, Valid: true}},
+				}, nil)
+				topicRepo.EXPECT().CountTrashed(ctx).Return(1, nil)
+			},
+			assertion: func(res []response.Topic, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []response.Topic{{ID: 2, Name: "Sport", Slug: "sport", DeletedAt: &deletedAt}}, res)
+				assert.Equal(t, response.Pagination{Page: 1, Limit: 10, Total: 1}, meta)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			res, meta, err := topicUC.GetTrashedTopics(ctx, tt.actor, pagination)
+			tt.assertion(res, meta, err)
+		})
+	}
+}
+
+func Test_RestoreAndPurgeTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicAccessor(ctrl)
+	topicUC := accessor.topicUC
+	topicRepo := accessor.topicRepo
+	ctx := context.Background()
+
+	editor := dto.AuthUser{ID: 5, Role: entity.RoleEditor}
+
+	assert.Equal(t, exception.ErrPermissionDenied, topicUC.RestoreTopic(ctx, editor, 2))
+	assert.Equal(t, exception.ErrPermissionDenied, topicUC.PurgeTopic(ctx, editor, 2))
+
+	topicRepo.EXPECT().Restore(ctx, 2).Return(false, nil)
+	assert.Equal(t, exception.ErrTopicNotInTrash, topicUC.RestoreTopic(ctx, adminActor, 2))
+
+	topicRepo.EXPECT().Restore(ctx, 2).Return(false, errors.New("db error"))
+	assert.Equal(t, exception.ErrFailedRestoreTopic, topicUC.RestoreTopic(ctx, adminActor, 2))
+
+	topicRepo.EXPECT().Restore(ctx, 2).Return(false, repository.ErrTopicMerged)
+	assert.Equal(t, exception.ErrTopicMerged, topicUC.RestoreTopic(ctx, adminActor, 2))
+
+	topicRepo.EXPECT().Restore(ctx, 2).Return(true, nil)
+	assert.NoError(t, topicUC.RestoreTopic(ctx, adminActor, 2))
+
+	topicRepo.EXPECT().Purge(ctx, 2).Return(false, nil)
+	assert.Equal(t, exception.ErrTopicNotInTrash, topicUC.PurgeTopic(ctx, adminActor, 2))
+
+	topicRepo.EXPECT().Purge(ctx, 2).Return(false, errors.New("db error"))
+	assert.Equal(t, exception.ErrFailedPurgeTopic, topicUC.PurgeTopic(ctx, adminActor, 2))
+
+	topicRepo.EXPECT().Purge(ctx, 2).Return(true, nil)
+	assert.NoError(t, topicUC.PurgeTopic(ctx, adminActor, 2))
+}
+
+func Test_PurgeTrashedTopics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newTopicAccessor(ctrl)
+	topicUC := accessor.topicUC
+	topicRepo := accessor.topicRepo
+	ctx := context.Background()
+
+	before := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		topicRepo.EXPECT().PurgeTrashed(ctx, before, 2).Return([]int{1, 2}, nil),
+		topicRepo.EXPECT().PurgeTrashed(ctx, before, 2).Return(nil, errors.New("db error")),
+	)
+
+	purged, err := topicUC.PurgeTrashedTopics(ctx, before, 2)
+	assert.Equal(t, exception.ErrFailedPurgeTopic, err)
+	assert.Equal(t, 2, purged)
+}
@@ -5,23 +5,57 @@ import (
 	"newsapi/internal/model/dto"
 	"newsapi/internal/model/request"
 	"newsapi/internal/model/response"
+	"time"
 )
 
 type UsersUsecase interface {
 	CreateUser(ctx context.Context, body request.CreateUserRequest) error
+	UpdateUserRole(ctx context.Context, actor dto.AuthUser, id int, body request.UpdateUserRoleRequest) error
+	GetUser(ctx context.Context, actor dto.AuthUser, id int) (response.User, error)
+	GetUsers(ctx context.Context, actor dto.AuthUser, pagination dto.Pagination) ([]response.User, response.Pagination, error)
+	UpdateUser(ctx context.Context, actor dto.AuthUser, id int, body request.UpdateUserRequest) error
+	DeactivateUser(ctx context.Context, actor dto.AuthUser, id int) error
+}
+
+type AuthUsecase interface {
+	Login(ctx context.Context, body request.LoginRequest) (response.AuthToken, error)
+	RefreshToken(ctx context.Context, body request.RefreshTokenRequest) (response.AuthToken, error)
+	Actor(ctx context.Context, userID int) (dto.AuthUser, error)
 }
 
 type NewsUsecase interface {
-	CreateNewsArticle(ctx context.Context, body request.CreateNewsArticleRequest) error
-	GetNewsArticles(ctx context.Context, filter dto.NewsFilter) ([]response.NewsArticle, error)
-	GetNewsArticleBySlug(ctx context.Context, slug string) (response.NewsArticleWithTopic, error)
-	UpdateNewsArticleBySlug(ctx context.Context, slug string, body request.UpdateNewsArticleRequest) error
-	DeleteNewsArticleBySlug(ctx context.Context, slug string) error
+	CreateNewsArticle(ctx context.Context, actor dto.AuthUser, body request.CreateNewsArticleRequest) error
+	GetNewsArticles(ctx context.Context, viewer dto.AuthUser, filter dto.NewsFilter) ([]response.NewsArticle, response.Pagination, error)
+	GetNewsArticleBySlug(ctx context.Context, viewer dto.AuthUser, slug string) (response.NewsArticleWithTopic, error)
+	ResolveNewsSlug(ctx context.Context, viewer dto.AuthUser, slug string) (string, error)
+	GetAuthorArticles(ctx context.Context, authorID int, pagination dto.Pagination) (response.AuthorProfile, response.Pagination, error)
+	UpdateNewsArticleBySlug(ctx context.Context, actor dto.AuthUser, slug string, body request.UpdateNewsArticleRequest, expectedVersion *int) error
+	TransitionNewsArticle(ctx context.Context, actor dto.AuthUser, slug string, body request.TransitionNewsArticleRequest) (response.NewsStatus, error)
+	DeleteNewsArticleBySlug(ctx context.Context, actor dto.AuthUser, slug string, expectedVersion *int) error
+	BulkNews(ctx context.Context, actor dto.AuthUser, body request.BulkNewsRequest) (response.BulkNewsResult, error)
+	ImportNews(ctx context.Context, actor dto.AuthUser, source NewsImportSource, dryRun bool) (response.NewsImport, error)
+	ExportNews(ctx context.Context, actor dto.AuthUser, filter dto.NewsFilter, write func(response.NewsExport) error) error
+	GetNewsRevisions(ctx context.Context, actor dto.AuthUser, slug string, pagination dto.Pagination) ([]response.ArticleRevision, response.Pagination, error)
+	DiffNewsRevisions(ctx context.Context, actor dto.AuthUser, slug string, from, to int) (response.RevisionDiff, error)
+	RestoreNewsRevision(ctx context.Context, actor dto.AuthUser, slug string, revision int) error
+	PublishScheduledNews(ctx context.Context, batchSize int) (int, error)
+	GetTrashedNews(ctx context.Context, actor dto.AuthUser, pagination dto.Pagination) ([]response.NewsArticle, response.Pagination, error)
+	RestoreNewsArticle(ctx context.Context, actor dto.AuthUser, slug string) error
+	PurgeNewsArticle(ctx context.Context, actor dto.AuthUser, slug string) error
+	PurgeTrashedNews(ctx context.Context, before time.Time, batchSize int) (int, error)
 }
 
 type TopicsUsecase interface {
-	CreateTopic(ctx context.Context, body request.CreateTopicRequest) error
-	GetTopics(ctx context.Context) ([]response.Topic, error)
-	UpdateTopic(ctx context.Context, id int, body request.UpdateTopicRequest) error
-	DeleteTopic(ctx context.Context, id int) error
+	CreateTopic(ctx context.Context, actor dto.AuthUser, body request.CreateTopicRequest) error
+	GetTopics(ctx context.Context, pagination dto.Pagination, withCounts bool) ([]response.Topic, response.Pagination, error)
+	GetTopic(ctx context.Context, key string, latest int) (response.TopicDetail, error)
+	ResolveTopicSlug(ctx context.Context, slug string) (string, error)
+	GetTopicTree(ctx context.Context) ([]response.TopicNode, error)
+	UpdateTopic(ctx context.Context, actor dto.AuthUser, id int, body request.UpdateTopicRequest, expectedVersion *int) error
+	DeleteTopic(ctx context.Context, actor dto.AuthUser, id int, expectedVersion *int, force bool) error
+	MergeTopic(ctx context.Context, actor dto.AuthUser, id int, body request.MergeTopicRequest, expectedVersion *int) (response.TopicMerge, error)
+	GetTrashedTopics(ctx context.Context, actor dto.AuthUser, pagination dto.Pagination) ([]response.Topic, response.Pagination, error)
+	RestoreTopic(ctx context.Context, actor dto.AuthUser, id int) error
+	PurgeTopic(ctx context.Context, actor dto.AuthUser, id int) error
+	PurgeTrashedTopics(ctx context.Context, before time.Time, batchSize int) (int, error)
 }
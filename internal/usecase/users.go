@@ -2,12 +2,17 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
 	"newsapi/internal/model/entity"
 	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
 	"newsapi/internal/repository"
+	"newsapi/internal/utils"
 
 	"github.com/labstack/gommon/log"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type usersUsecase struct {
@@ -19,14 +24,174 @@ func NewUsersUsecase(repo repository.UsersRepository) UsersUsecase {
 }
 
 func (u usersUsecase) CreateUser(ctx context.Context, body request.CreateUserRequest) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Errorf("failed hash password: %v", err)
+		return exception.ErrFailedCreateUser
+	}
+	password := string(hashed)
+
 	entity := &entity.User{
-		Name:  body.Name,
-		Email: body.Email,
+		Name:     body.Name,
+		Email:    body.Email,
+		Password: &password,
+	}
+	err = u.repo.Create(ctx, entity)
+	if err != nil {
+		if valid, hint := utils.IsDuplicateKey(err); valid {
+			return errors.New(hint)
+		}
+		log.Errorf("failed create user: %v", err)
+		return exception.ErrFailedCreateUser
+	}
+
+	return nil
+}
+
+func (u usersUsecase) UpdateUserRole(
+	ctx context.Context,
+	actor dto.AuthUser,
+	id int,
+	body request.UpdateUserRoleRequest,
+) error {
+	if !canManageUsers(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	_, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return exception.ErrUserNotFound
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return exception.ErrFailedGetUser
+	}
+
+	err = u.repo.UpdateRole(ctx, id, entity.UserRole(body.Role))
+	if err != nil {
+		log.Errorf("failed update user role: %s", err.Error())
+		return exception.ErrFailedUpdateUser
+	}
+
+	return nil
+}
+
+func (u usersUsecase) GetUser(ctx context.Context, actor dto.AuthUser, id int) (response.User, error) {
+	if !canAccessUser(actor, id) {
+		return response.User{}, exception.ErrPermissionDenied
+	}
+
+	user, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return response.User{}, exception.ErrUserNotFound
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return response.User{}, exception.ErrFailedGetUser
+	}
+
+	return response.UserSerializer(user), nil
+}
+
+func (u usersUsecase) GetUsers(
+	ctx context.Context,
+	actor dto.AuthUser,
+	pagination dto.Pagination,
+) ([]response.User, response.Pagination, error) {
+	if !canManageUsers(actor) {
+		return nil, response.Pagination{}, exception.ErrPermissionDenied
+	}
+
+	users, err := u.repo.GetAll(ctx, pagination)
+	if err != nil {
+		log.Errorf("failed get users: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetUser
 	}
-	err := u.repo.Create(ctx, entity)
+
+	total, err := u.repo.Count(ctx)
+	if err != nil {
+		log.Errorf("failed count users: %v", err)
+		return nil, response.Pagination{}, exception.ErrFailedGetUser
+	}
+
+	res := []response.User{}
+	for _, user := range users {
+		res = append(res, response.UserSerializer(user))
+	}
+
+	return res, response.PaginationSerializer(pagination, total), nil
+}
+
+func (u usersUsecase) UpdateUser(
+	ctx context.Context,
+	actor dto.AuthUser,
+	id int,
+	body request.UpdateUserRequest,
+) error {
+	if !canAccessUser(actor, id) {
+		return exception.ErrPermissionDenied
+	}
+
+	currentUser, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return exception.ErrUserNotFound
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return exception.ErrFailedGetUser
+	}
+
+	updateFields := make([]string, 0)
+	updatedUser := currentUser
+
+	if body.Name != nil && *body.Name != currentUser.Name {
+		updatedUser.Name = *body.Name
+		updateFields = append(updateFields, "name")
+	}
+
+	if body.Email != nil && *body.Email != currentUser.Email {
+		updatedUser.Email = *body.Email
+		updateFields = append(updateFields, "email")
+	}
+
+	if len(updateFields) == 0 {
+		return exception.ErrNoFieldUpdate
+	}
+
+	err = u.repo.UpdateUserFields(ctx, &updatedUser, updateFields)
+	if err != nil {
+		if valid, hint := utils.IsDuplicateKey(err); valid {
+			return errors.New(hint)
+		}
+		log.Errorf("failed update user: %s", err.Error())
+		return exception.ErrFailedUpdateUser
+	}
+
+	return nil
+}
+
+func (u usersUsecase) DeactivateUser(ctx context.Context, actor dto.AuthUser, id int) error {
+	if !canManageUsers(actor) {
+		return exception.ErrPermissionDenied
+	}
+
+	currentUser, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if notFound := utils.IsNoRowError(err); notFound {
+			return exception.ErrUserNotFound
+		}
+
+		log.Errorf("failed get user: %s", err.Error())
+		return exception.ErrFailedGetUser
+	}
+
+	err = u.repo.Deactivate(ctx, currentUser.ID)
 	if err != nil {
-		log.Errorf("failed create user: %w", err)
-		return exception.ErrFailedInsertTopic
+		log.Errorf("failed deactivate user: %s", err.Error())
+		return exception.ErrFailedDeactivateUser
 	}
 
 	return nil
@@ -2,13 +2,20 @@ package usecase_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"newsapi/internal/exception"
+	"newsapi/internal/model/dto"
+	"newsapi/internal/model/entity"
 	"newsapi/internal/model/request"
+	"newsapi/internal/model/response"
 	"newsapi/internal/usecase"
+	"newsapi/internal/utils"
 	mock_repository "newsapi/mocks/repository"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,8 +43,9 @@ func Test_CreateUse(t *testing.T) {
 	ctx := context.Background()
 
 	mockReq := request.CreateUserRequest{
-		Name:  "John Doe",
-		Email: "john@example.com",
+		Name:     "John Doe",
+		Email:    "john@example.com",
+		Password: "secret-password",
 	}
 
 	tests := []struct {
@@ -54,6 +62,18 @@ func Test_CreateUse(t *testing.T) {
 				assert.Error(t, err)
 			},
 		},
+		{
+			testname: "duplicate email then return hint",
+			initMock: func() {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&pq.Error{
+					Code:   "23505",
+					Detail: "Key (email)=(john@example.com) already exists.",
+				})
+			},
+			assertion: func(err error) {
+				assert.EqualError(t, err, "email: john@example.com already exists")
+			},
+		},
 		{
 			testname: "success create user then return err nil",
 			initMock: func() {
@@ -73,3 +93,379 @@ func Test_CreateUse(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpdateUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUserAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.userUC
+	ctx := context.Background()
+
+	mockReq := request.UpdateUserRoleRequest{Role: "editor"}
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "non admin cannot update role",
+			actor:    dto.AuthUser{ID: 2, Role: entity.RoleEditor},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "user not found then return error",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrUserNotFound, err)
+			},
+		},
+		{
+			testname: "repository fail on update then return error",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{ID: 5}, nil)
+				repo.EXPECT().UpdateRole(ctx, 5, entity.RoleEditor).Return(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedUpdateUser, err)
+			},
+		},
+		{
+			testname: "admin update role successfully",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{ID: 5}, nil)
+				repo.EXPECT().UpdateRole(ctx, 5, entity.RoleEditor).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := uc.UpdateUserRole(ctx, tt.actor, 5, mockReq)
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUserAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.userUC
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		id        int
+		initMock  func()
+		assertion func(user response.User, err error)
+	}{
+		{
+			testname: "reader cannot get another user",
+			actor:    dto.AuthUser{ID: 2, Role: entity.RoleReader},
+			id:       5,
+			initMock: func() {},
+			assertion: func(user response.User, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "reader get own profile",
+			actor:    dto.AuthUser{ID: 5, Role: entity.RoleReader},
+			id:       5,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{ID: 5, Name: "John", Password: utils.StringPtr("hash")}, nil)
+			},
+			assertion: func(user response.User, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "John", user.Name)
+			},
+		},
+		{
+			testname: "user not found then return error",
+			actor:    adminActor,
+			id:       5,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(user response.User, err error) {
+				assert.Equal(t, exception.ErrUserNotFound, err)
+			},
+		},
+		{
+			testname: "repository error then return error",
+			actor:    adminActor,
+			id:       5,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{}, errors.New("db error"))
+			},
+			assertion: func(user response.User, err error) {
+				assert.Equal(t, exception.ErrFailedGetUser, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			user, err := uc.GetUser(ctx, tt.actor, tt.id)
+			tt.assertion(user, err)
+		})
+	}
+}
+
+func Test_GetUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUserAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.userUC
+	ctx := context.Background()
+
+	pagination := dto.NewPagination(1, 2)
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(users []response.User, meta response.Pagination, err error)
+	}{
+		{
+			testname: "non admin cannot list users",
+			actor:    dto.AuthUser{ID: 2, Role: entity.RoleEditor},
+			initMock: func() {},
+			assertion: func(users []response.User, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "repository error then return error",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetAll(ctx, pagination).Return(nil, errors.New("db error"))
+			},
+			assertion: func(users []response.User, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetUser, err)
+			},
+		},
+		{
+			testname: "count error then return error",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetAll(ctx, pagination).Return([]entity.User{{ID: 1}}, nil)
+				repo.EXPECT().Count(ctx).Return(0, errors.New("db error"))
+			},
+			assertion: func(users []response.User, meta response.Pagination, err error) {
+				assert.Equal(t, exception.ErrFailedGetUser, err)
+			},
+		},
+		{
+			testname: "list users with pagination",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetAll(ctx, pagination).Return([]entity.User{{ID: 1}, {ID: 2}}, nil)
+				repo.EXPECT().Count(ctx).Return(7, nil)
+			},
+			assertion: func(users []response.User, meta response.Pagination, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, users, 2)
+				assert.Equal(t, response.Pagination{Page: 1, Limit: 2, Total: 7}, meta)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			users, meta, err := uc.GetUsers(ctx, tt.actor, pagination)
+			tt.assertion(users, meta, err)
+		})
+	}
+}
+
+func Test_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUserAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.userUC
+	ctx := context.Background()
+
+	currentUser := entity.User{ID: 5, Name: "John", Email: "john@example.com"}
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		body      request.UpdateUserRequest
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "author cannot update another user",
+			actor:    dto.AuthUser{ID: 2, Role: entity.RoleAuthor},
+			body:     request.UpdateUserRequest{Name: utils.StringPtr("Jane")},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "user not found then return error",
+			actor:    adminActor,
+			body:     request.UpdateUserRequest{Name: utils.StringPtr("Jane")},
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrUserNotFound, err)
+			},
+		},
+		{
+			testname: "same values then return no field update",
+			actor:    adminActor,
+			body:     request.UpdateUserRequest{Name: utils.StringPtr("John")},
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(currentUser, nil)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrNoFieldUpdate, err)
+			},
+		},
+		{
+			testname: "duplicate email then return hint",
+			actor:    adminActor,
+			body:     request.UpdateUserRequest{Email: utils.StringPtr("jane@example.com")},
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(currentUser, nil)
+				repo.EXPECT().UpdateUserFields(ctx, gomock.Any(), []string{"email"}).Return(&pq.Error{
+					Code:   "23505",
+					Detail: "Key (email)=(jane@example.com) already exists.",
+				})
+			},
+			assertion: func(err error) {
+				assert.EqualError(t, err, "email: jane@example.com already exists")
+			},
+		},
+		{
+			testname: "repository error then return error",
+			actor:    adminActor,
+			body:     request.UpdateUserRequest{Name: utils.StringPtr("Jane")},
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(currentUser, nil)
+				repo.EXPECT().UpdateUserFields(ctx, gomock.Any(), []string{"name"}).Return(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedUpdateUser, err)
+			},
+		},
+		{
+			testname: "user update own name and email",
+			actor:    dto.AuthUser{ID: 5, Role: entity.RoleReader},
+			body: request.UpdateUserRequest{
+				Name:  utils.StringPtr("Jane"),
+				Email: utils.StringPtr("jane@example.com"),
+			},
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(currentUser, nil)
+				repo.EXPECT().UpdateUserFields(ctx, gomock.Any(), []string{"name", "email"}).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := uc.UpdateUser(ctx, tt.actor, 5, tt.body)
+			tt.assertion(err)
+		})
+	}
+}
+
+func Test_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessor := newUserAccessor(ctrl)
+	repo := accessor.userRepo
+	uc := accessor.userUC
+	ctx := context.Background()
+
+	tests := []struct {
+		testname  string
+		actor     dto.AuthUser
+		initMock  func()
+		assertion func(err error)
+	}{
+		{
+			testname: "non admin cannot deactivate user",
+			actor:    dto.AuthUser{ID: 5, Role: entity.RoleEditor},
+			initMock: func() {},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrPermissionDenied, err)
+			},
+		},
+		{
+			testname: "user not found then return error",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{}, sql.ErrNoRows)
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrUserNotFound, err)
+			},
+		},
+		{
+			testname: "repository error then return error",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{ID: 5}, nil)
+				repo.EXPECT().Deactivate(ctx, 5).Return(errors.New("db error"))
+			},
+			assertion: func(err error) {
+				assert.Equal(t, exception.ErrFailedDeactivateUser, err)
+			},
+		},
+		{
+			testname: "admin deactivate user successfully",
+			actor:    adminActor,
+			initMock: func() {
+				repo.EXPECT().GetByID(ctx, 5).Return(entity.User{ID: 5}, nil)
+				repo.EXPECT().Deactivate(ctx, 5).Return(nil)
+			},
+			assertion: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			tt.initMock()
+			err := uc.DeactivateUser(ctx, tt.actor, 5)
+			tt.assertion(err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+type TokenClaims struct {
+	UserID    int    `json:"uid"`
+	Role      string `json:"role"`
+	TokenType string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken signs a HS256 token for the given user, role and token type
+func GenerateToken(secret string, userID int, role string, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := TokenClaims{
+		UserID:    userID,
+		Role:      role,
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseToken verifies signature, expiry and type of the token and returns its claims
+func ParseToken(secret string, tokenString string, tokenType string) (TokenClaims, error) {
+	var claims TokenClaims
+
+	_, err := jwt.ParseWithClaims(
+		tokenString,
+		&claims,
+		func(t *jwt.Token) (interface{}, error) {
+			return []byte(secret), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return TokenClaims{}, err
+	}
+
+	if claims.TokenType != tokenType {
+		return TokenClaims{}, errors.New("invalid token type")
+	}
+
+	return claims, nil
+}
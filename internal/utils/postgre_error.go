@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 
@@ -14,7 +15,7 @@ func IsNoRowError(err error) bool {
 
 func IsDuplicateKey(err error) (bool, string) {
 	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
-		log.Errorf("Postgre Error: %w", pqErr)
+		log.Errorf("Postgre Error: %v", pqErr)
 
 		re := regexp.MustCompile(`Key \((.+?)\)=\((.+?)\) already exists`)
 		matches := re.FindStringSubmatch(pqErr.Detail)
@@ -28,3 +29,9 @@ func IsDuplicateKey(err error) (bool, string) {
 
 	return false, ""
 }
+
+// IsForeignKeyViolation reports whether err is a postgres foreign key violation
+func IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
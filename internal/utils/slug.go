@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+// slugSuffixRoom is kept free by Slugify so a "-<n>" collision suffix still fits
+const slugSuffixRoom = 8
+
+// Slugify derives a lowercase, hyphenated slug from s, transliterating unicode
+// to ASCII. The result leaves room for a collision suffix within maxLength and
+// is empty when s holds no letters or digits.
+func Slugify(s string, maxLength int) string {
+	result := slug.Make(s)
+
+	if limit := maxLength - slugSuffixRoom; len(result) > limit {
+		result = strings.TrimRight(result[:limit], "-")
+	}
+
+	return result
+}
+
+// UniqueSlug returns base, or base with the lowest numeric suffix starting at 2
+// that is not in taken
+func UniqueSlug(base string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, s := range taken {
+		used[s] = true
+	}
+
+	return FreeSlug(base, func(s string) bool { return used[s] })
+}
+
+// FreeSlug is UniqueSlug for callers that look taken slugs up themselves
+func FreeSlug(base string, taken func(string) bool) string {
+	candidate := base
+	for n := 2; taken(candidate); n++ {
+		candidate = base + "-" + strconv.Itoa(n)
+	}
+
+	return candidate
+}
+
+// IsSlug reports whether s is already in the form Slugify produces
+func IsSlug(s string) bool {
+	return slug.IsSlug(s)
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
@@ -16,3 +17,11 @@ func GenerateMockDb() (*sql.DB, *sqlx.DB, sqlmock.Sqlmock) {
 func StringPtr(s string) *string {
 	return &s
 }
+
+func IntPtr(i int) *int {
+	return &i
+}
+
+func TimePtr(t time.Time) *time.Time {
+	return &t
+}
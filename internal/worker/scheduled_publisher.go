@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"newsapi/internal/config/env"
+	"newsapi/internal/usecase"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ScheduledPublisher periodically publishes scheduled articles whose publish_at
+// has passed. Every API replica may run one, the repository skips rows another
+// replica is already publishing.
+type ScheduledPublisher struct {
+	uc        usecase.NewsUsecase
+	interval  time.Duration
+	batchSize int
+}
+
+func NewScheduledPublisher(uc usecase.NewsUsecase, config env.WorkerConfig) *ScheduledPublisher {
+	return &ScheduledPublisher{
+		uc:        uc,
+		interval:  config.PublishInterval,
+		batchSize: config.PublishBatchSize,
+	}
+}
+
+// Run publishes due articles right away and then every interval until ctx is done
+func (p *ScheduledPublisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.publish(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ScheduledPublisher) publish(ctx context.Context) {
+	published, err := p.uc.PublishScheduledNews(ctx, p.batchSize)
+	if published > 0 {
+		log.Infof("ScheduledPublisher.publish: published %d news", published)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("ScheduledPublisher.publish: %v", err)
+	}
+}
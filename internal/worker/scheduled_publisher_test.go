@@ -0,0 +1,71 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/config/env"
+	"newsapi/internal/worker"
+	mock_usecase "newsapi/mocks/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScheduledPublisherRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newsUC := mock_usecase.NewMockNewsUsecase(ctrl)
+	config := env.WorkerConfig{PublishInterval: time.Millisecond, PublishBatchSize: 25}
+
+	tests := []struct {
+		testname string
+		initMock func(cancel context.CancelFunc)
+	}{
+		{
+			testname: "publish immediately then stop once cancelled",
+			initMock: func(cancel context.CancelFunc) {
+				newsUC.EXPECT().PublishScheduledNews(gomock.Any(), 25).
+					DoAndReturn(func(context.Context, int) (int, error) {
+						cancel()
+						return 3, nil
+					})
+			},
+		},
+		{
+			testname: "keep polling after a failed run",
+			initMock: func(cancel context.CancelFunc) {
+				gomock.InOrder(
+					newsUC.EXPECT().PublishScheduledNews(gomock.Any(), 25).Return(0, errors.New("db error")),
+					newsUC.EXPECT().PublishScheduledNews(gomock.Any(), 25).
+						DoAndReturn(func(context.Context, int) (int, error) {
+							cancel()
+							return 0, nil
+						}),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			tt.initMock(cancel)
+
+			done := make(chan struct{})
+			go func() {
+				worker.NewScheduledPublisher(newsUC, config).Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				assert.Fail(t, "publisher did not stop after cancel")
+			}
+		})
+	}
+}
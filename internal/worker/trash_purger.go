@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"newsapi/internal/config/env"
+	"newsapi/internal/usecase"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// TrashPurger periodically deletes for good the articles and topics that stayed
+// soft-deleted longer than the retention period. A non positive retention keeps
+// trashed rows forever.
+type TrashPurger struct {
+	news      usecase.NewsUsecase
+	topics    usecase.TopicsUsecase
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+func NewTrashPurger(news usecase.NewsUsecase, topics usecase.TopicsUsecase, config env.WorkerConfig) *TrashPurger {
+	return &TrashPurger{
+		news:      news,
+		topics:    topics,
+		interval:  config.TrashPurgeInterval,
+		retention: config.TrashRetention,
+		batchSize: config.TrashPurgeBatchSize,
+	}
+}
+
+// Run purges expired trash right away and then every interval until ctx is done
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.retention <= 0 {
+		log.Info("TrashPurger.Run: retention disabled, trashed rows are kept")
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+
+	news, err := p.news.PurgeTrashedNews(ctx, before, p.batchSize)
+	if news > 0 {
+		log.Infof("TrashPurger.purge: purged %d news", news)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("TrashPurger.purge: %v", err)
+	}
+
+	topics, err := p.topics.PurgeTrashedTopics(ctx, before, p.batchSize)
+	if topics > 0 {
+		log.Infof("TrashPurger.purge: purged %d topics", topics)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("TrashPurger.purge: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"newsapi/internal/config/env"
+	"newsapi/internal/worker"
+	mock_usecase "newsapi/mocks/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TrashPurgerRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newsUC := mock_usecase.NewMockNewsUsecase(ctrl)
+	topicsUC := mock_usecase.NewMockTopicsUsecase(ctrl)
+
+	tests := []struct {
+		testname string
+		config   env.WorkerConfig
+		initMock func(cancel context.CancelFunc)
+	}{
+		{
+			testname: "purge news then topics trashed before the retention period",
+			config:   env.WorkerConfig{TrashPurgeInterval: time.Millisecond, TrashRetention: time.Hour, TrashPurgeBatchSize: 25},
+			initMock: func(cancel context.CancelFunc) {
+				var newsBefore time.Time
+				gomock.InOrder(
+					newsUC.EXPECT().PurgeTrashedNews(gomock.Any(), gomock.Any(), 25).
+						DoAndReturn(func(_ context.Context, before time.Time, _ int) (int, error) {
+							newsBefore = before
+							assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Second)
+							return 0, errors.New("db error")
+						}),
+					topicsUC.EXPECT().PurgeTrashedTopics(gomock.Any(), gomock.Any(), 25).
+						DoAndReturn(func(_ context.Context, before time.Time, _ int) (int, error) {
+							assert.Equal(t, newsBefore, before)
+							cancel()
+							return 2, nil
+						}),
+				)
+			},
+		},
+		{
+			testname: "zero retention keeps trashed rows",
+			config:   env.WorkerConfig{TrashPurgeInterval: time.Millisecond, TrashPurgeBatchSize: 25},
+			initMock: func(context.CancelFunc) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testname, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			tt.initMock(cancel)
+
+			done := make(chan struct{})
+			go func() {
+				worker.NewTrashPurger(newsUC, topicsUC, tt.config).Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				assert.Fail(t, "purger did not stop")
+			}
+		})
+	}
+}
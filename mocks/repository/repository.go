@@ -9,10 +9,48 @@ import (
 	dto "newsapi/internal/model/dto"
 	entity "newsapi/internal/model/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
+
 // MockUsersRepository is a mock of UsersRepository interface.
 type MockUsersRepository struct {
 	ctrl     *gomock.Controller
@@ -36,6 +74,21 @@ func (m *MockUsersRepository) EXPECT() *MockUsersRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockUsersRepository) Count(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockUsersRepositoryMockRecorder) Count(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUsersRepository)(nil).Count), ctx)
+}
+
 // Create mocks base method.
 func (m *MockUsersRepository) Create(ctx context.Context, entity *entity.User) error {
 	m.ctrl.T.Helper()
@@ -50,6 +103,93 @@ func (mr *MockUsersRepositoryMockRecorder) Create(ctx, entity interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUsersRepository)(nil).Create), ctx, entity)
 }
 
+// Deactivate mocks base method.
+func (m *MockUsersRepository) Deactivate(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockUsersRepositoryMockRecorder) Deactivate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockUsersRepository)(nil).Deactivate), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockUsersRepository) GetAll(ctx context.Context, pagination dto.Pagination) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, pagination)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockUsersRepositoryMockRecorder) GetAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUsersRepository)(nil).GetAll), ctx, pagination)
+}
+
+// GetByEmail mocks base method.
+func (m *MockUsersRepository) GetByEmail(ctx context.Context, email string) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUsersRepositoryMockRecorder) GetByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUsersRepository)(nil).GetByEmail), ctx, email)
+}
+
+// GetByID mocks base method.
+func (m *MockUsersRepository) GetByID(ctx context.Context, id int) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUsersRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUsersRepository)(nil).GetByID), ctx, id)
+}
+
+// UpdateRole mocks base method.
+func (m *MockUsersRepository) UpdateRole(ctx context.Context, id int, role entity.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUsersRepositoryMockRecorder) UpdateRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUsersRepository)(nil).UpdateRole), ctx, id, role)
+}
+
+// UpdateUserFields mocks base method.
+func (m *MockUsersRepository) UpdateUserFields(ctx context.Context, user *entity.User, updateFields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserFields", ctx, user, updateFields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserFields indicates an expected call of UpdateUserFields.
+func (mr *MockUsersRepositoryMockRecorder) UpdateUserFields(ctx, user, updateFields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserFields", reflect.TypeOf((*MockUsersRepository)(nil).UpdateUserFields), ctx, user, updateFields)
+}
+
 // MockTopicsRepository is a mock of TopicsRepository interface.
 type MockTopicsRepository struct {
 	ctrl     *gomock.Controller
@@ -73,6 +213,51 @@ func (m *MockTopicsRepository) EXPECT() *MockTopicsRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockTopicsRepository) Count(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockTopicsRepositoryMockRecorder) Count(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockTopicsRepository)(nil).Count), ctx)
+}
+
+// CountPublishedArticles mocks base method.
+func (m *MockTopicsRepository) CountPublishedArticles(ctx context.Context, topicIDs []int) ([]entity.TopicArticleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPublishedArticles", ctx, topicIDs)
+	ret0, _ := ret[0].([]entity.TopicArticleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublishedArticles indicates an expected call of CountPublishedArticles.
+func (mr *MockTopicsRepositoryMockRecorder) CountPublishedArticles(ctx, topicIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublishedArticles", reflect.TypeOf((*MockTopicsRepository)(nil).CountPublishedArticles), ctx, topicIDs)
+}
+
+// CountTrashed mocks base method.
+func (m *MockTopicsRepository) CountTrashed(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTrashed", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTrashed indicates an expected call of CountTrashed.
+func (mr *MockTopicsRepositoryMockRecorder) CountTrashed(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTrashed", reflect.TypeOf((*MockTopicsRepository)(nil).CountTrashed), ctx)
+}
+
 // Create mocks base method.
 func (m *MockTopicsRepository) Create(ctx context.Context, entity *entity.Topic) error {
 	m.ctrl.T.Helper()
@@ -88,32 +273,47 @@ func (mr *MockTopicsRepositoryMockRecorder) Create(ctx, entity interface{}) *gom
 }
 
 // Delete mocks base method.
-func (m *MockTopicsRepository) Delete(ctx context.Context, id int) error {
+func (m *MockTopicsRepository) Delete(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTopicsRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockTopicsRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTopicsRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTopicsRepository)(nil).Delete), ctx, id, version)
 }
 
 // GetAll mocks base method.
-func (m *MockTopicsRepository) GetAll(ctx context.Context) ([]entity.Topic, error) {
+func (m *MockTopicsRepository) GetAll(ctx context.Context, pagination dto.Pagination) ([]entity.Topic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, pagination)
 	ret0, _ := ret[0].([]entity.Topic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTopicsRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockTopicsRepositoryMockRecorder) GetAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTopicsRepository)(nil).GetAll), ctx, pagination)
+}
+
+// GetAncestorIDs mocks base method.
+func (m *MockTopicsRepository) GetAncestorIDs(ctx context.Context, id int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestorIDs", ctx, id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestorIDs indicates an expected call of GetAncestorIDs.
+func (mr *MockTopicsRepositoryMockRecorder) GetAncestorIDs(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTopicsRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestorIDs", reflect.TypeOf((*MockTopicsRepository)(nil).GetAncestorIDs), ctx, id)
 }
 
 // GetByID mocks base method.
@@ -131,6 +331,185 @@ func (mr *MockTopicsRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTopicsRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByRetiredSlug mocks base method.
+func (m *MockTopicsRepository) GetByRetiredSlug(ctx context.Context, slug string) (entity.RetiredSlug, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRetiredSlug", ctx, slug)
+	ret0, _ := ret[0].(entity.RetiredSlug)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRetiredSlug indicates an expected call of GetByRetiredSlug.
+func (mr *MockTopicsRepositoryMockRecorder) GetByRetiredSlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRetiredSlug", reflect.TypeOf((*MockTopicsRepository)(nil).GetByRetiredSlug), ctx, slug)
+}
+
+// GetBySlug mocks base method.
+func (m *MockTopicsRepository) GetBySlug(ctx context.Context, slug string) (entity.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(entity.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockTopicsRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockTopicsRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// GetHierarchy mocks base method.
+func (m *MockTopicsRepository) GetHierarchy(ctx context.Context) ([]entity.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHierarchy", ctx)
+	ret0, _ := ret[0].([]entity.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHierarchy indicates an expected call of GetHierarchy.
+func (mr *MockTopicsRepositoryMockRecorder) GetHierarchy(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHierarchy", reflect.TypeOf((*MockTopicsRepository)(nil).GetHierarchy), ctx)
+}
+
+// GetIDsBySlugs mocks base method.
+func (m *MockTopicsRepository) GetIDsBySlugs(ctx context.Context, slugs []string) ([]entity.TopicSlugRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsBySlugs", ctx, slugs)
+	ret0, _ := ret[0].([]entity.TopicSlugRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsBySlugs indicates an expected call of GetIDsBySlugs.
+func (mr *MockTopicsRepositoryMockRecorder) GetIDsBySlugs(ctx, slugs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsBySlugs", reflect.TypeOf((*MockTopicsRepository)(nil).GetIDsBySlugs), ctx, slugs)
+}
+
+// GetLatestPublished mocks base method.
+func (m *MockTopicsRepository) GetLatestPublished(ctx context.Context, topicID, limit int) ([]entity.PublishedNewsWithTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestPublished", ctx, topicID, limit)
+	ret0, _ := ret[0].([]entity.PublishedNewsWithTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestPublished indicates an expected call of GetLatestPublished.
+func (mr *MockTopicsRepositoryMockRecorder) GetLatestPublished(ctx, topicID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestPublished", reflect.TypeOf((*MockTopicsRepository)(nil).GetLatestPublished), ctx, topicID, limit)
+}
+
+// GetLiveIDs mocks base method.
+func (m *MockTopicsRepository) GetLiveIDs(ctx context.Context, topicIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveIDs", ctx, topicIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveIDs indicates an expected call of GetLiveIDs.
+func (mr *MockTopicsRepositoryMockRecorder) GetLiveIDs(ctx, topicIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveIDs", reflect.TypeOf((*MockTopicsRepository)(nil).GetLiveIDs), ctx, topicIDs)
+}
+
+// GetTakenSlugs mocks base method.
+func (m *MockTopicsRepository) GetTakenSlugs(ctx context.Context, base string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenSlugs", ctx, base)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenSlugs indicates an expected call of GetTakenSlugs.
+func (mr *MockTopicsRepositoryMockRecorder) GetTakenSlugs(ctx, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenSlugs", reflect.TypeOf((*MockTopicsRepository)(nil).GetTakenSlugs), ctx, base)
+}
+
+// GetTrashed mocks base method.
+func (m *MockTopicsRepository) GetTrashed(ctx context.Context, pagination dto.Pagination) ([]entity.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashed", ctx, pagination)
+	ret0, _ := ret[0].([]entity.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashed indicates an expected call of GetTrashed.
+func (mr *MockTopicsRepositoryMockRecorder) GetTrashed(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashed", reflect.TypeOf((*MockTopicsRepository)(nil).GetTrashed), ctx, pagination)
+}
+
+// Merge mocks base method.
+func (m *MockTopicsRepository) Merge(ctx context.Context, source entity.Topic, targetID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, source, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTopicsRepositoryMockRecorder) Merge(ctx, source, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTopicsRepository)(nil).Merge), ctx, source, targetID)
+}
+
+// Purge mocks base method.
+func (m *MockTopicsRepository) Purge(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTopicsRepositoryMockRecorder) Purge(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTopicsRepository)(nil).Purge), ctx, id)
+}
+
+// PurgeTrashed mocks base method.
+func (m *MockTopicsRepository) PurgeTrashed(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashed", ctx, before, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashed indicates an expected call of PurgeTrashed.
+func (mr *MockTopicsRepositoryMockRecorder) PurgeTrashed(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashed", reflect.TypeOf((*MockTopicsRepository)(nil).PurgeTrashed), ctx, before, limit)
+}
+
+// Restore mocks base method.
+func (m *MockTopicsRepository) Restore(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTopicsRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTopicsRepository)(nil).Restore), ctx, id)
+}
+
 // UpdateTopicFileds mocks base method.
 func (m *MockTopicsRepository) UpdateTopicFileds(ctx context.Context, topic *entity.Topic, updateFields []string) error {
 	m.ctrl.T.Helper()
@@ -168,6 +547,51 @@ func (m *MockNewsArticlesRepository) EXPECT() *MockNewsArticlesRepositoryMockRec
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockNewsArticlesRepository) Count(ctx context.Context, filter dto.NewsFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockNewsArticlesRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockNewsArticlesRepository)(nil).Count), ctx, filter)
+}
+
+// CountPublishedByAuthor mocks base method.
+func (m *MockNewsArticlesRepository) CountPublishedByAuthor(ctx context.Context, authorID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPublishedByAuthor", ctx, authorID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublishedByAuthor indicates an expected call of CountPublishedByAuthor.
+func (mr *MockNewsArticlesRepositoryMockRecorder) CountPublishedByAuthor(ctx, authorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublishedByAuthor", reflect.TypeOf((*MockNewsArticlesRepository)(nil).CountPublishedByAuthor), ctx, authorID)
+}
+
+// CountTrashed mocks base method.
+func (m *MockNewsArticlesRepository) CountTrashed(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTrashed", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTrashed indicates an expected call of CountTrashed.
+func (mr *MockNewsArticlesRepositoryMockRecorder) CountTrashed(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTrashed", reflect.TypeOf((*MockNewsArticlesRepository)(nil).CountTrashed), ctx)
+}
+
 // Create mocks base method.
 func (m *MockNewsArticlesRepository) Create(ctx context.Context, entity *entity.NewsArticle) (int, error) {
 	m.ctrl.T.Helper()
@@ -183,18 +607,62 @@ func (mr *MockNewsArticlesRepositoryMockRecorder) Create(ctx, entity interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNewsArticlesRepository)(nil).Create), ctx, entity)
 }
 
+// CreateBatch mocks base method.
+func (m *MockNewsArticlesRepository) CreateBatch(ctx context.Context, articles []entity.NewsArticle) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, articles)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockNewsArticlesRepositoryMockRecorder) CreateBatch(ctx, articles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockNewsArticlesRepository)(nil).CreateBatch), ctx, articles)
+}
+
+// DeleteArticles mocks base method.
+func (m *MockNewsArticlesRepository) DeleteArticles(ctx context.Context, articles []entity.NewsArticleWithTopic) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArticles", ctx, articles)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteArticles indicates an expected call of DeleteArticles.
+func (mr *MockNewsArticlesRepositoryMockRecorder) DeleteArticles(ctx, articles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticles", reflect.TypeOf((*MockNewsArticlesRepository)(nil).DeleteArticles), ctx, articles)
+}
+
 // DeleteBySlug mocks base method.
-func (m *MockNewsArticlesRepository) DeleteBySlug(ctx context.Context, slug string) error {
+func (m *MockNewsArticlesRepository) DeleteBySlug(ctx context.Context, slug string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBySlug", ctx, slug)
+	ret := m.ctrl.Call(m, "DeleteBySlug", ctx, slug, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteBySlug indicates an expected call of DeleteBySlug.
-func (mr *MockNewsArticlesRepositoryMockRecorder) DeleteBySlug(ctx, slug interface{}) *gomock.Call {
+func (mr *MockNewsArticlesRepositoryMockRecorder) DeleteBySlug(ctx, slug, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySlug", reflect.TypeOf((*MockNewsArticlesRepository)(nil).DeleteBySlug), ctx, slug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySlug", reflect.TypeOf((*MockNewsArticlesRepository)(nil).DeleteBySlug), ctx, slug, version)
+}
+
+// Export mocks base method.
+func (m *MockNewsArticlesRepository) Export(ctx context.Context, filter dto.NewsFilter, fn func(entity.NewsExport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockNewsArticlesRepositoryMockRecorder) Export(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockNewsArticlesRepository)(nil).Export), ctx, filter, fn)
 }
 
 // GetActiveArticleBySlug mocks base method.
@@ -242,6 +710,170 @@ func (mr *MockNewsArticlesRepositoryMockRecorder) GetArticleBySlug(ctx, slug int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBySlug", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetArticleBySlug), ctx, slug)
 }
 
+// GetArticlesBySlugs mocks base method.
+func (m *MockNewsArticlesRepository) GetArticlesBySlugs(ctx context.Context, slugs []string) ([]entity.NewsArticleWithTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticlesBySlugs", ctx, slugs)
+	ret0, _ := ret[0].([]entity.NewsArticleWithTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticlesBySlugs indicates an expected call of GetArticlesBySlugs.
+func (mr *MockNewsArticlesRepositoryMockRecorder) GetArticlesBySlugs(ctx, slugs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesBySlugs", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetArticlesBySlugs), ctx, slugs)
+}
+
+// GetByRetiredSlug mocks base method.
+func (m *MockNewsArticlesRepository) GetByRetiredSlug(ctx context.Context, slug string) (entity.RetiredSlug, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRetiredSlug", ctx, slug)
+	ret0, _ := ret[0].(entity.RetiredSlug)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRetiredSlug indicates an expected call of GetByRetiredSlug.
+func (mr *MockNewsArticlesRepositoryMockRecorder) GetByRetiredSlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRetiredSlug", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetByRetiredSlug), ctx, slug)
+}
+
+// GetPublishedByAuthor mocks base method.
+func (m *MockNewsArticlesRepository) GetPublishedByAuthor(ctx context.Context, authorID int, pagination dto.Pagination) ([]entity.PublishedNewsWithTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedByAuthor", ctx, authorID, pagination)
+	ret0, _ := ret[0].([]entity.PublishedNewsWithTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedByAuthor indicates an expected call of GetPublishedByAuthor.
+func (mr *MockNewsArticlesRepositoryMockRecorder) GetPublishedByAuthor(ctx, authorID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedByAuthor", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetPublishedByAuthor), ctx, authorID, pagination)
+}
+
+// GetTakenSlugs mocks base method.
+func (m *MockNewsArticlesRepository) GetTakenSlugs(ctx context.Context, base string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenSlugs", ctx, base)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenSlugs indicates an expected call of GetTakenSlugs.
+func (mr *MockNewsArticlesRepositoryMockRecorder) GetTakenSlugs(ctx, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenSlugs", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetTakenSlugs), ctx, base)
+}
+
+// GetTakenSlugsOf mocks base method.
+func (m *MockNewsArticlesRepository) GetTakenSlugsOf(ctx context.Context, bases []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenSlugsOf", ctx, bases)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenSlugsOf indicates an expected call of GetTakenSlugsOf.
+func (mr *MockNewsArticlesRepositoryMockRecorder) GetTakenSlugsOf(ctx, bases interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenSlugsOf", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetTakenSlugsOf), ctx, bases)
+}
+
+// GetTrashed mocks base method.
+func (m *MockNewsArticlesRepository) GetTrashed(ctx context.Context, pagination dto.Pagination) ([]entity.NewsArticleWithTopicID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashed", ctx, pagination)
+	ret0, _ := ret[0].([]entity.NewsArticleWithTopicID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashed indicates an expected call of GetTrashed.
+func (mr *MockNewsArticlesRepositoryMockRecorder) GetTrashed(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashed", reflect.TypeOf((*MockNewsArticlesRepository)(nil).GetTrashed), ctx, pagination)
+}
+
+// PublishDue mocks base method.
+func (m *MockNewsArticlesRepository) PublishDue(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDue", ctx, now, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDue indicates an expected call of PublishDue.
+func (mr *MockNewsArticlesRepositoryMockRecorder) PublishDue(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDue", reflect.TypeOf((*MockNewsArticlesRepository)(nil).PublishDue), ctx, now, limit)
+}
+
+// Purge mocks base method.
+func (m *MockNewsArticlesRepository) Purge(ctx context.Context, slug string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, slug)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockNewsArticlesRepositoryMockRecorder) Purge(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockNewsArticlesRepository)(nil).Purge), ctx, slug)
+}
+
+// PurgeTrashed mocks base method.
+func (m *MockNewsArticlesRepository) PurgeTrashed(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashed", ctx, before, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashed indicates an expected call of PurgeTrashed.
+func (mr *MockNewsArticlesRepositoryMockRecorder) PurgeTrashed(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashed", reflect.TypeOf((*MockNewsArticlesRepository)(nil).PurgeTrashed), ctx, before, limit)
+}
+
+// Restore mocks base method.
+func (m *MockNewsArticlesRepository) Restore(ctx context.Context, slug string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, slug)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockNewsArticlesRepositoryMockRecorder) Restore(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockNewsArticlesRepository)(nil).Restore), ctx, slug)
+}
+
+// TouchArticles mocks base method.
+func (m *MockNewsArticlesRepository) TouchArticles(ctx context.Context, ids []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchArticles", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchArticles indicates an expected call of TouchArticles.
+func (mr *MockNewsArticlesRepositoryMockRecorder) TouchArticles(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchArticles", reflect.TypeOf((*MockNewsArticlesRepository)(nil).TouchArticles), ctx, ids)
+}
+
 // UpdateArticleFields mocks base method.
 func (m *MockNewsArticlesRepository) UpdateArticleFields(ctx context.Context, entity *entity.NewsArticleWithTopic, updateFields []string) error {
 	m.ctrl.T.Helper()
@@ -256,6 +888,36 @@ func (mr *MockNewsArticlesRepositoryMockRecorder) UpdateArticleFields(ctx, entit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticleFields", reflect.TypeOf((*MockNewsArticlesRepository)(nil).UpdateArticleFields), ctx, entity, updateFields)
 }
 
+// UpdateStatus mocks base method.
+func (m *MockNewsArticlesRepository) UpdateStatus(ctx context.Context, entity *entity.NewsArticleWithTopic, from entity.ArticleStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, entity, from)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockNewsArticlesRepositoryMockRecorder) UpdateStatus(ctx, entity, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockNewsArticlesRepository)(nil).UpdateStatus), ctx, entity, from)
+}
+
+// UpdateStatuses mocks base method.
+func (m *MockNewsArticlesRepository) UpdateStatuses(ctx context.Context, articles []entity.NewsArticleWithTopic, to entity.ArticleStatus, reviewerID *int, comment *string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatuses", ctx, articles, to, reviewerID, comment)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatuses indicates an expected call of UpdateStatuses.
+func (mr *MockNewsArticlesRepositoryMockRecorder) UpdateStatuses(ctx, articles, to, reviewerID, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatuses", reflect.TypeOf((*MockNewsArticlesRepository)(nil).UpdateStatuses), ctx, articles, to, reviewerID, comment)
+}
+
 // MockNewsTopicsRepository is a mock of NewsTopicsRepository interface.
 type MockNewsTopicsRepository struct {
 	ctrl     *gomock.Controller
@@ -279,6 +941,20 @@ func (m *MockNewsTopicsRepository) EXPECT() *MockNewsTopicsRepositoryMockRecorde
 	return m.recorder
 }
 
+// AddTopics mocks base method.
+func (m *MockNewsTopicsRepository) AddTopics(ctx context.Context, articleIDs, topicIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTopics", ctx, articleIDs, topicIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTopics indicates an expected call of AddTopics.
+func (mr *MockNewsTopicsRepositoryMockRecorder) AddTopics(ctx, articleIDs, topicIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTopics", reflect.TypeOf((*MockNewsTopicsRepository)(nil).AddTopics), ctx, articleIDs, topicIDs)
+}
+
 // Create mocks base method.
 func (m *MockNewsTopicsRepository) Create(ctx context.Context, articleID int, topicIDs []int) error {
 	m.ctrl.T.Helper()
@@ -293,6 +969,20 @@ func (mr *MockNewsTopicsRepositoryMockRecorder) Create(ctx, articleID, topicIDs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNewsTopicsRepository)(nil).Create), ctx, articleID, topicIDs)
 }
 
+// CreateLinks mocks base method.
+func (m *MockNewsTopicsRepository) CreateLinks(ctx context.Context, articleIDs, topicIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLinks", ctx, articleIDs, topicIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLinks indicates an expected call of CreateLinks.
+func (mr *MockNewsTopicsRepositoryMockRecorder) CreateLinks(ctx, articleIDs, topicIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLinks", reflect.TypeOf((*MockNewsTopicsRepository)(nil).CreateLinks), ctx, articleIDs, topicIDs)
+}
+
 // DeleteByArticleID mocks base method.
 func (m *MockNewsTopicsRepository) DeleteByArticleID(ctx context.Context, articleID int) error {
 	m.ctrl.T.Helper()
@@ -307,6 +997,64 @@ func (mr *MockNewsTopicsRepositoryMockRecorder) DeleteByArticleID(ctx, articleID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleID", reflect.TypeOf((*MockNewsTopicsRepository)(nil).DeleteByArticleID), ctx, articleID)
 }
 
+// DeleteByArticleIDs mocks base method.
+func (m *MockNewsTopicsRepository) DeleteByArticleIDs(ctx context.Context, articleIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIDs", ctx, articleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIDs indicates an expected call of DeleteByArticleIDs.
+func (mr *MockNewsTopicsRepositoryMockRecorder) DeleteByArticleIDs(ctx, articleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIDs", reflect.TypeOf((*MockNewsTopicsRepository)(nil).DeleteByArticleIDs), ctx, articleIDs)
+}
+
+// DetachTopic mocks base method.
+func (m *MockNewsTopicsRepository) DetachTopic(ctx context.Context, topicID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachTopic", ctx, topicID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachTopic indicates an expected call of DetachTopic.
+func (mr *MockNewsTopicsRepositoryMockRecorder) DetachTopic(ctx, topicID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachTopic", reflect.TypeOf((*MockNewsTopicsRepository)(nil).DetachTopic), ctx, topicID)
+}
+
+// MoveTopic mocks base method.
+func (m *MockNewsTopicsRepository) MoveTopic(ctx context.Context, fromID, toID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTopic", ctx, fromID, toID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTopic indicates an expected call of MoveTopic.
+func (mr *MockNewsTopicsRepositoryMockRecorder) MoveTopic(ctx, fromID, toID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTopic", reflect.TypeOf((*MockNewsTopicsRepository)(nil).MoveTopic), ctx, fromID, toID)
+}
+
+// RemoveTopics mocks base method.
+func (m *MockNewsTopicsRepository) RemoveTopics(ctx context.Context, articleIDs, topicIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTopics", ctx, articleIDs, topicIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTopics indicates an expected call of RemoveTopics.
+func (mr *MockNewsTopicsRepositoryMockRecorder) RemoveTopics(ctx, articleIDs, topicIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTopics", reflect.TypeOf((*MockNewsTopicsRepository)(nil).RemoveTopics), ctx, articleIDs, topicIDs)
+}
+
 // ReplaceArticleTopics mocks base method.
 func (m *MockNewsTopicsRepository) ReplaceArticleTopics(ctx context.Context, articleID int, topicIDs []int32) error {
 	m.ctrl.T.Helper()
@@ -320,3 +1068,99 @@ func (mr *MockNewsTopicsRepositoryMockRecorder) ReplaceArticleTopics(ctx, articl
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceArticleTopics", reflect.TypeOf((*MockNewsTopicsRepository)(nil).ReplaceArticleTopics), ctx, articleID, topicIDs)
 }
+
+// MockArticleRevisionsRepository is a mock of ArticleRevisionsRepository interface.
+type MockArticleRevisionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleRevisionsRepositoryMockRecorder
+}
+
+// MockArticleRevisionsRepositoryMockRecorder is the mock recorder for MockArticleRevisionsRepository.
+type MockArticleRevisionsRepositoryMockRecorder struct {
+	mock *MockArticleRevisionsRepository
+}
+
+// NewMockArticleRevisionsRepository creates a new mock instance.
+func NewMockArticleRevisionsRepository(ctrl *gomock.Controller) *MockArticleRevisionsRepository {
+	mock := &MockArticleRevisionsRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleRevisionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleRevisionsRepository) EXPECT() *MockArticleRevisionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByArticleID mocks base method.
+func (m *MockArticleRevisionsRepository) CountByArticleID(ctx context.Context, articleID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByArticleID", ctx, articleID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByArticleID indicates an expected call of CountByArticleID.
+func (mr *MockArticleRevisionsRepositoryMockRecorder) CountByArticleID(ctx, articleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByArticleID", reflect.TypeOf((*MockArticleRevisionsRepository)(nil).CountByArticleID), ctx, articleID)
+}
+
+// Create mocks base method.
+func (m *MockArticleRevisionsRepository) Create(ctx context.Context, revision *entity.ArticleRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleRevisionsRepositoryMockRecorder) Create(ctx, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRevisionsRepository)(nil).Create), ctx, revision)
+}
+
+// CreateSnapshots mocks base method.
+func (m *MockArticleRevisionsRepository) CreateSnapshots(ctx context.Context, articleIDs []int, editorID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshots", ctx, articleIDs, editorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSnapshots indicates an expected call of CreateSnapshots.
+func (mr *MockArticleRevisionsRepositoryMockRecorder) CreateSnapshots(ctx, articleIDs, editorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshots", reflect.TypeOf((*MockArticleRevisionsRepository)(nil).CreateSnapshots), ctx, articleIDs, editorID)
+}
+
+// GetByArticleID mocks base method.
+func (m *MockArticleRevisionsRepository) GetByArticleID(ctx context.Context, articleID int, pagination dto.Pagination) ([]entity.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleID", ctx, articleID, pagination)
+	ret0, _ := ret[0].([]entity.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleID indicates an expected call of GetByArticleID.
+func (mr *MockArticleRevisionsRepositoryMockRecorder) GetByArticleID(ctx, articleID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleID", reflect.TypeOf((*MockArticleRevisionsRepository)(nil).GetByArticleID), ctx, articleID, pagination)
+}
+
+// GetByRevision mocks base method.
+func (m *MockArticleRevisionsRepository) GetByRevision(ctx context.Context, articleID, revision int) (entity.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRevision", ctx, articleID, revision)
+	ret0, _ := ret[0].(entity.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRevision indicates an expected call of GetByRevision.
+func (mr *MockArticleRevisionsRepositoryMockRecorder) GetByRevision(ctx, articleID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRevision", reflect.TypeOf((*MockArticleRevisionsRepository)(nil).GetByRevision), ctx, articleID, revision)
+}
@@ -9,7 +9,9 @@ import (
 	dto "newsapi/internal/model/dto"
 	request "newsapi/internal/model/request"
 	response "newsapi/internal/model/response"
+	usecase "newsapi/internal/usecase"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -51,6 +53,147 @@ func (mr *MockUsersUsecaseMockRecorder) CreateUser(ctx, body interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsersUsecase)(nil).CreateUser), ctx, body)
 }
 
+// DeactivateUser mocks base method.
+func (m *MockUsersUsecase) DeactivateUser(ctx context.Context, actor dto.AuthUser, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockUsersUsecaseMockRecorder) DeactivateUser(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockUsersUsecase)(nil).DeactivateUser), ctx, actor, id)
+}
+
+// GetUser mocks base method.
+func (m *MockUsersUsecase) GetUser(ctx context.Context, actor dto.AuthUser, id int) (response.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, actor, id)
+	ret0, _ := ret[0].(response.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUsersUsecaseMockRecorder) GetUser(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUsersUsecase)(nil).GetUser), ctx, actor, id)
+}
+
+// GetUsers mocks base method.
+func (m *MockUsersUsecase) GetUsers(ctx context.Context, actor dto.AuthUser, pagination dto.Pagination) ([]response.User, response.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, actor, pagination)
+	ret0, _ := ret[0].([]response.User)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUsersUsecaseMockRecorder) GetUsers(ctx, actor, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUsersUsecase)(nil).GetUsers), ctx, actor, pagination)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUsersUsecase) UpdateUser(ctx context.Context, actor dto.AuthUser, id int, body request.UpdateUserRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, actor, id, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUsersUsecaseMockRecorder) UpdateUser(ctx, actor, id, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUsersUsecase)(nil).UpdateUser), ctx, actor, id, body)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUsersUsecase) UpdateUserRole(ctx context.Context, actor dto.AuthUser, id int, body request.UpdateUserRoleRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, actor, id, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUsersUsecaseMockRecorder) UpdateUserRole(ctx, actor, id, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUsersUsecase)(nil).UpdateUserRole), ctx, actor, id, body)
+}
+
+// MockAuthUsecase is a mock of AuthUsecase interface.
+type MockAuthUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthUsecaseMockRecorder
+}
+
+// MockAuthUsecaseMockRecorder is the mock recorder for MockAuthUsecase.
+type MockAuthUsecaseMockRecorder struct {
+	mock *MockAuthUsecase
+}
+
+// NewMockAuthUsecase creates a new mock instance.
+func NewMockAuthUsecase(ctrl *gomock.Controller) *MockAuthUsecase {
+	mock := &MockAuthUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuthUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthUsecase) EXPECT() *MockAuthUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Actor mocks base method.
+func (m *MockAuthUsecase) Actor(ctx context.Context, userID int) (dto.AuthUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Actor", ctx, userID)
+	ret0, _ := ret[0].(dto.AuthUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Actor indicates an expected call of Actor.
+func (mr *MockAuthUsecaseMockRecorder) Actor(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Actor", reflect.TypeOf((*MockAuthUsecase)(nil).Actor), ctx, userID)
+}
+
+// Login mocks base method.
+func (m *MockAuthUsecase) Login(ctx context.Context, body request.LoginRequest) (response.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, body)
+	ret0, _ := ret[0].(response.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthUsecaseMockRecorder) Login(ctx, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUsecase)(nil).Login), ctx, body)
+}
+
+// RefreshToken mocks base method.
+func (m *MockAuthUsecase) RefreshToken(ctx context.Context, body request.RefreshTokenRequest) (response.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, body)
+	ret0, _ := ret[0].(response.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockAuthUsecaseMockRecorder) RefreshToken(ctx, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthUsecase)(nil).RefreshToken), ctx, body)
+}
+
 // MockNewsUsecase is a mock of NewsUsecase interface.
 type MockNewsUsecase struct {
 	ctrl     *gomock.Controller
@@ -74,76 +217,286 @@ func (m *MockNewsUsecase) EXPECT() *MockNewsUsecaseMockRecorder {
 	return m.recorder
 }
 
+// BulkNews mocks base method.
+func (m *MockNewsUsecase) BulkNews(ctx context.Context, actor dto.AuthUser, body request.BulkNewsRequest) (response.BulkNewsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkNews", ctx, actor, body)
+	ret0, _ := ret[0].(response.BulkNewsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkNews indicates an expected call of BulkNews.
+func (mr *MockNewsUsecaseMockRecorder) BulkNews(ctx, actor, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkNews", reflect.TypeOf((*MockNewsUsecase)(nil).BulkNews), ctx, actor, body)
+}
+
 // CreateNewsArticle mocks base method.
-func (m *MockNewsUsecase) CreateNewsArticle(ctx context.Context, body request.CreateNewsArticleRequest) error {
+func (m *MockNewsUsecase) CreateNewsArticle(ctx context.Context, actor dto.AuthUser, body request.CreateNewsArticleRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNewsArticle", ctx, body)
+	ret := m.ctrl.Call(m, "CreateNewsArticle", ctx, actor, body)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateNewsArticle indicates an expected call of CreateNewsArticle.
-func (mr *MockNewsUsecaseMockRecorder) CreateNewsArticle(ctx, body interface{}) *gomock.Call {
+func (mr *MockNewsUsecaseMockRecorder) CreateNewsArticle(ctx, actor, body interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewsArticle", reflect.TypeOf((*MockNewsUsecase)(nil).CreateNewsArticle), ctx, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewsArticle", reflect.TypeOf((*MockNewsUsecase)(nil).CreateNewsArticle), ctx, actor, body)
 }
 
 // DeleteNewsArticleBySlug mocks base method.
-func (m *MockNewsUsecase) DeleteNewsArticleBySlug(ctx context.Context, slug string) error {
+func (m *MockNewsUsecase) DeleteNewsArticleBySlug(ctx context.Context, actor dto.AuthUser, slug string, expectedVersion *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNewsArticleBySlug", ctx, slug)
+	ret := m.ctrl.Call(m, "DeleteNewsArticleBySlug", ctx, actor, slug, expectedVersion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNewsArticleBySlug indicates an expected call of DeleteNewsArticleBySlug.
-func (mr *MockNewsUsecaseMockRecorder) DeleteNewsArticleBySlug(ctx, slug interface{}) *gomock.Call {
+func (mr *MockNewsUsecaseMockRecorder) DeleteNewsArticleBySlug(ctx, actor, slug, expectedVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNewsArticleBySlug", reflect.TypeOf((*MockNewsUsecase)(nil).DeleteNewsArticleBySlug), ctx, slug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNewsArticleBySlug", reflect.TypeOf((*MockNewsUsecase)(nil).DeleteNewsArticleBySlug), ctx, actor, slug, expectedVersion)
+}
+
+// DiffNewsRevisions mocks base method.
+func (m *MockNewsUsecase) DiffNewsRevisions(ctx context.Context, actor dto.AuthUser, slug string, from, to int) (response.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffNewsRevisions", ctx, actor, slug, from, to)
+	ret0, _ := ret[0].(response.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffNewsRevisions indicates an expected call of DiffNewsRevisions.
+func (mr *MockNewsUsecaseMockRecorder) DiffNewsRevisions(ctx, actor, slug, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffNewsRevisions", reflect.TypeOf((*MockNewsUsecase)(nil).DiffNewsRevisions), ctx, actor, slug, from, to)
+}
+
+// ExportNews mocks base method.
+func (m *MockNewsUsecase) ExportNews(ctx context.Context, actor dto.AuthUser, filter dto.NewsFilter, write func(response.NewsExport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportNews", ctx, actor, filter, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportNews indicates an expected call of ExportNews.
+func (mr *MockNewsUsecaseMockRecorder) ExportNews(ctx, actor, filter, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportNews", reflect.TypeOf((*MockNewsUsecase)(nil).ExportNews), ctx, actor, filter, write)
+}
+
+// GetAuthorArticles mocks base method.
+func (m *MockNewsUsecase) GetAuthorArticles(ctx context.Context, authorID int, pagination dto.Pagination) (response.AuthorProfile, response.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorArticles", ctx, authorID, pagination)
+	ret0, _ := ret[0].(response.AuthorProfile)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAuthorArticles indicates an expected call of GetAuthorArticles.
+func (mr *MockNewsUsecaseMockRecorder) GetAuthorArticles(ctx, authorID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorArticles", reflect.TypeOf((*MockNewsUsecase)(nil).GetAuthorArticles), ctx, authorID, pagination)
 }
 
 // GetNewsArticleBySlug mocks base method.
-func (m *MockNewsUsecase) GetNewsArticleBySlug(ctx context.Context, slug string) (response.NewsArticleWithTopic, error) {
+func (m *MockNewsUsecase) GetNewsArticleBySlug(ctx context.Context, viewer dto.AuthUser, slug string) (response.NewsArticleWithTopic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNewsArticleBySlug", ctx, slug)
+	ret := m.ctrl.Call(m, "GetNewsArticleBySlug", ctx, viewer, slug)
 	ret0, _ := ret[0].(response.NewsArticleWithTopic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNewsArticleBySlug indicates an expected call of GetNewsArticleBySlug.
-func (mr *MockNewsUsecaseMockRecorder) GetNewsArticleBySlug(ctx, slug interface{}) *gomock.Call {
+func (mr *MockNewsUsecaseMockRecorder) GetNewsArticleBySlug(ctx, viewer, slug interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsArticleBySlug", reflect.TypeOf((*MockNewsUsecase)(nil).GetNewsArticleBySlug), ctx, slug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsArticleBySlug", reflect.TypeOf((*MockNewsUsecase)(nil).GetNewsArticleBySlug), ctx, viewer, slug)
 }
 
 // GetNewsArticles mocks base method.
-func (m *MockNewsUsecase) GetNewsArticles(ctx context.Context, filter dto.NewsFilter) ([]response.NewsArticle, error) {
+func (m *MockNewsUsecase) GetNewsArticles(ctx context.Context, viewer dto.AuthUser, filter dto.NewsFilter) ([]response.NewsArticle, response.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewsArticles", ctx, viewer, filter)
+	ret0, _ := ret[0].([]response.NewsArticle)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNewsArticles indicates an expected call of GetNewsArticles.
+func (mr *MockNewsUsecaseMockRecorder) GetNewsArticles(ctx, viewer, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsArticles", reflect.TypeOf((*MockNewsUsecase)(nil).GetNewsArticles), ctx, viewer, filter)
+}
+
+// GetNewsRevisions mocks base method.
+func (m *MockNewsUsecase) GetNewsRevisions(ctx context.Context, actor dto.AuthUser, slug string, pagination dto.Pagination) ([]response.ArticleRevision, response.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNewsArticles", ctx, filter)
+	ret := m.ctrl.Call(m, "GetNewsRevisions", ctx, actor, slug, pagination)
+	ret0, _ := ret[0].([]response.ArticleRevision)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNewsRevisions indicates an expected call of GetNewsRevisions.
+func (mr *MockNewsUsecaseMockRecorder) GetNewsRevisions(ctx, actor, slug, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsRevisions", reflect.TypeOf((*MockNewsUsecase)(nil).GetNewsRevisions), ctx, actor, slug, pagination)
+}
+
+// GetTrashedNews mocks base method.
+func (m *MockNewsUsecase) GetTrashedNews(ctx context.Context, actor dto.AuthUser, pagination dto.Pagination) ([]response.NewsArticle, response.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedNews", ctx, actor, pagination)
 	ret0, _ := ret[0].([]response.NewsArticle)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTrashedNews indicates an expected call of GetTrashedNews.
+func (mr *MockNewsUsecaseMockRecorder) GetTrashedNews(ctx, actor, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedNews", reflect.TypeOf((*MockNewsUsecase)(nil).GetTrashedNews), ctx, actor, pagination)
+}
+
+// ImportNews mocks base method.
+func (m *MockNewsUsecase) ImportNews(ctx context.Context, actor dto.AuthUser, source usecase.NewsImportSource, dryRun bool) (response.NewsImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportNews", ctx, actor, source, dryRun)
+	ret0, _ := ret[0].(response.NewsImport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNewsArticles indicates an expected call of GetNewsArticles.
-func (mr *MockNewsUsecaseMockRecorder) GetNewsArticles(ctx, filter interface{}) *gomock.Call {
+// ImportNews indicates an expected call of ImportNews.
+func (mr *MockNewsUsecaseMockRecorder) ImportNews(ctx, actor, source, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportNews", reflect.TypeOf((*MockNewsUsecase)(nil).ImportNews), ctx, actor, source, dryRun)
+}
+
+// PublishScheduledNews mocks base method.
+func (m *MockNewsUsecase) PublishScheduledNews(ctx context.Context, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduledNews", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduledNews indicates an expected call of PublishScheduledNews.
+func (mr *MockNewsUsecaseMockRecorder) PublishScheduledNews(ctx, batchSize interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsArticles", reflect.TypeOf((*MockNewsUsecase)(nil).GetNewsArticles), ctx, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduledNews", reflect.TypeOf((*MockNewsUsecase)(nil).PublishScheduledNews), ctx, batchSize)
+}
+
+// PurgeNewsArticle mocks base method.
+func (m *MockNewsUsecase) PurgeNewsArticle(ctx context.Context, actor dto.AuthUser, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNewsArticle", ctx, actor, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNewsArticle indicates an expected call of PurgeNewsArticle.
+func (mr *MockNewsUsecaseMockRecorder) PurgeNewsArticle(ctx, actor, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNewsArticle", reflect.TypeOf((*MockNewsUsecase)(nil).PurgeNewsArticle), ctx, actor, slug)
+}
+
+// PurgeTrashedNews mocks base method.
+func (m *MockNewsUsecase) PurgeTrashedNews(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashedNews", ctx, before, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashedNews indicates an expected call of PurgeTrashedNews.
+func (mr *MockNewsUsecaseMockRecorder) PurgeTrashedNews(ctx, before, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashedNews", reflect.TypeOf((*MockNewsUsecase)(nil).PurgeTrashedNews), ctx, before, batchSize)
+}
+
+// ResolveNewsSlug mocks base method.
+func (m *MockNewsUsecase) ResolveNewsSlug(ctx context.Context, viewer dto.AuthUser, slug string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveNewsSlug", ctx, viewer, slug)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveNewsSlug indicates an expected call of ResolveNewsSlug.
+func (mr *MockNewsUsecaseMockRecorder) ResolveNewsSlug(ctx, viewer, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveNewsSlug", reflect.TypeOf((*MockNewsUsecase)(nil).ResolveNewsSlug), ctx, viewer, slug)
+}
+
+// RestoreNewsArticle mocks base method.
+func (m *MockNewsUsecase) RestoreNewsArticle(ctx context.Context, actor dto.AuthUser, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNewsArticle", ctx, actor, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNewsArticle indicates an expected call of RestoreNewsArticle.
+func (mr *MockNewsUsecaseMockRecorder) RestoreNewsArticle(ctx, actor, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNewsArticle", reflect.TypeOf((*MockNewsUsecase)(nil).RestoreNewsArticle), ctx, actor, slug)
+}
+
+// RestoreNewsRevision mocks base method.
+func (m *MockNewsUsecase) RestoreNewsRevision(ctx context.Context, actor dto.AuthUser, slug string, revision int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNewsRevision", ctx, actor, slug, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNewsRevision indicates an expected call of RestoreNewsRevision.
+func (mr *MockNewsUsecaseMockRecorder) RestoreNewsRevision(ctx, actor, slug, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNewsRevision", reflect.TypeOf((*MockNewsUsecase)(nil).RestoreNewsRevision), ctx, actor, slug, revision)
+}
+
+// TransitionNewsArticle mocks base method.
+func (m *MockNewsUsecase) TransitionNewsArticle(ctx context.Context, actor dto.AuthUser, slug string, body request.TransitionNewsArticleRequest) (response.NewsStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionNewsArticle", ctx, actor, slug, body)
+	ret0, _ := ret[0].(response.NewsStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionNewsArticle indicates an expected call of TransitionNewsArticle.
+func (mr *MockNewsUsecaseMockRecorder) TransitionNewsArticle(ctx, actor, slug, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionNewsArticle", reflect.TypeOf((*MockNewsUsecase)(nil).TransitionNewsArticle), ctx, actor, slug, body)
 }
 
 // UpdateNewsArticleBySlug mocks base method.
-func (m *MockNewsUsecase) UpdateNewsArticleBySlug(ctx context.Context, slug string, body request.UpdateNewsArticleRequest) error {
+func (m *MockNewsUsecase) UpdateNewsArticleBySlug(ctx context.Context, actor dto.AuthUser, slug string, body request.UpdateNewsArticleRequest, expectedVersion *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNewsArticleBySlug", ctx, slug, body)
+	ret := m.ctrl.Call(m, "UpdateNewsArticleBySlug", ctx, actor, slug, body, expectedVersion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateNewsArticleBySlug indicates an expected call of UpdateNewsArticleBySlug.
-func (mr *MockNewsUsecaseMockRecorder) UpdateNewsArticleBySlug(ctx, slug, body interface{}) *gomock.Call {
+func (mr *MockNewsUsecaseMockRecorder) UpdateNewsArticleBySlug(ctx, actor, slug, body, expectedVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNewsArticleBySlug", reflect.TypeOf((*MockNewsUsecase)(nil).UpdateNewsArticleBySlug), ctx, slug, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNewsArticleBySlug", reflect.TypeOf((*MockNewsUsecase)(nil).UpdateNewsArticleBySlug), ctx, actor, slug, body, expectedVersion)
 }
 
 // MockTopicsUsecase is a mock of TopicsUsecase interface.
@@ -170,58 +523,178 @@ func (m *MockTopicsUsecase) EXPECT() *MockTopicsUsecaseMockRecorder {
 }
 
 // CreateTopic mocks base method.
-func (m *MockTopicsUsecase) CreateTopic(ctx context.Context, body request.CreateTopicRequest) error {
+func (m *MockTopicsUsecase) CreateTopic(ctx context.Context, actor dto.AuthUser, body request.CreateTopicRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTopic", ctx, body)
+	ret := m.ctrl.Call(m, "CreateTopic", ctx, actor, body)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateTopic indicates an expected call of CreateTopic.
-func (mr *MockTopicsUsecaseMockRecorder) CreateTopic(ctx, body interface{}) *gomock.Call {
+func (mr *MockTopicsUsecaseMockRecorder) CreateTopic(ctx, actor, body interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).CreateTopic), ctx, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).CreateTopic), ctx, actor, body)
 }
 
 // DeleteTopic mocks base method.
-func (m *MockTopicsUsecase) DeleteTopic(ctx context.Context, id int) error {
+func (m *MockTopicsUsecase) DeleteTopic(ctx context.Context, actor dto.AuthUser, id int, expectedVersion *int, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTopic", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteTopic", ctx, actor, id, expectedVersion, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTopic indicates an expected call of DeleteTopic.
-func (mr *MockTopicsUsecaseMockRecorder) DeleteTopic(ctx, id interface{}) *gomock.Call {
+func (mr *MockTopicsUsecaseMockRecorder) DeleteTopic(ctx, actor, id, expectedVersion, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).DeleteTopic), ctx, actor, id, expectedVersion, force)
+}
+
+// GetTopic mocks base method.
+func (m *MockTopicsUsecase) GetTopic(ctx context.Context, key string, latest int) (response.TopicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopic", ctx, key, latest)
+	ret0, _ := ret[0].(response.TopicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopic indicates an expected call of GetTopic.
+func (mr *MockTopicsUsecaseMockRecorder) GetTopic(ctx, key, latest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).GetTopic), ctx, key, latest)
+}
+
+// GetTopicTree mocks base method.
+func (m *MockTopicsUsecase) GetTopicTree(ctx context.Context) ([]response.TopicNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopicTree", ctx)
+	ret0, _ := ret[0].([]response.TopicNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopicTree indicates an expected call of GetTopicTree.
+func (mr *MockTopicsUsecaseMockRecorder) GetTopicTree(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).DeleteTopic), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopicTree", reflect.TypeOf((*MockTopicsUsecase)(nil).GetTopicTree), ctx)
 }
 
 // GetTopics mocks base method.
-func (m *MockTopicsUsecase) GetTopics(ctx context.Context) ([]response.Topic, error) {
+func (m *MockTopicsUsecase) GetTopics(ctx context.Context, pagination dto.Pagination, withCounts bool) ([]response.Topic, response.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTopics", ctx)
+	ret := m.ctrl.Call(m, "GetTopics", ctx, pagination, withCounts)
 	ret0, _ := ret[0].([]response.Topic)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTopics indicates an expected call of GetTopics.
+func (mr *MockTopicsUsecaseMockRecorder) GetTopics(ctx, pagination, withCounts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopics", reflect.TypeOf((*MockTopicsUsecase)(nil).GetTopics), ctx, pagination, withCounts)
+}
+
+// GetTrashedTopics mocks base method.
+func (m *MockTopicsUsecase) GetTrashedTopics(ctx context.Context, actor dto.AuthUser, pagination dto.Pagination) ([]response.Topic, response.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedTopics", ctx, actor, pagination)
+	ret0, _ := ret[0].([]response.Topic)
+	ret1, _ := ret[1].(response.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTrashedTopics indicates an expected call of GetTrashedTopics.
+func (mr *MockTopicsUsecaseMockRecorder) GetTrashedTopics(ctx, actor, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedTopics", reflect.TypeOf((*MockTopicsUsecase)(nil).GetTrashedTopics), ctx, actor, pagination)
+}
+
+// MergeTopic mocks base method.
+func (m *MockTopicsUsecase) MergeTopic(ctx context.Context, actor dto.AuthUser, id int, body request.MergeTopicRequest, expectedVersion *int) (response.TopicMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTopic", ctx, actor, id, body, expectedVersion)
+	ret0, _ := ret[0].(response.TopicMerge)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTopics indicates an expected call of GetTopics.
-func (mr *MockTopicsUsecaseMockRecorder) GetTopics(ctx interface{}) *gomock.Call {
+// MergeTopic indicates an expected call of MergeTopic.
+func (mr *MockTopicsUsecaseMockRecorder) MergeTopic(ctx, actor, id, body, expectedVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).MergeTopic), ctx, actor, id, body, expectedVersion)
+}
+
+// PurgeTopic mocks base method.
+func (m *MockTopicsUsecase) PurgeTopic(ctx context.Context, actor dto.AuthUser, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTopic", ctx, actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTopic indicates an expected call of PurgeTopic.
+func (mr *MockTopicsUsecaseMockRecorder) PurgeTopic(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).PurgeTopic), ctx, actor, id)
+}
+
+// PurgeTrashedTopics mocks base method.
+func (m *MockTopicsUsecase) PurgeTrashedTopics(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashedTopics", ctx, before, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashedTopics indicates an expected call of PurgeTrashedTopics.
+func (mr *MockTopicsUsecaseMockRecorder) PurgeTrashedTopics(ctx, before, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashedTopics", reflect.TypeOf((*MockTopicsUsecase)(nil).PurgeTrashedTopics), ctx, before, batchSize)
+}
+
+// ResolveTopicSlug mocks base method.
+func (m *MockTopicsUsecase) ResolveTopicSlug(ctx context.Context, slug string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTopicSlug", ctx, slug)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveTopicSlug indicates an expected call of ResolveTopicSlug.
+func (mr *MockTopicsUsecaseMockRecorder) ResolveTopicSlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTopicSlug", reflect.TypeOf((*MockTopicsUsecase)(nil).ResolveTopicSlug), ctx, slug)
+}
+
+// RestoreTopic mocks base method.
+func (m *MockTopicsUsecase) RestoreTopic(ctx context.Context, actor dto.AuthUser, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTopic", ctx, actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTopic indicates an expected call of RestoreTopic.
+func (mr *MockTopicsUsecaseMockRecorder) RestoreTopic(ctx, actor, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopics", reflect.TypeOf((*MockTopicsUsecase)(nil).GetTopics), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).RestoreTopic), ctx, actor, id)
 }
 
 // UpdateTopic mocks base method.
-func (m *MockTopicsUsecase) UpdateTopic(ctx context.Context, id int, body request.UpdateTopicRequest) error {
+func (m *MockTopicsUsecase) UpdateTopic(ctx context.Context, actor dto.AuthUser, id int, body request.UpdateTopicRequest, expectedVersion *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTopic", ctx, id, body)
+	ret := m.ctrl.Call(m, "UpdateTopic", ctx, actor, id, body, expectedVersion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTopic indicates an expected call of UpdateTopic.
-func (mr *MockTopicsUsecaseMockRecorder) UpdateTopic(ctx, id, body interface{}) *gomock.Call {
+func (mr *MockTopicsUsecaseMockRecorder) UpdateTopic(ctx, actor, id, body, expectedVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).UpdateTopic), ctx, id, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTopic", reflect.TypeOf((*MockTopicsUsecase)(nil).UpdateTopic), ctx, actor, id, body, expectedVersion)
 }